@@ -1,20 +1,173 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"fmt"
-	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 	"tiny-blockchain-app/app/config"
+	"tiny-blockchain-app/app/pkg/batch"
+	"tiny-blockchain-app/app/pkg/blockchain/client"
+	"tiny-blockchain-app/app/pkg/blockchain/event"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+	"tiny-blockchain-app/app/pkg/blockchain/monitor"
+	"tiny-blockchain-app/app/pkg/blockchain/quorum"
+	"tiny-blockchain-app/app/pkg/lifecycle"
+	"tiny-blockchain-app/app/pkg/logging"
 	"tiny-blockchain-app/app/pkg/restapi"
 )
 
+var logger = logging.New("main")
+
 func main() {
+	// 설정 파일을 읽기 전까지는 기본 설정(info, logfmt)으로 출력
+	logging.Setup(os.Stderr, false, "")
+
+	conf := loadConfig()
+	blockchainConfig := conf.BlockChain()
+	logging.Setup(os.Stderr, blockchainConfig.DebugMode, conf.Log().Format)
+	logger.Info("Loaded config", "endpoint", blockchainConfig.EndPoint, "endpoints", blockchainConfig.EndPoints,
+		"websocket", blockchainConfig.WebSocket, "debugMode", blockchainConfig.DebugMode, "wallet", conf.Wallet())
+
+	signer, err := conf.Wallet().LoadSigner()
+	if err != nil {
+		logger.Crit("Failed to load wallet", "err", err)
+	}
+	if signer != nil {
+		logger.Info("Loaded signer", "address", signer.Address())
+	}
+
+	controller, err := client.NewEthereumController(blockchainConfig, signer)
+	if err != nil {
+		logger.Crit("Failed to connect blockchain", "err", err)
+	}
+	contracts, err := conf.Contracts()
+	if err != nil {
+		logger.Crit("Failed to load contracts config", "err", err)
+	}
+	if err := controller.Contracts.Load(contracts); err != nil {
+		logger.Crit("Failed to register contracts", "err", err)
+	}
+	lifecycleConfig := conf.Lifecycle()
+	if lifecycleConfig.CheckpointFile != "" {
+		controller.Checkpoints = event.NewFileCheckpointStore(lifecycleConfig.CheckpointFile)
+	}
+	if batchConfig := conf.Batch(); batchConfig.Dir != "" {
+		controller.Batches = batch.NewFileStore(batchConfig.Dir)
+	}
+	journalConfig := conf.Journal()
+	if journalConfig.File != "" {
+		if err := controller.Journal.SetStore(journal.NewFileStore(journalConfig.File)); err != nil {
+			logger.Crit("Failed to load transaction journal", "file", journalConfig.File, "err", err)
+		}
+	}
+	if journalConfig.RebroadcastIntervalSec > 0 {
+		controller.Journal.RebroadcastInterval = time.Duration(journalConfig.RebroadcastIntervalSec) * time.Second
+	}
+	if journalConfig.MaxAttempts > 0 {
+		controller.Journal.MaxAttempts = journalConfig.MaxAttempts
+	}
+
+	// raft API 는 선택 (연결하지 못하면 raft 요청은 503)
+	var raft *quorum.RaftClient
+	if endpoint := conf.PrimaryEndpoint(); endpoint != "" {
+		if raft, err = quorum.Dial(endpoint); err != nil {
+			logger.Warn("Failed to connect raft api, raft endpoints are disabled", "endpoint", endpoint, "err", err)
+			raft = nil
+		}
+	}
+
+	server := restapi.NewServer(controller.Client, signer)
+	server.SetRaftClient(raft)
+	server.SetNonceErrRetryCnt(blockchainConfig.NonceErrRetryCnt)
+	server.SetTxManager(controller.Transactions)
+	server.SetJournal(controller.Journal)
+
+	authConfig, err := conf.Auth()
+	if err != nil {
+		logger.Crit("Failed to load auth config", "err", err)
+	}
+	auth, err := restapi.NewAuthenticator(authConfig)
+	if err != nil {
+		logger.Crit("Invalid auth config", "err", err)
+	}
+	if !authConfig.Enabled {
+		logger.Warn("REST API authentication is disabled")
+	}
+	server.SetAuth(auth)
+
+	rateLimitConfig, err := conf.RateLimit()
+	if err != nil {
+		logger.Crit("Failed to load rate limit config", "err", err)
+	}
+	if err := server.SetRateLimit(rateLimitConfig); err != nil {
+		logger.Crit("Failed to set rate limit", "err", err)
+	}
+	if err := server.SetIdempotency(conf.Idempotency()); err != nil {
+		logger.Crit("Failed to load idempotency keys", "err", err)
+	}
 
-	blockchainConfig := loadConfig().BlockChain()
-	fmt.Println(blockchainConfig)
+	monitorConfig, err := conf.Monitor()
+	if err != nil {
+		logger.Crit("Failed to load monitor config", "err", err)
+	}
+
+	// 등록 순서대로 시작하고 SIGINT, SIGTERM 을 받으면 역순으로 종료
+	// (REST 서버가 처리 중인 요청을 마친 뒤 블록체인 연결의 트랜잭션 대기, 구독 checkpoint 저장, 연결 종료)
+	app := lifecycle.New(time.Duration(lifecycleConfig.ShutdownTimeoutSec) * time.Second)
+	app.Add(lifecycle.Component{
+		Name: "blockchain",
+		Stop: controller.Shutdown,
+	})
+	app.Add(lifecycle.Component{
+		Name: "journal",
+		Start: func(ctx context.Context) error {
+			app.Go("journal", func(ctx context.Context) error {
+				controller.Journal.Run(ctx, controller.Journal.RebroadcastInterval)
+				return nil
+			})
+			return nil
+		},
+	})
+	if len(monitorConfig.Nodes) > 0 {
+		nodeMonitor, err := monitor.New(monitorConfig)
+		if err != nil {
+			logger.Crit("Failed to start node monitor", "err", err)
+		}
+		server.SetMonitor(nodeMonitor)
+		app.Add(lifecycle.Component{
+			Name: "monitor",
+			Start: func(ctx context.Context) error {
+				app.Go("monitor", func(ctx context.Context) error {
+					nodeMonitor.Start(ctx)
+					return nil
+				})
+				return nil
+			},
+		})
+	}
 
-	restapi.NewServer()
+	address := conf.RestAPI().Address
+	app.Add(lifecycle.Component{
+		Name: "restapi",
+		Start: func(ctx context.Context) error {
+			logger.Info("Starting REST API server", "address", address)
+			app.Go("restapi", func(ctx context.Context) error {
+				return server.Start(address)
+			})
+			return nil
+		},
+		Stop: server.Shutdown,
+	})
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := app.Run(ctx); err != nil {
+		logger.Crit("Stopped with error", "err", err)
+	}
+	logger.Info("Stopped")
 }
 
 func loadConfig() *config.Config {
@@ -26,7 +179,7 @@ func loadConfig() *config.Config {
 	// load configs from file
 	conf, err := config.New(configFilePath, configFileName, "yaml")
 	if err != nil {
-		log.Fatalln("failed to load config file - ", err.Error())
+		logger.Crit("Failed to load config file", "err", err)
 	}
 	return conf
 }
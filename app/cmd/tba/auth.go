@@ -0,0 +1,61 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"strings"
+	"time"
+	"tiny-blockchain-app/app/pkg/restapi"
+)
+
+// authKey : 새 API key 와 설정 파일(auth.apiKeys[].keySha256)에 넣을 해시 출력
+func authKey(a *app, args []string) error {
+	flags := flag.NewFlagSet("auth key", flag.ExitOnError)
+	key := flags.String("key", "", "Existing API key to hash (default: generate a new key)")
+	flags.Parse(args)
+
+	if *key == "" {
+		random := make([]byte, 32)
+		if _, err := rand.Read(random); err != nil {
+			return err
+		}
+		*key = hex.EncodeToString(random)
+	}
+
+	hash := restapi.HashAPIKey(*key)
+	result := map[string]string{"key": *key, "keySha256": hash}
+	return a.printer.object(result, [][2]string{{"KEY", *key}, {"KEY_SHA256", hash}})
+}
+
+// authToken : 설정의 auth.jwtSecret 으로 JWT 발급
+func authToken(a *app, args []string) error {
+	flags := flag.NewFlagSet("auth token", flag.ExitOnError)
+	subject := flags.String("sub", "", "Caller name")
+	role := flags.String("role", restapi.RoleViewer, "Role (viewer|operator|token-admin|admin)")
+	accounts := flags.String("accounts", "", "Comma separated accounts the caller may sign with (\"*\" for any)")
+	ttl := flags.Duration("ttl", 24*time.Hour, "Token lifetime")
+	flags.Parse(args)
+
+	if *subject == "" {
+		return errors.New("--sub is required")
+	}
+	authConfig, err := a.conf.Auth()
+	if err != nil {
+		return err
+	}
+	if authConfig.JWTSecret == "" {
+		return errors.New("auth.jwtSecret is not configured")
+	}
+
+	var signers []string
+	if *accounts != "" {
+		signers = strings.Split(*accounts, ",")
+	}
+	token, err := restapi.IssueToken(authConfig.JWTSecret, authConfig.JWTIssuer, *subject, *role, signers, *ttl)
+	if err != nil {
+		return err
+	}
+	return a.printer.object(map[string]string{"token": token}, [][2]string{{"TOKEN", token}})
+}
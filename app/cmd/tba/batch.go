@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"tiny-blockchain-app/app/pkg/batch"
+	"tiny-blockchain-app/app/pkg/blockchain/client"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// tokenBatchTransfer : CSV(address,amount) 또는 JSON 파일의 수신자에게 일괄 전송
+// 중단되거나 실패하면 같은 --job 으로 다시 실행하여 이어서 처리 (이미 보낸 행은 다시 보내지 않음)
+func tokenBatchTransfer(a *app, args []string) error {
+	batchConfig := a.conf.Batch()
+	flags := flag.NewFlagSet("token batch-transfer", flag.ExitOnError)
+	contractAddress := flags.String("contract", "", "Token contract address")
+	file := flags.String("file", "", "Recipients file (.csv: address,amount / .json: [{address, amount}])")
+	jobID := flags.String("job", "", "Job id to create or resume (default: file name without extension)")
+	dir := flags.String("dir", batchConfig.Dir, "Job directory")
+	maxInFlight := flags.Int("max-in-flight", batchConfig.MaxInFlight, "Transactions sent before waiting for receipts")
+	report := flags.String("report", "", "Write a per-row CSV report to this path")
+	flags.Parse(args)
+
+	if err := requireAddresses(map[string]string{"contract": *contractAddress}); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("--file is required")
+	}
+	if *jobID == "" {
+		*jobID = strings.TrimSuffix(filepath.Base(*file), filepath.Ext(*file))
+	}
+	if *dir == "" {
+		return errors.New("--dir or batch.dir is required")
+	}
+
+	rows, err := batch.ParseFile(*file)
+	if err != nil {
+		return err
+	}
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	records, err := a.records(a.conf.Journal().File)
+	if err != nil {
+		return err
+	}
+
+	// 서버와 같이 교체(speed-up, cancel)된 행의 receipt 를 따라가도록 txmanager 로 감쌈
+	manager := txmanager.New(cli)
+	manager.Records = records
+	transferer, err := batch.NewTransferer(client.NewNonceManager(cli).Backend(manager.Backend()), signer, batch.NewFileStore(*dir))
+	if err != nil {
+		return err
+	}
+	transferer.MaxInFlight = *maxInFlight
+	transferer.Records = records
+
+	// Ctrl-C 로 중단해도 작업 파일은 남아 있으므로 다시 실행하면 이어서 처리
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	job, runErr := transferer.Run(ctx, *jobID, common.HexToAddress(*contractAddress), rows)
+	if job == nil {
+		return runErr
+	}
+
+	if *report != "" {
+		if err := writeReport(job, *report); err != nil {
+			return err
+		}
+	}
+	if err := a.printSummary(job); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return fmt.Errorf("%v (run again with --job %s to resume)", runErr, job.ID)
+	}
+	return nil
+}
+
+// tokenBatchStatus : 저장된 일괄 전송 작업의 행별 상태
+func tokenBatchStatus(a *app, args []string) error {
+	flags := flag.NewFlagSet("token batch-status", flag.ExitOnError)
+	jobID := flags.String("job", "", "Job id")
+	dir := flags.String("dir", a.conf.Batch().Dir, "Job directory")
+	report := flags.String("report", "", "Write a per-row CSV report to this path")
+	flags.Parse(args)
+
+	if *jobID == "" {
+		return errors.New("--job is required")
+	}
+	job, err := batch.NewFileStore(*dir).Load(*jobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("job %s not found in %s", *jobID, *dir)
+	}
+	if *report != "" {
+		if err := writeReport(job, *report); err != nil {
+			return err
+		}
+	}
+
+	rows := make([][]string, 0, len(job.Transfers))
+	for _, t := range job.Transfers {
+		rows = append(rows, []string{strconv.Itoa(t.Line), t.To.Hex(), t.Amount, string(t.Status), t.TxHash, t.Error})
+	}
+	return a.printer.table(job, []string{"LINE", "ADDRESS", "AMOUNT", "STATUS", "TX HASH", "ERROR"}, rows)
+}
+
+func (a *app) printSummary(job *batch.Job) error {
+	summary := job.Summary()
+	return a.printer.object(map[string]interface{}{"job": job.ID, "summary": summary}, [][2]string{
+		{"JOB", job.ID},
+		{"TOTAL", strconv.Itoa(summary.Total)},
+		{"MINED", strconv.Itoa(summary.Mined)},
+		{"FAILED", strconv.Itoa(summary.Failed)},
+		{"UNKNOWN", strconv.Itoa(summary.Unknown)},
+		{"SENT", strconv.Itoa(summary.Sent)},
+		{"PENDING", strconv.Itoa(summary.Pending)},
+		{"AMOUNT", summary.Amount},
+	})
+}
+
+func writeReport(job *batch.Job, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := job.WriteReport(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
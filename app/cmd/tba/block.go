@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"math/big"
+	"strconv"
+	"tiny-blockchain-app/app/pkg/blockchain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func blockGet(a *app, args []string) error {
+	flags := flag.NewFlagSet("block get", flag.ExitOnError)
+	number := flags.String("number", "latest", "Block number or 'latest'")
+	flags.Parse(args)
+
+	var blockNumber *big.Int
+	if *number != "latest" {
+		var ok bool
+		blockNumber, ok = new(big.Int).SetString(*number, 0)
+		if !ok || blockNumber.Sign() < 0 {
+			return errors.New("--number must be a block number or 'latest'")
+		}
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	block, err := blockchain.GetBlock(cli, blockNumber)
+	if err != nil {
+		return err
+	}
+
+	txHashes := make([]string, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		txHashes[i] = tx.Hash().Hex()
+	}
+
+	result := map[string]interface{}{
+		"number":       block.NumberU64(),
+		"hash":         block.Hash().Hex(),
+		"parentHash":   block.ParentHash().Hex(),
+		"timestamp":    block.Time(),
+		"gasUsed":      block.GasUsed(),
+		"gasLimit":     block.GasLimit(),
+		"transactions": txHashes,
+	}
+	return a.printer.object(result, [][2]string{
+		{"NUMBER", strconv.FormatUint(block.NumberU64(), 10)},
+		{"HASH", block.Hash().Hex()},
+		{"PARENT HASH", block.ParentHash().Hex()},
+		{"TIMESTAMP", strconv.FormatUint(block.Time(), 10)},
+		{"GAS USED", strconv.FormatUint(block.GasUsed(), 10)},
+		{"TRANSACTIONS", strconv.Itoa(len(txHashes))},
+	})
+}
+
+func txReceipt(a *app, args []string) error {
+	flags := flag.NewFlagSet("tx receipt", flag.ExitOnError)
+	hash := flags.String("hash", "", "Transaction hash")
+	flags.Parse(args)
+
+	if len(common.FromHex(*hash)) != common.HashLength {
+		return errors.New("--hash must be a 32 byte transaction hash")
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	receipt, err := cli.TransactionReceipt(context.Background(), common.HexToHash(*hash))
+	if err != nil {
+		return err
+	}
+	return a.printReceipt(receipt)
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"tiny-blockchain-app/app/pkg/blockchain/event"
+	smartcontract "tiny-blockchain-app/smartcontract/golang"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// eventFlags : history, watch 공통 플래그
+type eventFlags struct {
+	contract *string
+	abiFile  *string
+	name     *string
+	rules    *string
+}
+
+func newEventFlags(flags *flag.FlagSet) eventFlags {
+	return eventFlags{
+		contract: flags.String("contract", "", "Contract address"),
+		abiFile:  flags.String("abi", "", "ABI json file (default: ERC20Burnable)"),
+		name:     flags.String("event", "", "Event name (default: all events)"),
+		rules:    flags.String("filter", "", "Indexed argument filter, e.g. from=0xabc,to=0xdef"),
+	}
+}
+
+func (f eventFlags) request() (event.EventRequest, error) {
+	if !common.IsHexAddress(*f.contract) {
+		return event.EventRequest{}, errors.New("--contract must be a valid address")
+	}
+
+	abiJSON := smartcontract.ERC20BurnableMetaData.ABI
+	if *f.abiFile != "" {
+		content, err := os.ReadFile(*f.abiFile)
+		if err != nil {
+			return event.EventRequest{}, err
+		}
+		abiJSON = string(content)
+	}
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return event.EventRequest{}, err
+	}
+
+	request := event.EventRequest{
+		ABI:       contractABI,
+		Addresses: []common.Address{common.HexToAddress(*f.contract)},
+	}
+
+	if *f.name == "" {
+		if *f.rules != "" {
+			return event.EventRequest{}, errors.New("--filter requires --event")
+		}
+		return request, nil
+	}
+	if _, exist := contractABI.Events[*f.name]; !exist {
+		return event.EventRequest{}, fmt.Errorf("event %q not found in abi", *f.name)
+	}
+
+	request.Events = event.EventDescription{Name: *f.name}
+	if *f.rules != "" {
+		request.Events.Rules = map[string][]interface{}{}
+		for _, rule := range strings.Split(*f.rules, ",") {
+			kv := strings.SplitN(rule, "=", 2)
+			if len(kv) != 2 {
+				return event.EventRequest{}, fmt.Errorf("invalid filter %q", rule)
+			}
+			request.Events.Rules[kv[0]] = append(request.Events.Rules[kv[0]], kv[1])
+		}
+	}
+	return request, nil
+}
+
+func eventsHistory(a *app, args []string) error {
+	flags := flag.NewFlagSet("events history", flag.ExitOnError)
+	f := newEventFlags(flags)
+	from := flags.Int64("from", 0, "From block")
+	to := flags.Int64("to", -1, "To block (default: latest)")
+	flags.Parse(args)
+
+	request, err := f.request()
+	if err != nil {
+		return err
+	}
+
+	factory, err := event.NewEventFactory(a.conf.BlockChain())
+	if err != nil {
+		return err
+	}
+
+	var toBlock *big.Int
+	if *to >= 0 {
+		toBlock = big.NewInt(*to)
+	}
+	events, err := factory.NewEventHistoryFinder(request, big.NewInt(*from), toBlock).History()
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(events))
+	for i, e := range events {
+		rows[i] = eventRow(e)
+	}
+	return a.printer.table(events, []string{"BLOCK", "TX HASH", "INDEX", "EVENT", "ARGS"}, rows)
+}
+
+func eventsWatch(a *app, args []string) error {
+	flags := flag.NewFlagSet("events watch", flag.ExitOnError)
+	f := newEventFlags(flags)
+	flags.Parse(args)
+
+	request, err := f.request()
+	if err != nil {
+		return err
+	}
+
+	factory, err := event.NewEventFactory(a.conf.BlockChain())
+	if err != nil {
+		return err
+	}
+
+	outch, errch, err := factory.NewEventSubscriber(request).Subscribe()
+	if err != nil {
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	header := true
+	for {
+		select {
+		case e := <-outch:
+			if err := a.printer.table(e, eventHeader(header), [][]string{eventRow(e)}); err != nil {
+				return err
+			}
+			header = false
+		case err := <-errch:
+			return err
+		case <-interrupt:
+			return nil
+		}
+	}
+}
+
+func eventHeader(show bool) []string {
+	if !show {
+		return nil
+	}
+	return []string{"BLOCK", "TX HASH", "INDEX", "EVENT", "ARGS"}
+}
+
+func eventRow(e event.EventResponse) []string {
+	keys := make([]string, 0, len(e.Event))
+	for key := range e.Event {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		value, _ := json.Marshal(e.Event[key])
+		pairs[i] = key + "=" + strings.Trim(string(value), `"`)
+	}
+
+	return []string{
+		strconv.FormatUint(e.BlockNumber, 10),
+		e.TxHash,
+		strconv.FormatUint(uint64(e.Index), 10),
+		e.Name,
+		strings.Join(pairs, " "),
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"strconv"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// journalList : 서버가 전송 전에 기록한 트랜잭션 목록 (journal 파일을 읽기만 함)
+func journalList(a *app, args []string) error {
+	flags := flag.NewFlagSet("journal list", flag.ExitOnError)
+	file := flags.String("file", a.conf.Journal().File, "Journal file")
+	status := flags.String("status", "", "Only entries with this status (pending|mined|reverted|dropped|rejected|replaced)")
+	from := flags.String("from", "", "Only entries sent from this address")
+	limit := flags.Int("limit", 0, "Most recent entries (0 for all)")
+	flags.Parse(args)
+
+	if *file == "" {
+		return errors.New("--file is required (journal.file is not configured)")
+	}
+	txJournal, err := journal.Read(journal.NewFileStore(*file))
+	if err != nil {
+		return err
+	}
+	filter := journal.Filter{Status: journal.Status(*status), Limit: *limit}
+	if *from != "" {
+		if !common.IsHexAddress(*from) {
+			return errors.New("--from must be a hex address")
+		}
+		address := common.HexToAddress(*from)
+		filter.From = &address
+	}
+
+	entries := txJournal.List(filter)
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.Hash.Hex(), entry.From.Hex(), strconv.FormatUint(entry.Nonce, 10), string(entry.Status),
+			strconv.Itoa(entry.Attempts), entry.Requester, entry.SentAt.Format(time.RFC3339), entry.Error,
+		})
+	}
+	return a.printer.table(entries, []string{"HASH", "FROM", "NONCE", "STATUS", "ATTEMPTS", "REQUESTER", "SENT AT", "ERROR"}, rows)
+}
@@ -0,0 +1,180 @@
+// tba : 토큰, 블록, 트랜잭션, 이벤트, 지갑 작업을 위한 커맨드라인 도구
+//
+//	tba [global flags] <command> <subcommand> [flags]
+//
+//	tba token deploy|mint|transfer|balance|approve|burn|pause|batch-transfer|batch-status|balances|info|deploy-multicall
+//	tba block get
+//	tba tx receipt|status|speedup|cancel
+//	tba nonce inspect|repair
+//	tba journal list
+//	tba events history|watch
+//	tba wallet new|import|list
+//	tba raft cluster|leader|add|remove|promote
+//	tba auth key|token
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"tiny-blockchain-app/app/config"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/blockchain/client"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+	"tiny-blockchain-app/app/pkg/blockchain/quorum"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+	"tiny-blockchain-app/app/pkg/wallet"
+)
+
+type command func(app *app, args []string) error
+
+var commands = map[string]map[string]command{
+	"token": {
+		"deploy":   tokenDeploy,
+		"mint":     tokenMint,
+		"transfer": tokenTransfer,
+		"balance":  tokenBalance,
+		"approve":  tokenApprove,
+		"burn":     tokenBurn,
+		"pause":    tokenPause,
+
+		"batch-transfer": tokenBatchTransfer,
+		"batch-status":   tokenBatchStatus,
+
+		"balances":         tokenBalances,
+		"info":             tokenInfo,
+		"deploy-multicall": tokenDeployMulticall,
+	},
+	"block": {
+		"get": blockGet,
+	},
+	"tx": {
+		"receipt": txReceipt,
+		"status":  txStatus,
+		"speedup": txSpeedUp,
+		"cancel":  txCancel,
+	},
+	"nonce": {
+		"inspect": nonceInspect,
+		"repair":  nonceRepair,
+	},
+	"journal": {
+		"list": journalList,
+	},
+	"events": {
+		"history": eventsHistory,
+		"watch":   eventsWatch,
+	},
+	"wallet": {
+		"new":    walletNew,
+		"import": walletImport,
+		"list":   walletList,
+	},
+	"raft": {
+		"cluster": raftCluster,
+		"leader":  raftLeader,
+		"add":     raftAdd,
+		"remove":  raftRemove,
+		"promote": raftPromote,
+	},
+	"auth": {
+		"key":   authKey,
+		"token": authToken,
+	},
+}
+
+// app : 서브커맨드가 공유하는 설정과 출력 방식
+type app struct {
+	conf    *config.Config
+	printer *printer
+}
+
+func main() {
+	global := flag.NewFlagSet("tba", flag.ExitOnError)
+	configFilePath := global.String("conf-path", "./config", "Config File Path")
+	configFileName := global.String("conf-file", "config", "Config File Name")
+	output := global.String("output", "table", "Output format (table|json)")
+	global.Usage = usage
+	global.Parse(os.Args[1:])
+
+	args := global.Args()
+	if len(args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, exist := commands[args[0]][args[1]]
+	if !exist {
+		fmt.Fprintf(os.Stderr, "unknown command: %s %s\n\n", args[0], args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	p, err := newPrinter(*output, os.Stdout)
+	if err != nil {
+		fail(err)
+	}
+
+	conf, err := config.New(*configFilePath, *configFileName, "yaml")
+	if err != nil {
+		fail(fmt.Errorf("failed to load config file - %v", err))
+	}
+
+	if err := cmd(&app{conf: conf, printer: p}, args[2:]); err != nil {
+		fail(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: tba [--conf-path ./config] [--conf-file config] [--output table|json] <command> <subcommand> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Commands:")
+	fmt.Fprintln(os.Stderr, "  token   deploy | mint | transfer | balance | approve | burn | pause | batch-transfer | batch-status |")
+	fmt.Fprintln(os.Stderr, "          balances | info | deploy-multicall")
+	fmt.Fprintln(os.Stderr, "  block   get")
+	fmt.Fprintln(os.Stderr, "  tx      receipt | status | speedup | cancel")
+	fmt.Fprintln(os.Stderr, "  nonce   inspect | repair")
+	fmt.Fprintln(os.Stderr, "  journal list")
+	fmt.Fprintln(os.Stderr, "  events  history | watch")
+	fmt.Fprintln(os.Stderr, "  wallet  new | import | list")
+	fmt.Fprintln(os.Stderr, "  raft    cluster | leader | add | remove | promote")
+	fmt.Fprintln(os.Stderr, "  auth    key | token")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Run 'tba <command> <subcommand> -h' for subcommand flags.")
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(1)
+}
+
+func (a *app) client() (backend.Backend, error) {
+	return client.NewBackend(a.conf.BlockChain())
+}
+
+// records : journal 파일의 전송 기록 (서버가 쓰는 파일이므로 조회만 함, file 이 비어 있으면 nil)
+func (a *app) records(file string) (txmanager.Records, error) {
+	if file == "" {
+		return nil, nil
+	}
+	txJournal, err := journal.Read(journal.NewFileStore(file))
+	if err != nil {
+		return nil, err
+	}
+	return txJournal, nil
+}
+
+func (a *app) signer() (wallet.Signer, error) {
+	signer, err := a.conf.Wallet().LoadSigner()
+	if err != nil {
+		return nil, err
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("no wallet configured for signing")
+	}
+	return signer, nil
+}
+
+func (a *app) raft() (*quorum.RaftClient, error) {
+	return quorum.Dial(a.conf.PrimaryEndpoint())
+}
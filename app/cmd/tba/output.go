@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// printer : table(기본) 또는 json 형식으로 결과 출력
+type printer struct {
+	format string
+	out    io.Writer
+}
+
+func newPrinter(format string, out io.Writer) (*printer, error) {
+	if format != "table" && format != "json" {
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+	return &printer{format: format, out: out}, nil
+}
+
+// object : 하나의 결과를 key/value 목록으로 출력 (json 모드에서는 value 그대로 인코딩)
+func (p *printer) object(value interface{}, fields [][2]string) error {
+	if p.format == "json" {
+		return p.json(value)
+	}
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		fmt.Fprintf(w, "%s\t%s\n", field[0], field[1])
+	}
+	return w.Flush()
+}
+
+// table : 여러 결과를 헤더가 있는 표로 출력
+func (p *printer) table(value interface{}, header []string, rows [][]string) error {
+	if p.format == "json" {
+		return p.json(value)
+	}
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	if len(header) > 0 {
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func (p *printer) json(value interface{}) error {
+	encoder := json.NewEncoder(p.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
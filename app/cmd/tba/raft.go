@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strconv"
+	"tiny-blockchain-app/app/pkg/blockchain/quorum"
+)
+
+func raftCluster(a *app, args []string) error {
+	flags := flag.NewFlagSet("raft cluster", flag.ExitOnError)
+	flags.Parse(args)
+
+	raft, err := a.raft()
+	if err != nil {
+		return err
+	}
+	defer raft.Close()
+
+	members, err := raft.Cluster(context.Background())
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(members))
+	for i, member := range members {
+		rows[i] = []string{
+			strconv.Itoa(int(member.RaftID)),
+			member.Role,
+			member.IP,
+			strconv.Itoa(int(member.P2PPort)),
+			strconv.Itoa(int(member.RaftPort)),
+			strconv.FormatBool(member.NodeActive),
+			member.NodeID,
+		}
+	}
+	return a.printer.table(members, []string{"RAFT ID", "ROLE", "IP", "P2P PORT", "RAFT PORT", "ACTIVE", "NODE ID"}, rows)
+}
+
+func raftLeader(a *app, args []string) error {
+	flags := flag.NewFlagSet("raft leader", flag.ExitOnError)
+	flags.Parse(args)
+
+	raft, err := a.raft()
+	if err != nil {
+		return err
+	}
+	defer raft.Close()
+
+	ctx := context.Background()
+	leader, err := raft.Leader(ctx)
+	if err != nil {
+		return err
+	}
+	role, err := raft.Role(ctx)
+	if err != nil {
+		return err
+	}
+	return a.printer.object(map[string]string{"leader": leader, "role": role}, [][2]string{
+		{"LEADER", leader},
+		{"ROLE", role},
+	})
+}
+
+func raftAdd(a *app, args []string) error {
+	flags := flag.NewFlagSet("raft add", flag.ExitOnError)
+	enode := flags.String("enode", "", "Enode URL of the new node (with raftport)")
+	learner := flags.Bool("learner", false, "Add as a non-voting learner")
+	flags.Parse(args)
+
+	if *enode == "" {
+		return errors.New("--enode is required")
+	}
+
+	raft, err := a.raft()
+	if err != nil {
+		return err
+	}
+	defer raft.Close()
+
+	role := quorum.RoleVerifier
+	var raftID uint16
+	if *learner {
+		role = quorum.RoleLearner
+		raftID, err = raft.AddLearner(context.Background(), *enode)
+	} else {
+		raftID, err = raft.AddPeer(context.Background(), *enode)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 추가된 노드는 --raftjoinexisting <raftId> 옵션으로 시작해야 클러스터에 합류
+	return a.printer.object(map[string]interface{}{"raftId": raftID, "role": role}, [][2]string{
+		{"RAFT ID", strconv.Itoa(int(raftID))},
+		{"ROLE", role},
+		{"START WITH", "--raftjoinexisting " + strconv.Itoa(int(raftID))},
+	})
+}
+
+func raftRemove(a *app, args []string) error {
+	flags := flag.NewFlagSet("raft remove", flag.ExitOnError)
+	raftID := flags.Uint("id", 0, "Raft ID of the node to remove")
+	flags.Parse(args)
+
+	id, err := requireRaftID(*raftID)
+	if err != nil {
+		return err
+	}
+
+	raft, err := a.raft()
+	if err != nil {
+		return err
+	}
+	defer raft.Close()
+
+	if err := raft.RemovePeer(context.Background(), id); err != nil {
+		return err
+	}
+	return a.printer.object(map[string]interface{}{"raftId": id, "removed": true}, [][2]string{
+		{"RAFT ID", strconv.Itoa(int(id))},
+		{"REMOVED", "true"},
+	})
+}
+
+func raftPromote(a *app, args []string) error {
+	flags := flag.NewFlagSet("raft promote", flag.ExitOnError)
+	raftID := flags.Uint("id", 0, "Raft ID of the learner to promote")
+	flags.Parse(args)
+
+	id, err := requireRaftID(*raftID)
+	if err != nil {
+		return err
+	}
+
+	raft, err := a.raft()
+	if err != nil {
+		return err
+	}
+	defer raft.Close()
+
+	promoted, err := raft.PromoteToPeer(context.Background(), id)
+	if err != nil {
+		return err
+	}
+	return a.printer.object(map[string]interface{}{"raftId": id, "promoted": promoted}, [][2]string{
+		{"RAFT ID", strconv.Itoa(int(id))},
+		{"PROMOTED", strconv.FormatBool(promoted)},
+	})
+}
+
+func requireRaftID(raftID uint) (uint16, error) {
+	if raftID == 0 || raftID > 65535 {
+		return 0, errors.New("--id must be a raft id between 1 and 65535")
+	}
+	return uint16(raftID), nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"tiny-blockchain-app/app/pkg/contract"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// tokenDeployMulticall : token balances, token info 의 --multicall 에 사용할 컨트랙트 배포
+func tokenDeployMulticall(a *app, args []string) error {
+	flags := flag.NewFlagSet("token deploy-multicall", flag.ExitOnError)
+	flags.Parse(args)
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	response, err := contract.DeployMulticall(context.Background(), cli, signer)
+	if err != nil {
+		return err
+	}
+
+	result := map[string]string{
+		"contract": response.Address.Hex(),
+		"txHash":   response.Tx.Hash().Hex(),
+	}
+	return a.printer.object(result, [][2]string{
+		{"CONTRACT", result["contract"]},
+		{"TX HASH", result["txHash"]},
+	})
+}
+
+// tokenBalances : 여러 계정의 잔액을 JSON-RPC batch (--multicall 이 있으면 Multicall 컨트랙트)로 조회
+func tokenBalances(a *app, args []string) error {
+	flags := flag.NewFlagSet("token balances", flag.ExitOnError)
+	contractAddress := flags.String("contract", "", "Token contract address")
+	holders := flags.String("holders", "", "Comma separated account addresses")
+	file := flags.String("file", "", "File with one account address per line (first CSV column)")
+	multicall := flags.String("multicall", "", "Multicall contract address (default: JSON-RPC batch)")
+	batchSize := flags.Int("batch-size", contract.DefaultBatchSize, "Calls per batch request")
+	flags.Parse(args)
+
+	if err := requireAddresses(map[string]string{"contract": *contractAddress}); err != nil {
+		return err
+	}
+	accounts, err := parseHolders(*holders, *file)
+	if err != nil {
+		return err
+	}
+
+	reader, err := a.reader(*multicall, *batchSize)
+	if err != nil {
+		return err
+	}
+	balances, err := reader.BalancesOf(context.Background(), common.HexToAddress(*contractAddress), accounts)
+	if err != nil {
+		return err
+	}
+
+	type balance struct {
+		Account common.Address `json:"account"`
+		Balance string         `json:"balance"`
+	}
+	result := make([]balance, len(accounts))
+	rows := make([][]string, len(accounts))
+	for i, account := range accounts {
+		result[i] = balance{Account: account, Balance: balances[i].String()}
+		rows[i] = []string{account.Hex(), result[i].Balance}
+	}
+	return a.printer.table(result, []string{"ACCOUNT", "BALANCE"}, rows)
+}
+
+// tokenInfo : 여러 토큰의 name, symbol, decimals, totalSupply 를 한 번에 조회
+func tokenInfo(a *app, args []string) error {
+	flags := flag.NewFlagSet("token info", flag.ExitOnError)
+	contracts := flags.String("contract", "", "Comma separated token contract addresses")
+	multicall := flags.String("multicall", "", "Multicall contract address (default: JSON-RPC batch)")
+	flags.Parse(args)
+
+	var tokens []common.Address
+	for _, address := range strings.Split(*contracts, ",") {
+		address = strings.TrimSpace(address)
+		if err := requireAddresses(map[string]string{"contract": address}); err != nil {
+			return err
+		}
+		tokens = append(tokens, common.HexToAddress(address))
+	}
+
+	reader, err := a.reader(*multicall, contract.DefaultBatchSize)
+	if err != nil {
+		return err
+	}
+	infos, err := reader.TokenInfo(context.Background(), tokens...)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(infos))
+	for i, info := range infos {
+		rows[i] = []string{info.Address.Hex(), info.Name, info.Symbol, strconv.Itoa(int(info.Decimals)), info.TotalSupply.String()}
+	}
+	return a.printer.table(infos, []string{"CONTRACT", "NAME", "SYMBOL", "DECIMALS", "TOTAL SUPPLY"}, rows)
+}
+
+func (a *app) reader(multicall string, batchSize int) (*contract.Reader, error) {
+	cli, err := a.client()
+	if err != nil {
+		return nil, err
+	}
+	reader := contract.NewReader(cli)
+	reader.BatchSize = batchSize
+	if multicall != "" {
+		if err := requireAddresses(map[string]string{"multicall": multicall}); err != nil {
+			return nil, err
+		}
+		reader.Multicall = common.HexToAddress(multicall)
+	}
+	return reader, nil
+}
+
+// parseHolders : --holders 목록과 --file 의 주소 (빈 줄, # 주석, address 헤더는 건너뜀)
+func parseHolders(holders, file string) ([]common.Address, error) {
+	var texts []string
+	for _, text := range strings.Split(holders, ",") {
+		if text = strings.TrimSpace(text); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			text := strings.TrimSpace(strings.SplitN(scanner.Text(), ",", 2)[0])
+			if text == "" || strings.HasPrefix(text, "#") || strings.EqualFold(text, "address") {
+				continue
+			}
+			texts = append(texts, text)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if len(texts) == 0 {
+		return nil, errors.New("--holders or --file is required")
+	}
+
+	accounts := make([]common.Address, len(texts))
+	for i, text := range texts {
+		if !common.IsHexAddress(text) {
+			return nil, fmt.Errorf("invalid address %q", text)
+		}
+		accounts[i] = common.HexToAddress(text)
+	}
+	return accounts, nil
+}
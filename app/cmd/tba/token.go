@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"strconv"
+	"tiny-blockchain-app/app/pkg/contract"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func tokenDeploy(a *app, args []string) error {
+	flags := flag.NewFlagSet("token deploy", flag.ExitOnError)
+	name := flags.String("name", "", "Token name")
+	symbol := flags.String("symbol", "", "Token symbol")
+	decimals := flags.Uint("decimals", 18, "Token decimals")
+	flags.Parse(args)
+
+	if *name == "" || *symbol == "" {
+		return errors.New("--name and --symbol are required")
+	}
+	if *decimals > 255 {
+		return errors.New("--decimals must be less than 256")
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	response, err := contract.DeployERC20Burnable(context.Background(), cli, signer, contract.ERC20Constructor{
+		Name:     *name,
+		Symbol:   *symbol,
+		Decimals: uint8(*decimals),
+	})
+	if err != nil {
+		return err
+	}
+
+	result := map[string]string{
+		"contract": response.Address.Hex(),
+		"txHash":   response.Tx.Hash().Hex(),
+	}
+	return a.printer.object(result, [][2]string{
+		{"CONTRACT", result["contract"]},
+		{"TX HASH", result["txHash"]},
+	})
+}
+
+func tokenMint(a *app, args []string) error {
+	flags := flag.NewFlagSet("token mint", flag.ExitOnError)
+	contractAddress := flags.String("contract", "", "Token contract address")
+	to := flags.String("to", "", "Receiver address")
+	amount := flags.String("amount", "", "Amount to mint (smallest unit, decimal)")
+	flags.Parse(args)
+
+	if err := requireAddresses(map[string]string{"contract": *contractAddress, "to": *to}); err != nil {
+		return err
+	}
+	value, err := parsePositive(*amount)
+	if err != nil {
+		return err
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	receipt, err := contract.MintERC20Burnable(context.Background(), cli, signer, *contractAddress, *to, value)
+	if err != nil {
+		return err
+	}
+	return a.printReceipt(receipt)
+}
+
+func tokenTransfer(a *app, args []string) error {
+	flags := flag.NewFlagSet("token transfer", flag.ExitOnError)
+	contractAddress := flags.String("contract", "", "Token contract address")
+	to := flags.String("to", "", "Receiver address")
+	amount := flags.String("amount", "", "Amount to transfer (smallest unit, decimal)")
+	flags.Parse(args)
+
+	if err := requireAddresses(map[string]string{"contract": *contractAddress, "to": *to}); err != nil {
+		return err
+	}
+	value, err := parsePositive(*amount)
+	if err != nil {
+		return err
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	receipt, err := contract.TransferERC20UsingABIGen(context.Background(), cli, signer, *contractAddress, *to, value)
+	if err != nil {
+		return err
+	}
+	return a.printReceipt(receipt)
+}
+
+func tokenBalance(a *app, args []string) error {
+	flags := flag.NewFlagSet("token balance", flag.ExitOnError)
+	contractAddress := flags.String("contract", "", "Token contract address")
+	account := flags.String("account", "", "Account address (default: configured wallet)")
+	flags.Parse(args)
+
+	if *account == "" {
+		signer, err := a.signer()
+		if err != nil {
+			return err
+		}
+		*account = signer.Address().Hex()
+	}
+	if err := requireAddresses(map[string]string{"contract": *contractAddress, "account": *account}); err != nil {
+		return err
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	balance, err := contract.BalanceERC20Burnable(context.Background(), cli, *contractAddress, *account)
+	if err != nil {
+		return err
+	}
+
+	result := map[string]string{
+		"account": common.HexToAddress(*account).Hex(),
+		"balance": balance,
+	}
+	return a.printer.object(result, [][2]string{
+		{"ACCOUNT", result["account"]},
+		{"BALANCE", result["balance"]},
+	})
+}
+
+func tokenApprove(a *app, args []string) error {
+	flags := flag.NewFlagSet("token approve", flag.ExitOnError)
+	contractAddress := flags.String("contract", "", "Token contract address")
+	spender := flags.String("spender", "", "Spender address")
+	amount := flags.String("amount", "", "Allowance amount")
+	flags.Parse(args)
+
+	if err := requireAddresses(map[string]string{"contract": *contractAddress, "spender": *spender}); err != nil {
+		return err
+	}
+	value, ok := new(big.Int).SetString(*amount, 10)
+	if !ok || value.Sign() < 0 {
+		return errors.New("--amount must be a non-negative integer")
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	receipt, err := contract.ApproveERc20UsingABIGen(context.Background(), cli, signer, *contractAddress, common.HexToAddress(*spender), value)
+	if err != nil {
+		return err
+	}
+	return a.printReceipt(receipt)
+}
+
+func tokenBurn(a *app, args []string) error {
+	flags := flag.NewFlagSet("token burn", flag.ExitOnError)
+	contractAddress := flags.String("contract", "", "Token contract address")
+	amount := flags.String("amount", "", "Amount to burn (smallest unit, decimal)")
+	flags.Parse(args)
+
+	if err := requireAddresses(map[string]string{"contract": *contractAddress}); err != nil {
+		return err
+	}
+	value, err := parsePositive(*amount)
+	if err != nil {
+		return err
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	receipt, err := contract.BurnERC20Burnable(context.Background(), cli, signer, *contractAddress, value)
+	if err != nil {
+		return err
+	}
+	return a.printReceipt(receipt)
+}
+
+func tokenPause(a *app, args []string) error {
+	flags := flag.NewFlagSet("token pause", flag.ExitOnError)
+	contractAddress := flags.String("contract", "", "Token contract address")
+	unpause := flags.Bool("unpause", false, "Unpause instead of pause")
+	flags.Parse(args)
+
+	if err := requireAddresses(map[string]string{"contract": *contractAddress}); err != nil {
+		return err
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	receipt, err := contract.PauseERC20Burnable(context.Background(), cli, signer, *contractAddress, !*unpause)
+	if err != nil {
+		return err
+	}
+	return a.printReceipt(receipt)
+}
+
+func (a *app) printReceipt(receipt *types.Receipt) error {
+	if receipt == nil {
+		return a.printer.object(map[string]string{"status": "submitted"}, [][2]string{
+			{"STATUS", "submitted (receipt not available yet)"},
+		})
+	}
+
+	status := "success"
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		status = "reverted"
+	}
+	fields := [][2]string{
+		{"TX HASH", receipt.TxHash.Hex()},
+		{"STATUS", status},
+		{"BLOCK", receipt.BlockNumber.String()},
+		{"GAS USED", strconv.FormatUint(receipt.GasUsed, 10)},
+	}
+	if receipt.ContractAddress != (common.Address{}) {
+		fields = append(fields, [2]string{"CONTRACT", receipt.ContractAddress.Hex()})
+	}
+	return a.printer.object(receipt, fields)
+}
+
+func requireAddresses(addresses map[string]string) error {
+	for name, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("--%s must be a valid address", name)
+		}
+	}
+	return nil
+}
+
+// parsePositive : 10진수 amount (토큰 최소 단위, int64 범위를 넘을 수 있음)
+func parsePositive(amount string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() <= 0 {
+		return nil, errors.New("--amount must be a positive integer")
+	}
+	return value, nil
+}
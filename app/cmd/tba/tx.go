@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strconv"
+	"strings"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txSpeedUp : 대기 중인 트랜잭션을 같은 nonce, 높은 가격으로 다시 전송 (이미 교체했으면 마지막 교체 hash 사용)
+func txSpeedUp(a *app, args []string) error {
+	return txReplace(a, "tx speedup", args, (*txmanager.Manager).SpeedUp)
+}
+
+// txCancel : 대기 중인 트랜잭션을 같은 nonce 의 자기 자신에게 0 을 보내는 트랜잭션으로 교체
+func txCancel(a *app, args []string) error {
+	return txReplace(a, "tx cancel", args, (*txmanager.Manager).Cancel)
+}
+
+func txReplace(a *app, name string, args []string, replace func(*txmanager.Manager, context.Context, wallet.Signer, common.Hash) (*types.Transaction, error)) error {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	hash := flags.String("hash", "", "Pending transaction hash")
+	bump := flags.Int("bump", txmanager.DefaultPriceBump, "Gas price increase (%)")
+	flags.Parse(args)
+
+	if len(common.FromHex(*hash)) != common.HashLength {
+		return errors.New("--hash must be a 32 byte transaction hash")
+	}
+	if *bump < txmanager.DefaultPriceBump {
+		return errors.New("--bump must be at least " + strconv.Itoa(txmanager.DefaultPriceBump))
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	manager := txmanager.New(cli)
+	manager.PriceBump = *bump
+	tx, err := replace(manager, context.Background(), signer, common.HexToHash(*hash))
+	if err != nil {
+		return err
+	}
+
+	result := map[string]string{
+		"txHash":   tx.Hash().Hex(),
+		"replaces": common.HexToHash(*hash).Hex(),
+		"nonce":    strconv.FormatUint(tx.Nonce(), 10),
+	}
+	return a.printer.object(result, [][2]string{
+		{"TX HASH", result["txHash"]},
+		{"REPLACES", result["replaces"]},
+		{"NONCE", result["nonce"]},
+	})
+}
+
+// txStatus : pending, mined, failed, dropped(같은 nonce 의 다른 트랜잭션이 채굴됨), unknown
+func txStatus(a *app, args []string) error {
+	flags := flag.NewFlagSet("tx status", flag.ExitOnError)
+	hash := flags.String("hash", "", "Transaction hash")
+	flags.Parse(args)
+
+	if len(common.FromHex(*hash)) != common.HashLength {
+		return errors.New("--hash must be a 32 byte transaction hash")
+	}
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	status, err := txmanager.New(cli).Status(context.Background(), common.HexToHash(*hash))
+	if err != nil {
+		return err
+	}
+
+	rows := [][2]string{
+		{"HASH", status.Hash.Hex()},
+		{"STATE", string(status.State)},
+	}
+	if status.MinedHash != nil {
+		rows = append(rows, [2]string{"MINED HASH", status.MinedHash.Hex()}, [2]string{"BLOCK", strconv.FormatUint(status.BlockNumber, 10)})
+	}
+	return a.printer.object(status, rows)
+}
+
+// nonceInspect : 채굴된 nonce, 노드 pending nonce, txpool 에서 비어 있는 nonce 와 그 뒤에 막힌 트랜잭션
+func nonceInspect(a *app, args []string) error {
+	flags := flag.NewFlagSet("nonce inspect", flag.ExitOnError)
+	account := flags.String("account", "", "Account address (default: wallet account)")
+	flags.Parse(args)
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	address, err := a.accountOrSigner(*account)
+	if err != nil {
+		return err
+	}
+
+	report, err := txmanager.New(cli).Inspect(context.Background(), address)
+	if err != nil {
+		return err
+	}
+	return a.printNonceReport(report, nil)
+}
+
+// nonceRepair : wallet 계정의 비어 있는 nonce 를 journal 에 기록된 트랜잭션을 다시 전송하거나 자기 자신에게 0 을 보내는 트랜잭션으로 채움
+func nonceRepair(a *app, args []string) error {
+	flags := flag.NewFlagSet("nonce repair", flag.ExitOnError)
+	journalFile := flags.String("journal", a.conf.Journal().File, "Journal file of transactions to resend (empty to fill gaps with no-op transfers only)")
+	flags.Parse(args)
+
+	cli, err := a.client()
+	if err != nil {
+		return err
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return err
+	}
+
+	// 다른 프로세스(서버)가 보낸 트랜잭션도 다시 전송하도록 journal 기록 사용
+	records, err := a.records(*journalFile)
+	if err != nil {
+		return err
+	}
+	manager := txmanager.New(cli)
+	manager.Records = records
+	report, fills, err := manager.RepairGaps(context.Background(), signer)
+	if err != nil {
+		return err
+	}
+	return a.printNonceReport(report, fills)
+}
+
+func (a *app) accountOrSigner(account string) (common.Address, error) {
+	if account != "" {
+		if err := requireAddresses(map[string]string{"account": account}); err != nil {
+			return common.Address{}, err
+		}
+		return common.HexToAddress(account), nil
+	}
+	signer, err := a.signer()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return signer.Address(), nil
+}
+
+func (a *app) printNonceReport(report *txmanager.NonceReport, fills []txmanager.Fill) error {
+	gaps := make([]string, len(report.Gaps))
+	for i, gap := range report.Gaps {
+		gaps[i] = strconv.FormatUint(gap, 10)
+	}
+	stuck := make([]string, len(report.Stuck))
+	for i, tx := range report.Stuck {
+		stuck[i] = strconv.FormatUint(tx.Nonce, 10) + ":" + tx.Hash.Hex()
+	}
+	rows := [][2]string{
+		{"ACCOUNT", report.Account.Hex()},
+		{"NONCE", strconv.FormatUint(report.Nonce, 10)},
+		{"PENDING NONCE", strconv.FormatUint(report.PendingNonce, 10)},
+		{"PENDING TXS", strconv.Itoa(len(report.Pending))},
+		{"GAPS", strings.Join(gaps, ",")},
+		{"STUCK", strings.Join(stuck, ",")},
+	}
+	if fills == nil {
+		return a.printer.object(report, rows)
+	}
+	filled := make([]string, len(fills))
+	for i, fill := range fills {
+		filled[i] = strconv.FormatUint(fill.Nonce, 10) + ":" + fill.Hash.Hex()
+	}
+	rows = append(rows, [2]string{"FILLED", strings.Join(filled, ",")})
+	return a.printer.object(map[string]interface{}{"report": report, "fills": fills}, rows)
+}
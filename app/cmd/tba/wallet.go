@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"tiny-blockchain-app/app/pkg/wallet"
+)
+
+// walletManager : --keystore 플래그 또는 설정의 keystoreDir로 Manager 생성
+func (a *app) walletManager(keystoreDir string) (*wallet.Manager, error) {
+	if keystoreDir == "" {
+		keystoreDir = a.conf.Wallet().KeystoreDir
+	}
+	if keystoreDir == "" {
+		return nil, errors.New("--keystore or wallet.keystoreDir is required")
+	}
+	return wallet.NewManager(keystoreDir, false), nil
+}
+
+func walletNew(a *app, args []string) error {
+	flags := flag.NewFlagSet("wallet new", flag.ExitOnError)
+	keystoreDir := flags.String("keystore", "", "Keystore directory (default: wallet.keystoreDir)")
+	passwordFile := flags.String("password-file", "", "File containing the passphrase for the new account")
+	flags.Parse(args)
+
+	manager, err := a.walletManager(*keystoreDir)
+	if err != nil {
+		return err
+	}
+	passphrase, err := wallet.ReadPassword(*passwordFile)
+	if err != nil {
+		return err
+	}
+
+	address, err := manager.NewAccount(passphrase)
+	if err != nil {
+		return err
+	}
+
+	result := map[string]string{"address": address.Hex()}
+	return a.printer.object(result, [][2]string{{"ADDRESS", address.Hex()}})
+}
+
+func walletImport(a *app, args []string) error {
+	flags := flag.NewFlagSet("wallet import", flag.ExitOnError)
+	keystoreDir := flags.String("keystore", "", "Keystore directory (default: wallet.keystoreDir)")
+	keyFile := flags.String("key-file", "", "Keystore json file, or a file containing a hex private key with --raw")
+	raw := flags.Bool("raw", false, "Treat --key-file as a hex private key")
+	passwordFile := flags.String("password-file", "", "File containing the passphrase of --key-file")
+	newPasswordFile := flags.String("new-password-file", "", "File containing the passphrase for the imported account (default: --password-file)")
+	flags.Parse(args)
+
+	if *keyFile == "" {
+		return errors.New("--key-file is required")
+	}
+	if *newPasswordFile == "" {
+		*newPasswordFile = *passwordFile
+	}
+
+	manager, err := a.walletManager(*keystoreDir)
+	if err != nil {
+		return err
+	}
+	passphrase, err := wallet.ReadPassword(*passwordFile)
+	if err != nil {
+		return err
+	}
+	newPassphrase, err := wallet.ReadPassword(*newPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(*keyFile)
+	if err != nil {
+		return err
+	}
+
+	var keyPair *wallet.KeyPair
+	if *raw {
+		privateKey, err := wallet.ReadPassword(*keyFile)
+		if err != nil {
+			return err
+		}
+		keyPair, err = wallet.GenerateKeyPair(trimHexPrefix(privateKey))
+		if err != nil {
+			return errors.New("invalid private key")
+		}
+	}
+
+	var result map[string]string
+	if keyPair != nil {
+		address, err := manager.ImportKeyPair(*keyPair, newPassphrase)
+		if err != nil {
+			return err
+		}
+		result = map[string]string{"address": address.Hex()}
+	} else {
+		address, err := manager.Import(content, passphrase, newPassphrase)
+		if err != nil {
+			return err
+		}
+		result = map[string]string{"address": address.Hex()}
+	}
+	return a.printer.object(result, [][2]string{{"ADDRESS", result["address"]}})
+}
+
+func walletList(a *app, args []string) error {
+	flags := flag.NewFlagSet("wallet list", flag.ExitOnError)
+	keystoreDir := flags.String("keystore", "", "Keystore directory (default: wallet.keystoreDir)")
+	flags.Parse(args)
+
+	manager, err := a.walletManager(*keystoreDir)
+	if err != nil {
+		return err
+	}
+
+	accounts := manager.Accounts()
+	addresses := make([]string, len(accounts))
+	rows := make([][]string, len(accounts))
+	for i, address := range accounts {
+		addresses[i] = address.Hex()
+		rows[i] = []string{addresses[i]}
+	}
+	return a.printer.table(addresses, []string{"ADDRESS"}, rows)
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && (s[:2] == "0x" || s[:2] == "0X") {
+		return s[2:]
+	}
+	return s
+}
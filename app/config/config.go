@@ -1,19 +1,185 @@
 package config
 
+import (
+	"tiny-blockchain-app/app/pkg/batch"
+	"tiny-blockchain-app/app/pkg/blockchain"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+	"tiny-blockchain-app/app/pkg/blockchain/monitor"
+	"tiny-blockchain-app/app/pkg/restapi"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/spf13/viper"
+)
+
 type Config struct {
 	Endpoint string
+	// Endpoints : client.Pool 에서 사용할 노드 목록 (첫 번째가 쓰기 우선 노드)
+	Endpoints []string
+	viper     *viper.Viper
+}
+
+type RestAPIConfig struct {
+	Address string
+}
+
+type LifecycleConfig struct {
+	// ShutdownTimeoutSec : SIGINT, SIGTERM 을 받은 뒤 진행 중인 요청과 트랜잭션을 기다리는 시간
+	ShutdownTimeoutSec uint64
+	// CheckpointFile : 이벤트 구독 재시작 블록 저장 파일 (비어있으면 저장하지 않음)
+	CheckpointFile string
+}
+
+type LogConfig struct {
+	// Format : logfmt(기본) 또는 json
+	Format string
+}
+
+// New : path 경로의 설정 파일(name.ext)을 읽어 Config 생성
+func New(path, name, ext string) (*Config, error) {
+	v := viper.New()
+	v.AddConfigPath(path)
+	v.SetConfigName(name)
+	v.SetConfigType(ext)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		Endpoint:  v.GetString("blockchain.endPoint"),
+		Endpoints: v.GetStringSlice("blockchain.endPoints"),
+		viper:     v,
+	}, nil
+}
+
+// PrimaryEndpoint : Endpoint, 비어 있으면 Endpoints 의 첫 번째 (쓰기 우선 노드), 둘 다 없으면 빈 문자열
+func (c Config) PrimaryEndpoint() string {
+	if c.Endpoint == "" && len(c.Endpoints) > 0 {
+		return c.Endpoints[0]
+	}
+	return c.Endpoint
+}
+
+func (c Config) BlockChain() blockchain.Config {
+	path := "blockchain"
+
+	return blockchain.Config{
+		EndPoint:                   c.viper.GetString(path + ".endPoint"),
+		EndPoints:                  c.viper.GetStringSlice(path + ".endPoints"),
+		WebSocket:                  c.viper.GetString(path + ".websocket"),
+		TxTimeoutSec:               c.viper.GetUint64(path + ".txTimeoutSec"),
+		CheckTxReceiptTimeMilliSec: c.viper.GetUint64(path + ".checkTxReceiptTimeMilliSec"),
+		NonceErrRetryCnt:           uint8(c.viper.GetUint(path + ".nonceErrRetryCnt")),
+		RPCRetryCnt:                uint8(c.viper.GetUint(path + ".rpcRetryCnt")),
+		RPCRetryIntervalMilliSec:   c.viper.GetUint64(path + ".rpcRetryIntervalMilliSec"),
+		RPCRateLimit:               c.viper.GetFloat64(path + ".rpcRateLimit"),
+		RPCRateBurst:               c.viper.GetInt(path + ".rpcRateBurst"),
+		HealthCheckIntervalSec:     c.viper.GetUint64(path + ".healthCheckIntervalSec"),
+		DebugMode:                  c.viper.GetBool(path + ".debugMode"),
+		UserLockEnable:             c.viper.GetBool(path + ".userLockEnable"),
+	}
+}
+
+func (c Config) Wallet() wallet.Config {
+	path := "wallet"
+
+	return wallet.Config{
+		PrivateKey:     c.viper.GetString(path + ".privateKey"),
+		KeystoreDir:    c.viper.GetString(path + ".keystoreDir"),
+		Account:        c.viper.GetString(path + ".account"),
+		PasswordFile:   c.viper.GetString(path + ".passwordFile"),
+		SignerEndpoint: c.viper.GetString(path + ".signerEndpoint"),
+	}
+}
+
+func (c Config) RestAPI() RestAPIConfig {
+	path := "restapi"
+
+	return RestAPIConfig{
+		Address: c.viper.GetString(path + ".address"),
+	}
 }
 
-// func (c Config) BlockChain() blockchain.Config {
-// 	path := "blockchain"
+func (c Config) Log() LogConfig {
+	path := "log"
 
-// 	return blockchain.Config{
-// 		EndPoint:                   c.viper.GetString(path + ".endPoint"),
-// 		WebSocket:                  c.viper.GetString(path + ".websocket"),
-// 		TxTimeoutSec:               c.viper.GetUint64(path + ".txTimeoutSec"),
-// 		CheckTxReceiptTimeMilliSec: c.viper.GetUint64(path + ".checkTxReceiptTimeMilliSec"),
-// 		NonceErrRetryCnt:           uint8(c.viper.GetUint(path + ".nonceErrRetryCnt")),
-// 		DebugMode:                  c.viper.GetBool(path + ".debugMode"),
-// 		UserLockEnable:             c.viper.GetBool(path + ".userLockEnable"),
-// 	}
-// }
+	return LogConfig{
+		Format: c.viper.GetString(path + ".format"),
+	}
+}
+
+func (c Config) Lifecycle() LifecycleConfig {
+	path := "lifecycle"
+
+	return LifecycleConfig{
+		ShutdownTimeoutSec: c.viper.GetUint64(path + ".shutdownTimeoutSec"),
+		CheckpointFile:     c.viper.GetString(path + ".checkpointFile"),
+	}
+}
+
+func (c Config) Journal() journal.Config {
+	path := "journal"
+
+	return journal.Config{
+		File:                   c.viper.GetString(path + ".file"),
+		RebroadcastIntervalSec: c.viper.GetInt(path + ".rebroadcastIntervalSec"),
+		MaxAttempts:            c.viper.GetInt(path + ".maxAttempts"),
+	}
+}
+
+func (c Config) Batch() batch.Config {
+	path := "batch"
+
+	return batch.Config{
+		Dir:         c.viper.GetString(path + ".dir"),
+		MaxInFlight: c.viper.GetInt(path + ".maxInFlight"),
+	}
+}
+
+func (c Config) Auth() (restapi.AuthConfig, error) {
+	var conf restapi.AuthConfig
+	if err := c.viper.UnmarshalKey("auth", &conf); err != nil {
+		return restapi.AuthConfig{}, err
+	}
+	return conf, nil
+}
+
+func (c Config) RateLimit() (restapi.RateLimitConfig, error) {
+	var conf restapi.RateLimitConfig
+	if err := c.viper.UnmarshalKey("rateLimit", &conf); err != nil {
+		return restapi.RateLimitConfig{}, err
+	}
+	return conf, nil
+}
+
+func (c Config) Idempotency() restapi.IdempotencyConfig {
+	path := "idempotency"
+
+	return restapi.IdempotencyConfig{
+		TTLMinutes: c.viper.GetInt(path + ".ttlMinutes"),
+		File:       c.viper.GetString(path + ".file"),
+	}
+}
+
+func (c Config) Monitor() (monitor.Config, error) {
+	path := "monitor"
+
+	conf := monitor.Config{
+		IntervalSec:     c.viper.GetUint64(path + ".intervalSec"),
+		TimeoutSec:      c.viper.GetUint64(path + ".timeoutSec"),
+		StallTimeoutSec: c.viper.GetUint64(path + ".stallTimeoutSec"),
+		MaxBlockLag:     c.viper.GetUint64(path + ".maxBlockLag"),
+	}
+	if err := c.viper.UnmarshalKey(path+".nodes", &conf.Nodes); err != nil {
+		return monitor.Config{}, err
+	}
+	return conf, nil
+}
+
+func (c Config) Contracts() ([]blockchain.ContractConfig, error) {
+	var contracts []blockchain.ContractConfig
+	if err := c.viper.UnmarshalKey("contracts", &contracts); err != nil {
+		return nil, err
+	}
+	return contracts, nil
+}
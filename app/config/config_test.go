@@ -20,3 +20,90 @@ func TestConfig(t *testing.T) {
 	assert.Equal(t, "http://127.0.0.1:22001", blockchainConfig.EndPoint)
 	assert.Equal(t, "ws://127.0.0.1:32001", blockchainConfig.WebSocket)
 }
+
+func TestConfig_PrimaryEndpoint(t *testing.T) {
+	assert.Equal(t, "http://a", Config{Endpoint: "http://a", Endpoints: []string{"http://b"}}.PrimaryEndpoint())
+	assert.Equal(t, "http://b", Config{Endpoints: []string{"http://b", "http://c"}}.PrimaryEndpoint())
+	assert.Equal(t, "", Config{}.PrimaryEndpoint())
+}
+
+func TestConfig_Monitor(t *testing.T) {
+	conf, err := New(".", "config", "yaml")
+	assert.Equal(t, nil, err)
+
+	monitorConfig, err := conf.Monitor()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 5, len(monitorConfig.Nodes))
+	assert.Equal(t, "node-1", monitorConfig.Nodes[0].Name)
+	assert.Equal(t, "http://127.0.0.1:22001", monitorConfig.Nodes[0].Endpoint)
+	assert.Equal(t, uint64(30), monitorConfig.StallTimeoutSec)
+	assert.Equal(t, uint64(3), monitorConfig.TimeoutSec)
+}
+
+func TestConfig_Contracts(t *testing.T) {
+	conf, err := New(".", "config", "yaml")
+	assert.Equal(t, nil, err)
+
+	contracts, err := conf.Contracts()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(contracts))
+}
+
+func TestConfig_Lifecycle(t *testing.T) {
+	conf, err := New(".", "config", "yaml")
+	assert.Equal(t, nil, err)
+
+	lifecycleConfig := conf.Lifecycle()
+	assert.Equal(t, uint64(30), lifecycleConfig.ShutdownTimeoutSec)
+	assert.Equal(t, "./data/checkpoints.json", lifecycleConfig.CheckpointFile)
+}
+
+func TestConfig_Batch(t *testing.T) {
+	conf, err := New(".", "config", "yaml")
+	assert.Equal(t, nil, err)
+
+	batchConfig := conf.Batch()
+	assert.Equal(t, "./data/batches", batchConfig.Dir)
+	assert.Equal(t, 16, batchConfig.MaxInFlight)
+}
+
+func TestConfig_Journal(t *testing.T) {
+	conf, err := New(".", "config", "yaml")
+	assert.Equal(t, nil, err)
+
+	journalConfig := conf.Journal()
+	assert.Equal(t, "./data/journal.jsonl", journalConfig.File)
+	assert.Equal(t, 30, journalConfig.RebroadcastIntervalSec)
+	assert.Equal(t, 10, journalConfig.MaxAttempts)
+}
+
+func TestConfig_Auth(t *testing.T) {
+	conf, err := New(".", "config", "yaml")
+	assert.Equal(t, nil, err)
+
+	authConfig, err := conf.Auth()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, authConfig.Enabled)
+	assert.Equal(t, "tiny-blockchain-app", authConfig.JWTIssuer)
+}
+
+func TestConfig_RateLimit(t *testing.T) {
+	conf, err := New(".", "config", "yaml")
+	assert.Equal(t, nil, err)
+
+	rateLimitConfig, err := conf.RateLimit()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, rateLimitConfig.Enabled)
+	assert.Equal(t, float64(2), rateLimitConfig.Transaction.RequestsPerSec)
+	assert.Equal(t, 5, rateLimitConfig.Transaction.Burst)
+	assert.Equal(t, 10, rateLimitConfig.MaxPendingPerAccount)
+	assert.Equal(t, 0, len(rateLimitConfig.TrustedProxies))
+}
+
+func TestConfig_Idempotency(t *testing.T) {
+	conf, err := New(".", "config", "yaml")
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 1440, conf.Idempotency().TTLMinutes)
+	assert.Equal(t, "./data/idempotency.jsonl", conf.Idempotency().File)
+}
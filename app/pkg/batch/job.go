@@ -0,0 +1,226 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Status : 행 처리 상태
+//
+//	pending : 아직 보내지 않음
+//	sent    : 서명한 트랜잭션을 기록하고 전송함 (receipt 대기)
+//	mined   : 성공 receipt 확인
+//	failed  : revert 또는 가스 추정 실패 (다시 실행하면 재시도)
+//	unknown : nonce 가 사용되었지만 기록한 트랜잭션과 그 교체의 receipt 를 찾지 못함
+//	          (기록에 없는 교체가 채굴되었거나 조회한 노드가 뒤처졌을 수 있어 다시 실행해도 보내지 않음, 확인 필요)
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusSent    Status = "sent"
+	StatusMined   Status = "mined"
+	StatusFailed  Status = "failed"
+	StatusUnknown Status = "unknown"
+)
+
+// Transfer : 행별 처리 결과
+type Transfer struct {
+	Line   int            `json:"line"`
+	To     common.Address `json:"to"`
+	Amount string         `json:"amount"`
+	Status Status         `json:"status"`
+	Nonce  *uint64        `json:"nonce,omitempty"`
+	TxHash string         `json:"txHash,omitempty"`
+	// RawTx : 전송 전에 기록하는 서명된 트랜잭션, 다시 실행할 때 노드에 없으면 그대로 재전송
+	RawTx string `json:"rawTx,omitempty"`
+	Block uint64 `json:"block,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func (t *Transfer) amount() *big.Int {
+	amount, _ := new(big.Int).SetString(t.Amount, 10)
+	return amount
+}
+
+// Job : 하나의 일괄 전송 작업 (Store 에 저장하여 다시 실행할 때 이어서 처리)
+type Job struct {
+	ID        string         `json:"id"`
+	Token     common.Address `json:"token"`
+	From      common.Address `json:"from"`
+	Transfers []*Transfer    `json:"transfers"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// NewJob : 모든 행이 pending 인 작업
+func NewJob(id string, token, from common.Address, rows []Row) *Job {
+	job := &Job{ID: id, Token: token, From: from, CreatedAt: time.Now()}
+	for _, row := range rows {
+		job.Transfers = append(job.Transfers, &Transfer{
+			Line:   row.Line,
+			To:     row.To,
+			Amount: row.Amount.String(),
+			Status: StatusPending,
+		})
+	}
+	return job
+}
+
+// matches : 다시 실행할 때 같은 토큰, 서명 계정, 수신자 목록인지
+func (j *Job) matches(token, from common.Address, rows []Row) error {
+	if j.Token != token || j.From != from {
+		return fmt.Errorf("job %s was created for token %s from %s", j.ID, j.Token.Hex(), j.From.Hex())
+	}
+	if len(j.Transfers) != len(rows) {
+		return fmt.Errorf("job %s has %d rows, input has %d", j.ID, len(j.Transfers), len(rows))
+	}
+	for i, row := range rows {
+		transfer := j.Transfers[i]
+		if transfer.To != row.To || transfer.Amount != row.Amount.String() {
+			return fmt.Errorf("job %s row %d differs from input line %d", j.ID, i+1, row.Line)
+		}
+	}
+	return nil
+}
+
+// Summary : 상태별 행 수와 전송 완료된 수량
+type Summary struct {
+	Total   int    `json:"total"`
+	Pending int    `json:"pending"`
+	Sent    int    `json:"sent"`
+	Mined   int    `json:"mined"`
+	Failed  int    `json:"failed"`
+	Unknown int    `json:"unknown"`
+	Amount  string `json:"amount"`
+	// Complete : 모든 행이 mined
+	Complete bool `json:"complete"`
+}
+
+func (j *Job) Summary() Summary {
+	summary := Summary{Total: len(j.Transfers)}
+	amount := new(big.Int)
+	for _, transfer := range j.Transfers {
+		switch transfer.Status {
+		case StatusPending:
+			summary.Pending++
+		case StatusSent:
+			summary.Sent++
+		case StatusMined:
+			summary.Mined++
+			amount.Add(amount, transfer.amount())
+		case StatusFailed:
+			summary.Failed++
+		case StatusUnknown:
+			summary.Unknown++
+		}
+	}
+	summary.Amount = amount.String()
+	summary.Complete = summary.Mined == summary.Total
+	return summary
+}
+
+// WriteReport : 행별 결과 CSV (line,address,amount,status,nonce,txHash,block,error)
+func (j *Job) WriteReport(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"line", "address", "amount", "status", "nonce", "txHash", "block", "error"}); err != nil {
+		return err
+	}
+	for _, t := range j.Transfers {
+		nonce, block := "", ""
+		if t.Nonce != nil {
+			nonce = strconv.FormatUint(*t.Nonce, 10)
+		}
+		if t.Block != 0 {
+			block = strconv.FormatUint(t.Block, 10)
+		}
+		record := []string{strconv.Itoa(t.Line), t.To.Hex(), t.Amount, string(t.Status), nonce, t.TxHash, block, t.Error}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Store : 작업 저장소
+type Store interface {
+	// Load : 저장된 적이 없으면 nil
+	Load(id string) (*Job, error)
+	Save(job *Job) error
+}
+
+var jobIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// FileStore : dir 아래 작업마다 <id>.json 파일
+type FileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) Load(id string) (*Job, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{}
+	if err := json.Unmarshal(data, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Save : 임시 파일에 쓴 뒤 교체하므로 저장 중에 종료되어도 기존 파일은 유지
+func (s *FileStore) Save(job *Job) error {
+	path, err := s.path(job.ID)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *FileStore) path(id string) (string, error) {
+	if !jobIDPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid job id %q (letters, digits, '.', '_', '-')", id)
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
@@ -0,0 +1,51 @@
+package batch
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJob_Report(t *testing.T) {
+	token, from := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	job := NewJob("report", token, from, []Row{
+		{Line: 1, To: common.HexToAddress("0x03"), Amount: big.NewInt(10)},
+		{Line: 2, To: common.HexToAddress("0x04"), Amount: big.NewInt(20)},
+	})
+	nonce := uint64(7)
+	job.Transfers[0].Status, job.Transfers[0].Nonce, job.Transfers[0].TxHash, job.Transfers[0].Block = StatusMined, &nonce, "0xabc", 12
+	job.Transfers[1].Status, job.Transfers[1].Error = StatusFailed, "reverted"
+
+	summary := job.Summary()
+	assert.Equal(t, Summary{Total: 2, Mined: 1, Failed: 1, Amount: "10"}, summary)
+
+	var report bytes.Buffer
+	err := job.WriteReport(&report)
+	assert.Equal(t, nil, err)
+	lines := strings.Split(strings.TrimSpace(report.String()), "\n")
+	assert.Equal(t, "line,address,amount,status,nonce,txHash,block,error", lines[0])
+	assert.Equal(t, "1,0x0000000000000000000000000000000000000003,10,mined,7,0xabc,12,", lines[1])
+	assert.Equal(t, "2,0x0000000000000000000000000000000000000004,20,failed,,,,reverted", lines[2])
+}
+
+func TestFileStore(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	job, err := store.Load("missing")
+	assert.Equal(t, nil, err)
+	assert.True(t, job == nil)
+
+	saved := NewJob("job-1", common.HexToAddress("0x01"), common.HexToAddress("0x02"), []Row{{Line: 1, To: common.HexToAddress("0x03"), Amount: big.NewInt(10)}})
+	err = store.Save(saved)
+	assert.Equal(t, nil, err)
+	job, err = store.Load("job-1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, saved.Transfers, job.Transfers)
+
+	err = store.Save(&Job{ID: "../escape"})
+	assert.NotEqual(t, nil, err)
+}
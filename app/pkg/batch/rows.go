@@ -0,0 +1,142 @@
+// batch : 여러 수신자에게 ERC20 토큰을 나누어 보내는 일괄 전송 (airdrop)
+//
+// 수신자 목록(CSV, JSON)을 검증하여 작업(Job)으로 저장하고, 연속된 nonce 로 receipt 를 기다리지 않고 이어서 전송
+// 서명한 트랜잭션을 전송 전에 작업 파일에 기록하므로, 중단된 작업을 다시 실행하면 같은 트랜잭션을 재전송하거나
+// 전송되지 않은 행만 새로 보내어 같은 행을 두 번 보내지 않음
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Row : 수신자와 수량 (토큰 최소 단위)
+type Row struct {
+	// Line : 입력 파일의 행 번호 (JSON 은 1부터 시작하는 순서)
+	Line   int
+	To     common.Address
+	Amount *big.Int
+}
+
+// ParseFile : 확장자가 .json 이면 JSON, 아니면 CSV
+func ParseFile(path string) ([]Row, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseJSON(file)
+	}
+	return ParseCSV(file)
+}
+
+// ParseCSV : "address,amount" 행 목록 (첫 행이 address 로 시작하는 헤더이면 건너뜀)
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rows []Row
+	var errs []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rows) == 0 && len(errs) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+		if len(record) != 2 {
+			errs = append(errs, fmt.Sprintf("line %d: expected address,amount", line))
+			continue
+		}
+		row, err := parseRow(line, record[0], record[1])
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return validate(rows, errs)
+}
+
+// ParseJSON : [{"address": "0x...", "amount": "1000"}] (amount 는 문자열 또는 정수)
+func ParseJSON(r io.Reader) ([]Row, error) {
+	var entries []struct {
+		Address string      `json:"address"`
+		Amount  json.Number `json:"amount"`
+	}
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if err := decoder.Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	var errs []string
+	for i, entry := range entries {
+		row, err := parseRow(i+1, entry.Address, entry.Amount.String())
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return validate(rows, errs)
+}
+
+func parseRow(line int, address, amount string) (Row, error) {
+	address, amount = strings.TrimSpace(address), strings.TrimSpace(amount)
+	to, err := parseAddress(address)
+	if err != nil {
+		return Row{}, fmt.Errorf("line %d: %v", line, err)
+	}
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() <= 0 {
+		return Row{}, fmt.Errorf("line %d: amount must be a positive integer: %q", line, amount)
+	}
+	return Row{Line: line, To: to, Amount: value}, nil
+}
+
+// parseAddress : 대소문자가 섞여 있으면 EIP-55 체크섬 확인, 0 주소는 허용하지 않음
+func parseAddress(text string) (common.Address, error) {
+	if !common.IsHexAddress(text) {
+		return common.Address{}, fmt.Errorf("invalid address %q", text)
+	}
+	address := common.HexToAddress(text)
+	hex := text[2:]
+	if hex != strings.ToLower(hex) && hex != strings.ToUpper(hex) && address.Hex()[2:] != hex {
+		return common.Address{}, fmt.Errorf("invalid EIP-55 checksum %q", text)
+	}
+	if address == (common.Address{}) {
+		return common.Address{}, errors.New("zero address is not allowed")
+	}
+	return address, nil
+}
+
+// validate : 행 오류를 모두 모아 하나의 오류로 반환
+func validate(rows []Row, errs []string) ([]Row, error) {
+	if len(errs) > 0 {
+		return nil, errors.New("invalid rows:\n  " + strings.Join(errs, "\n  "))
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("no rows")
+	}
+	return rows, nil
+}
@@ -0,0 +1,59 @@
+package batch
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	rows, err := ParseCSV(strings.NewReader(`address,amount
+# 주석은 무시
+0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9, 100
+0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448,2000000000000000000000
+`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, 3, rows[0].Line)
+	assert.Equal(t, common.HexToAddress("0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9"), rows[0].To)
+	assert.Equal(t, big.NewInt(100), rows[0].Amount)
+	assert.Equal(t, "2000000000000000000000", rows[1].Amount.String())
+
+	// 모든 행 오류를 함께 보고
+	_, err = ParseCSV(strings.NewReader(`0xb9d171F81716ee2Ce29b85Ba44B3966992512Ec9,100
+0x1234,100
+0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448,-1
+0x0000000000000000000000000000000000000000,1
+0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448
+`))
+	assert.NotEqual(t, nil, err)
+	for _, line := range []string{"line 1: invalid EIP-55", "line 2: invalid address", "line 3: amount", "line 4:", "line 5:"} {
+		assert.True(t, strings.Contains(err.Error(), line), line)
+	}
+
+	_, err = ParseCSV(strings.NewReader("address,amount\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestParseFile_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rows.json")
+	err := os.WriteFile(path, []byte(`[
+  {"address": "0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9", "amount": "100"},
+  {"address": "0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448", "amount": 25}
+]`), 0o644)
+	assert.Equal(t, nil, err)
+
+	rows, err := ParseFile(path)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, 2, rows[1].Line)
+	assert.Equal(t, big.NewInt(25), rows[1].Amount)
+
+	_, err = ParseJSON(strings.NewReader(`[{"address": "0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9", "amount": "1.5"}]`))
+	assert.NotEqual(t, nil, err)
+}
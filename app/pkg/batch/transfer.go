@@ -0,0 +1,419 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+	"tiny-blockchain-app/app/pkg/logging"
+	"tiny-blockchain-app/app/pkg/wallet"
+	smartcontract "tiny-blockchain-app/smartcontract/golang"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var logger = logging.New("batch")
+
+// DefaultMaxInFlight : receipt 를 기다리는 트랜잭션 수 기본값
+const DefaultMaxInFlight = 16
+
+// gasMargin : 추정한 가스에 더하는 비율 (%)
+const gasMargin = 20
+
+var ErrInsufficientBalance = errors.New("insufficient balance")
+
+// ErrJobRunning : 같은 id 의 작업이 이미 실행 중 (같은 행을 두 번 보내지 않도록 거부)
+var ErrJobRunning = errors.New("batch job is already running")
+
+// running : 이 프로세스에서 실행 중인 작업 id
+var running sync.Map
+
+// Config : 작업 파일 디렉터리와 동시에 receipt 를 기다리는 트랜잭션 수
+type Config struct {
+	Dir         string
+	MaxInFlight int
+}
+
+// Transferer : signer 계정으로 일괄 전송
+// client 의 PendingNonceAt 을 트랜잭션마다 호출하므로 NonceManager.Backend 로 감싼 client 를 사용하면
+// 같은 계정의 다른 트랜잭션과 nonce 가 겹치지 않고, txmanager.Manager.Backend 로 감싸면 교체된 행의 receipt 를 따라감
+type Transferer struct {
+	client backend.Backend
+	signer wallet.Signer
+	store  Store
+	erc20  abi.ABI
+
+	// MaxInFlight : 0 이면 DefaultMaxInFlight
+	MaxInFlight int
+	// PollInterval : receipt 조회 간격
+	PollInterval time.Duration
+	// Records : 재시작 후에도 남는 전송 기록 (journal), 기록한 트랜잭션 대신 같은 nonce 로 교체된 트랜잭션 확인 (nil 이면 사용하지 않음)
+	Records txmanager.Records
+}
+
+func NewTransferer(client backend.Backend, signer wallet.Signer, store Store) (*Transferer, error) {
+	erc20, err := smartcontract.ERC20BurnableMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Transferer{
+		client:       client,
+		signer:       signer,
+		store:        store,
+		erc20:        *erc20,
+		MaxInFlight:  DefaultMaxInFlight,
+		PollInterval: time.Second,
+	}, nil
+}
+
+// Run : id 작업을 처음부터 또는 이어서 실행하고 끝난 상태를 반환
+//
+//  1. 이전 실행에서 sent 로 남은 행은 receipt 를 확인하고, 노드에 없으면 기록한 트랜잭션을 재전송
+//     (nonce 가 이미 사용되었으면 Records 의 교체 트랜잭션 결과, 찾지 못하면 unknown 으로 두고 다시 보내지 않음)
+//  2. pending, failed 행의 합계가 토큰 잔액보다 크면 전송하지 않고 ErrInsufficientBalance
+//  3. 행마다 서명한 트랜잭션을 작업에 저장한 뒤 전송, MaxInFlight 개까지 receipt 를 기다리지 않고 이어서 전송
+//
+// 전송이 실패하면 그 전에 보낸 행의 receipt 를 기다린 뒤 오류 반환 (다시 실행하면 이어서 처리)
+// revert 된 행은 failed 로 기록하고 계속 진행, 같은 id 의 작업이 실행 중이면 ErrJobRunning
+func (t *Transferer) Run(ctx context.Context, id string, token common.Address, rows []Row) (*Job, error) {
+	if _, loaded := running.LoadOrStore(id, struct{}{}); loaded {
+		return nil, fmt.Errorf("%w: %s", ErrJobRunning, id)
+	}
+	defer running.Delete(id)
+
+	from := t.signer.Address()
+	job, err := t.store.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		job = NewJob(id, token, from, rows)
+	} else if err := job.matches(token, from, rows); err != nil {
+		return nil, err
+	}
+	if err := t.save(job); err != nil {
+		return nil, err
+	}
+	jobLogger := logging.FromContext(ctx, logger).New("job", id, "token", token, "from", from)
+
+	// 1. 이전 실행에서 전송한 행
+	var inflight []*Transfer
+	for _, transfer := range job.Transfers {
+		if transfer.Status != StatusSent {
+			continue
+		}
+		resent, err := t.resume(ctx, job, transfer)
+		if err != nil {
+			return job, err
+		}
+		if resent {
+			inflight = append(inflight, transfer)
+		}
+	}
+	if err := t.waitAll(ctx, job, inflight); err != nil {
+		return job, err
+	}
+
+	// 2. 잔액 확인
+	var remaining []*Transfer
+	total := new(big.Int)
+	for _, transfer := range job.Transfers {
+		if transfer.Status == StatusPending || transfer.Status == StatusFailed {
+			remaining = append(remaining, transfer)
+			total.Add(total, transfer.amount())
+		}
+	}
+	if len(remaining) == 0 {
+		return job, nil
+	}
+	if err := t.checkBalance(ctx, token, from, total); err != nil {
+		return job, err
+	}
+
+	chainID, err := t.client.ChainID(ctx)
+	if err != nil {
+		return job, err
+	}
+	gasPrice, err := t.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return job, err
+	}
+
+	// 3. 전송
+	jobLogger.Info("Sending batch", "rows", len(remaining), "amount", total)
+	maxInFlight := t.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = DefaultMaxInFlight
+	}
+	inflight = inflight[:0]
+	var sendErr error
+	for _, transfer := range remaining {
+		if len(inflight) >= maxInFlight {
+			if err := t.wait(ctx, job, inflight[0]); err != nil {
+				return job, err
+			}
+			inflight = inflight[1:]
+		}
+		if sendErr = t.send(ctx, job, transfer, chainID, gasPrice); sendErr != nil {
+			// 노드가 받지 않았을 수 있으므로 이 행은 기다리지 않고 다음 실행에서 확인
+			jobLogger.Error("Failed to send, stopping batch", "line", transfer.Line, "err", sendErr)
+			break
+		}
+		if transfer.Status == StatusSent {
+			inflight = append(inflight, transfer)
+		}
+	}
+	if err := t.waitAll(ctx, job, inflight); err != nil {
+		return job, err
+	}
+
+	summary := job.Summary()
+	jobLogger.Info("Batch finished", "mined", summary.Mined, "failed", summary.Failed, "unknown", summary.Unknown, "pending", summary.Pending)
+	return job, sendErr
+}
+
+// checkBalance : 토큰 잔액이 total 이상인지
+func (t *Transferer) checkBalance(ctx context.Context, token, from common.Address, total *big.Int) error {
+	data, err := t.erc20.Pack("balanceOf", from)
+	if err != nil {
+		return err
+	}
+	output, err := t.client.CallContract(ctx, ethereum.CallMsg{From: from, To: &token, Data: data}, nil)
+	if err != nil {
+		return err
+	}
+	values, err := t.erc20.Unpack("balanceOf", output)
+	if err != nil {
+		return err
+	}
+	balance := values[0].(*big.Int)
+	if balance.Cmp(total) < 0 {
+		return fmt.Errorf("%w: %s has %s, batch needs %s", ErrInsufficientBalance, from.Hex(), balance, total)
+	}
+	return nil
+}
+
+// send : 가스 추정, 서명, 작업 저장, 전송 순서
+// 가스 추정이 실패(revert)하면 nonce 를 할당하지 않고 failed 로 기록
+// 전송 오류는 노드가 받았을 수도 있으므로 sent 상태로 두고 오류 반환 (다시 실행할 때 확인)
+func (t *Transferer) send(ctx context.Context, job *Job, transfer *Transfer, chainID, gasPrice *big.Int) error {
+	data, err := t.erc20.Pack("transfer", transfer.To, transfer.amount())
+	if err != nil {
+		return err
+	}
+	gas, err := t.client.EstimateGas(ctx, ethereum.CallMsg{From: job.From, To: &job.Token, Data: data})
+	if err != nil {
+		transfer.Nonce, transfer.TxHash, transfer.RawTx, transfer.Block = nil, "", "", 0
+		t.fail(transfer, "estimate gas: "+err.Error())
+		return t.save(job)
+	}
+	gas += gas * gasMargin / 100
+
+	nonce, err := t.client.PendingNonceAt(ctx, job.From)
+	if err != nil {
+		return err
+	}
+	tx, err := t.signer.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      gas,
+		To:       &job.Token,
+		Data:     data,
+	}), chainID)
+	if err != nil {
+		backend.ReleaseNonce(t.client, job.From, nonce)
+		return err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		backend.ReleaseNonce(t.client, job.From, nonce)
+		return err
+	}
+
+	transfer.Status = StatusSent
+	transfer.Nonce = &nonce
+	transfer.TxHash = tx.Hash().Hex()
+	transfer.RawTx = hexutil.Encode(raw)
+	transfer.Block = 0
+	transfer.Error = ""
+	if err := t.save(job); err != nil {
+		backend.ReleaseNonce(t.client, job.From, nonce)
+		return err
+	}
+
+	if err := t.client.SendTransaction(ctx, tx); err != nil {
+		transfer.Error = err.Error()
+		if saveErr := t.save(job); saveErr != nil {
+			return saveErr
+		}
+		return fmt.Errorf("line %d: %w", transfer.Line, err)
+	}
+	return nil
+}
+
+// resume : 이전 실행에서 sent 로 남은 행 확인, receipt 를 기다려야 하면 true
+func (t *Transferer) resume(ctx context.Context, job *Job, transfer *Transfer) (bool, error) {
+	hash := common.HexToHash(transfer.TxHash)
+	receipt, err := t.client.TransactionReceipt(ctx, hash)
+	if err == nil {
+		t.settle(transfer, receipt)
+		return false, t.save(job)
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return false, err
+	}
+
+	if _, _, err := t.client.TransactionByHash(ctx, hash); err == nil {
+		return true, nil
+	} else if !errors.Is(err, ethereum.NotFound) {
+		return false, err
+	}
+
+	// 노드에 없음 : nonce 가 이미 사용되었으면 이 트랜잭션은 채굴될 수 없음
+	confirmed, err := t.client.NonceAt(ctx, job.From, nil)
+	if err != nil {
+		return false, err
+	}
+	if transfer.Nonce == nil || confirmed > *transfer.Nonce {
+		if err := t.replaced(ctx, job, transfer); err != nil {
+			return false, err
+		}
+		return false, t.save(job)
+	}
+
+	raw, err := hexutil.Decode(transfer.RawTx)
+	if err != nil {
+		return false, err
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return false, err
+	}
+	if err := t.client.SendTransaction(ctx, tx); err != nil && !strings.Contains(err.Error(), "already known") {
+		return false, fmt.Errorf("line %d: resend %s: %w", transfer.Line, transfer.TxHash, err)
+	}
+	logging.FromContext(ctx, logger).Info("Resent recorded transaction", "job", job.ID, "line", transfer.Line, "tx", transfer.TxHash, "nonce", *transfer.Nonce)
+	return true, nil
+}
+
+// replaced : nonce 가 사용되었는데 기록한 트랜잭션의 receipt 가 없는 행
+// speed-up 한 교체 트랜잭션이 채굴되었을 수 있으므로 Records 에서 같은 nonce 로 같은 전송을 한 트랜잭션의 receipt 로 처리하고,
+// 찾지 못하면 (cancel, 기록에 없는 교체, 뒤처진 노드) 다시 보내면 두 번 지급될 수 있으므로 unknown
+func (t *Transferer) replaced(ctx context.Context, job *Job, transfer *Transfer) error {
+	if transfer.Nonce == nil {
+		transfer.Status, transfer.Error = StatusUnknown, "nonce was used but no nonce was recorded, check before resending"
+		return nil
+	}
+	if t.Records != nil {
+		if tx := t.Records.Transaction(job.From, *transfer.Nonce); tx != nil && tx.Hash().Hex() != transfer.TxHash && t.sameTransfer(job, transfer, tx) {
+			receipt, err := t.client.TransactionReceipt(ctx, tx.Hash())
+			if err == nil {
+				transfer.TxHash = tx.Hash().Hex()
+				t.settle(transfer, receipt)
+				return nil
+			}
+			if !errors.Is(err, ethereum.NotFound) {
+				return err
+			}
+		}
+	}
+	transfer.Status = StatusUnknown
+	transfer.Error = fmt.Sprintf("nonce %d was used but %s is not mined, check before resending", *transfer.Nonce, transfer.TxHash)
+	return nil
+}
+
+// sameTransfer : tx 가 행과 같은 토큰 전송인지 (speed-up 은 가격만 올리고 내용은 같음)
+func (t *Transferer) sameTransfer(job *Job, transfer *Transfer, tx *types.Transaction) bool {
+	data, err := t.erc20.Pack("transfer", transfer.To, transfer.amount())
+	return err == nil && tx.To() != nil && *tx.To() == job.Token && bytes.Equal(tx.Data(), data)
+}
+
+func (t *Transferer) waitAll(ctx context.Context, job *Job, transfers []*Transfer) error {
+	for _, transfer := range transfers {
+		if err := t.wait(ctx, job, transfer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wait : receipt 를 받을 때까지 PollInterval 간격으로 조회 (ctx 가 끝나면 sent 상태로 남김)
+// nonce 가 사용되었는데 receipt 가 없으면 resume 과 같이 교체 트랜잭션의 결과 또는 unknown,
+// 노드에서 트랜잭션이 사라졌으면 sent 로 두고 오류 반환 (다시 실행하면 기록한 트랜잭션을 재전송)
+func (t *Transferer) wait(ctx context.Context, job *Job, transfer *Transfer) error {
+	hash := common.HexToHash(transfer.TxHash)
+	ticker := time.NewTicker(t.PollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := t.client.TransactionReceipt(ctx, hash)
+		if err == nil {
+			t.settle(transfer, receipt)
+			return t.save(job)
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			logging.FromContext(ctx, logger).Warn("Failed to get receipt", "job", job.ID, "tx", hash, "err", err)
+		} else if done, err := t.lost(ctx, job, transfer); done || err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// lost : receipt 가 없는 행의 nonce 가 사용되었거나 노드에서 사라졌는지 확인, 더 기다리지 않아도 되면 true
+func (t *Transferer) lost(ctx context.Context, job *Job, transfer *Transfer) (bool, error) {
+	hash := common.HexToHash(transfer.TxHash)
+	confirmed, err := t.client.NonceAt(ctx, job.From, nil)
+	if err != nil || transfer.Nonce == nil {
+		return false, nil
+	}
+	if confirmed > *transfer.Nonce {
+		// nonce 를 조회하는 사이 채굴되었을 수 있으므로 다시 확인
+		if receipt, err := t.client.TransactionReceipt(ctx, hash); err == nil {
+			t.settle(transfer, receipt)
+			return true, t.save(job)
+		}
+		if err := t.replaced(ctx, job, transfer); err != nil {
+			return true, err
+		}
+		return true, t.save(job)
+	}
+	if _, _, err := t.client.TransactionByHash(ctx, hash); errors.Is(err, ethereum.NotFound) {
+		return true, fmt.Errorf("line %d: %s is no longer in the node, run again to resend", transfer.Line, transfer.TxHash)
+	}
+	return false, nil
+}
+
+func (t *Transferer) settle(transfer *Transfer, receipt *types.Receipt) {
+	transfer.Block = receipt.BlockNumber.Uint64()
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		transfer.Status = StatusMined
+		transfer.Error = ""
+		return
+	}
+	transfer.Status = StatusFailed
+	transfer.Error = "reverted"
+}
+
+func (t *Transferer) fail(transfer *Transfer, reason string) {
+	transfer.Status = StatusFailed
+	transfer.Error = reason
+}
+
+func (t *Transferer) save(job *Job) error {
+	job.UpdatedAt = time.Now()
+	return t.store.Save(job)
+}
@@ -0,0 +1,314 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	"tiny-blockchain-app/app/pkg/wallet"
+	smartcontract "tiny-blockchain-app/smartcontract/golang"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakySender : fail 번째 SendTransaction 을 실패시킴 (deliver 가 true 이면 노드에는 전달하고 오류만 반환)
+type flakySender struct {
+	backend.Backend
+	fail    int
+	deliver bool
+	calls   int
+}
+
+func (f *flakySender) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	f.calls++
+	if f.calls != f.fail {
+		return f.Backend.SendTransaction(ctx, tx)
+	}
+	if f.deliver {
+		if err := f.Backend.SendTransaction(ctx, tx); err != nil {
+			return err
+		}
+	}
+	return errors.New("connection reset")
+}
+
+// replacingSender : fail 번째 SendTransaction 대신 replace 가 만든 같은 nonce 의 트랜잭션을 전송하고 오류 반환
+// (응답을 받지 못한 사이 다른 곳에서 speed-up 이나 cancel 한 경우, accepted 이면 오류 없이 성공 응답)
+// replace 가 nil 이면 아무것도 전송하지 않음 (노드의 txpool 에서 사라진 경우)
+type replacingSender struct {
+	backend.Backend
+	fail     int
+	replace  func(tx *types.Transaction) *types.Transaction
+	accepted bool
+	calls    int
+	replaced *types.Transaction
+}
+
+func (r *replacingSender) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	r.calls++
+	if r.calls != r.fail {
+		return r.Backend.SendTransaction(ctx, tx)
+	}
+	if r.replace != nil {
+		r.replaced = r.replace(tx)
+		if err := r.Backend.SendTransaction(ctx, r.replaced); err != nil {
+			return err
+		}
+	}
+	if r.accepted {
+		return nil
+	}
+	return errors.New("connection reset")
+}
+
+// records : nonce 별 교체 트랜잭션 기록
+type records map[uint64]*types.Transaction
+
+func (r records) Transaction(from common.Address, nonce uint64) *types.Transaction {
+	return r[nonce]
+}
+
+type batchFixture struct {
+	sim     *simulated.Backend
+	owner   wallet.Signer
+	token   common.Address
+	erc20   *smartcontract.ERC20Burnable
+	rows    []Row
+	store   *FileStore
+	context context.Context
+}
+
+func newBatchFixture(t *testing.T, recipients int, minted int64) *batchFixture {
+	sim, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	t.Cleanup(func() { sim.Close() })
+
+	owner := sim.Accounts[0]
+	token, erc20, err := sim.DeployERC20Burnable(owner, "Airdrop", "AIR", 18)
+	assert.Equal(t, nil, err)
+	err = sim.MintERC20Burnable(owner, token, owner.Address(), big.NewInt(minted))
+	assert.Equal(t, nil, err)
+
+	var rows []Row
+	for i := 0; i < recipients; i++ {
+		rows = append(rows, Row{Line: i + 1, To: common.BigToAddress(big.NewInt(int64(1000 + i))), Amount: big.NewInt(int64(10 * (i + 1)))})
+	}
+	return &batchFixture{sim: sim, owner: owner, token: token, erc20: erc20, rows: rows, store: NewFileStore(t.TempDir()), context: context.Background()}
+}
+
+func (f *batchFixture) transferer(t *testing.T, client backend.Backend) *Transferer {
+	transferer, err := NewTransferer(client, f.owner, f.store)
+	assert.Equal(t, nil, err)
+	transferer.MaxInFlight = 2
+	transferer.PollInterval = 10 * time.Millisecond
+	return transferer
+}
+
+func (f *batchFixture) assertBalances(t *testing.T) {
+	for _, row := range f.rows {
+		balance, err := f.erc20.BalanceOf(&bind.CallOpts{}, row.To)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, row.Amount, balance, row.To.Hex())
+	}
+}
+
+func (f *batchFixture) nonce(t *testing.T) uint64 {
+	nonce, err := f.sim.NonceAt(f.context, f.owner.Address(), nil)
+	assert.Equal(t, nil, err)
+	return nonce
+}
+
+func TestTransferer_Run(t *testing.T) {
+	f := newBatchFixture(t, 5, 1000)
+
+	job, err := f.transferer(t, f.sim).Run(f.context, "airdrop-1", f.token, f.rows)
+	assert.Equal(t, nil, err)
+	summary := job.Summary()
+	assert.Equal(t, 5, summary.Mined)
+	assert.Equal(t, "150", summary.Amount)
+	assert.True(t, summary.Complete)
+	f.assertBalances(t)
+
+	// 연속된 nonce, 저장된 작업과 같음
+	for i, transfer := range job.Transfers {
+		assert.Equal(t, *job.Transfers[0].Nonce+uint64(i), *transfer.Nonce)
+	}
+	saved, err := f.store.Load("airdrop-1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, job.Summary(), saved.Summary())
+
+	// 다시 실행해도 보내지 않음
+	nonce := f.nonce(t)
+	_, err = f.transferer(t, f.sim).Run(f.context, "airdrop-1", f.token, f.rows)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nonce, f.nonce(t))
+	f.assertBalances(t)
+
+	// 다른 수신자 목록으로 같은 작업을 실행할 수 없음
+	_, err = f.transferer(t, f.sim).Run(f.context, "airdrop-1", f.token, f.rows[:2])
+	assert.NotEqual(t, nil, err)
+}
+
+func TestTransferer_InsufficientBalance(t *testing.T) {
+	f := newBatchFixture(t, 5, 100)
+	nonce := f.nonce(t)
+
+	job, err := f.transferer(t, f.sim).Run(f.context, "too-much", f.token, f.rows)
+	assert.True(t, errors.Is(err, ErrInsufficientBalance))
+	assert.Equal(t, 5, job.Summary().Pending)
+	assert.Equal(t, nonce, f.nonce(t))
+}
+
+func TestTransferer_ResumeAfterFailure(t *testing.T) {
+	cases := []struct {
+		name    string
+		deliver bool
+	}{
+		{"not delivered", false},
+		{"delivered but failed to respond", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newBatchFixture(t, 5, 1000)
+
+			job, err := f.transferer(t, &flakySender{Backend: f.sim, fail: 3, deliver: tc.deliver}).Run(f.context, "resume", f.token, f.rows)
+			assert.NotEqual(t, nil, err)
+			summary := job.Summary()
+			assert.Equal(t, 2, summary.Mined)
+			assert.Equal(t, 1, summary.Sent)
+			assert.Equal(t, 2, summary.Pending)
+
+			job, err = f.transferer(t, f.sim).Run(f.context, "resume", f.token, f.rows)
+			assert.Equal(t, nil, err)
+			assert.True(t, job.Summary().Complete)
+			f.assertBalances(t)
+			assert.Equal(t, *job.Transfers[4].Nonce+1, f.nonce(t))
+		})
+	}
+}
+
+func TestTransferer_Reverted(t *testing.T) {
+	f := newBatchFixture(t, 3, 1000)
+
+	// 토큰이 정지되면 가스 추정이 실패하여 failed, 정지를 풀고 다시 실행하면 전송
+	_, err := f.erc20.Pause(f.sim.TransactOpts(f.owner))
+	assert.Equal(t, nil, err)
+	job, err := f.transferer(t, f.sim).Run(f.context, "paused", f.token, f.rows)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, job.Summary().Failed)
+	assert.Equal(t, StatusFailed, job.Transfers[0].Status)
+
+	_, err = f.erc20.UnPause(f.sim.TransactOpts(f.owner))
+	assert.Equal(t, nil, err)
+	job, err = f.transferer(t, f.sim).Run(f.context, "paused", f.token, f.rows)
+	assert.Equal(t, nil, err)
+	assert.True(t, job.Summary().Complete)
+	f.assertBalances(t)
+}
+
+func TestTransferer_ResumeReplaced(t *testing.T) {
+	cases := []struct {
+		name string
+		// cancel : 자기 자신에게 0 을 보내는 트랜잭션으로 교체
+		cancel bool
+		// recorded : 교체 트랜잭션이 Records 에 있음
+		recorded bool
+		status   Status
+	}{
+		{"speed-up recorded", false, true, StatusMined},
+		{"speed-up not recorded", false, false, StatusUnknown},
+		{"cancelled", true, true, StatusUnknown},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newBatchFixture(t, 3, 1000)
+			chainID, err := f.sim.ChainID(f.context)
+			assert.Equal(t, nil, err)
+
+			sender := &replacingSender{Backend: f.sim, fail: 2, replace: func(tx *types.Transaction) *types.Transaction {
+				to, value, data := *tx.To(), tx.Value(), tx.Data()
+				if tc.cancel {
+					to, value, data = f.owner.Address(), new(big.Int), nil
+				}
+				price := new(big.Int).Mul(tx.GasPrice(), big.NewInt(2))
+				replacement, err := f.owner.SignTx(types.NewTransaction(tx.Nonce(), to, value, tx.Gas(), price, data), chainID)
+				assert.Equal(t, nil, err)
+				return replacement
+			}}
+			job, err := f.transferer(t, sender).Run(f.context, "replaced", f.token, f.rows)
+			assert.NotEqual(t, nil, err)
+			assert.Equal(t, StatusSent, job.Transfers[1].Status)
+
+			transferer := f.transferer(t, f.sim)
+			if tc.recorded {
+				transferer.Records = records{sender.replaced.Nonce(): sender.replaced}
+			}
+			job, err = transferer.Run(f.context, "replaced", f.token, f.rows)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, tc.status, job.Transfers[1].Status)
+			if tc.status == StatusMined {
+				assert.Equal(t, sender.replaced.Hash().Hex(), job.Transfers[1].TxHash)
+				assert.True(t, job.Summary().Complete)
+				f.assertBalances(t)
+			} else {
+				assert.Equal(t, 1, job.Summary().Unknown)
+				assert.False(t, job.Summary().Complete)
+			}
+
+			// unknown 인 행은 다시 실행해도 보내지 않음
+			nonce := f.nonce(t)
+			job, err = f.transferer(t, f.sim).Run(f.context, "replaced", f.token, f.rows)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, tc.status, job.Transfers[1].Status)
+			assert.Equal(t, nonce, f.nonce(t))
+		})
+	}
+}
+
+func TestTransferer_WaitLost(t *testing.T) {
+	f := newBatchFixture(t, 3, 1000)
+	chainID, err := f.sim.ChainID(f.context)
+	assert.Equal(t, nil, err)
+
+	// 노드가 받은 뒤 txpool 에서 사라지면 기다리지 않고 오류, 다시 실행하면 재전송
+	job, err := f.transferer(t, &replacingSender{Backend: f.sim, fail: 3, accepted: true}).Run(f.context, "lost", f.token, f.rows)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, StatusSent, job.Transfers[2].Status)
+	job, err = f.transferer(t, f.sim).Run(f.context, "lost", f.token, f.rows)
+	assert.Equal(t, nil, err)
+	assert.True(t, job.Summary().Complete)
+	f.assertBalances(t)
+
+	// 기다리는 동안 다른 트랜잭션이 nonce 를 사용하면 unknown
+	f = newBatchFixture(t, 3, 1000)
+	cancel := func(tx *types.Transaction) *types.Transaction {
+		price := new(big.Int).Mul(tx.GasPrice(), big.NewInt(2))
+		cancelled, err := f.owner.SignTx(types.NewTransaction(tx.Nonce(), f.owner.Address(), new(big.Int), 21000, price, nil), chainID)
+		assert.Equal(t, nil, err)
+		return cancelled
+	}
+	job, err = f.transferer(t, &replacingSender{Backend: f.sim, fail: 2, replace: cancel, accepted: true}).Run(f.context, "cancelled", f.token, f.rows)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StatusUnknown, job.Transfers[1].Status)
+	assert.Equal(t, StatusMined, job.Transfers[2].Status)
+}
+
+func TestTransferer_Running(t *testing.T) {
+	f := newBatchFixture(t, 1, 1000)
+
+	running.Store("busy", struct{}{})
+	defer running.Delete("busy")
+	_, err := f.transferer(t, f.sim).Run(f.context, "busy", f.token, f.rows)
+	assert.True(t, errors.Is(err, ErrJobRunning))
+	job, err := f.store.Load("busy")
+	assert.Equal(t, nil, err)
+	assert.True(t, job == nil)
+}
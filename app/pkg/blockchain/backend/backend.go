@@ -0,0 +1,133 @@
+// backend : 패키지들이 *ethclient.Client 대신 의존하는 체인 접근 인터페이스와 middleware
+//
+// *ethclient.Client, client.Pool, simulated.Backend 모두 Backend 를 구현하며,
+// 사용하는 쪽은 필요한 만큼의 작은 인터페이스(ChainReader, LogSubscriber, Transactor)만 받음
+package backend
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ChainReader : 블록, 트랜잭션, 잔액 조회
+type ChainReader interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error)
+	TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// LogSubscriber : 이벤트 조회/구독 (구독 지연 측정을 위한 최신 블록 번호 포함)
+type LogSubscriber interface {
+	ethereum.LogFilterer
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Transactor : 컨트랙트 배포, 호출, 트랜잭션 전송과 receipt 대기
+type Transactor interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// Backend : 애플리케이션이 사용하는 체인 접근 전체
+type Backend interface {
+	ChainReader
+	LogSubscriber
+	Transactor
+}
+
+// BatchCaller : 여러 JSON-RPC 요청을 한 번에 보낼 수 있는 연결 (*Client, client.Pool)
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error
+}
+
+// ErrBatchUnsupported : 연결이 JSON-RPC batch 를 지원하지 않음 (simulated.Backend 등), 요청을 하나씩 보내야 함
+var ErrBatchUnsupported = errors.New("json-rpc batch is not supported")
+
+// BatchCall : client 가 BatchCaller 이면 batch 전송, 아니면 ErrBatchUnsupported
+func BatchCall(ctx context.Context, client interface{}, batch []rpc.BatchElem) error {
+	caller, ok := client.(BatchCaller)
+	if !ok {
+		return ErrBatchUnsupported
+	}
+	return caller.BatchCallContext(ctx, batch)
+}
+
+// TxPool : 노드 txpool 의 계정별 트랜잭션 (nonce 순서)
+// Pending 은 바로 실행할 수 있는 트랜잭션, Queued 는 앞 nonce 가 비어 대기 중인 트랜잭션
+type TxPool struct {
+	Pending map[common.Address][]*types.Transaction
+	Queued  map[common.Address][]*types.Transaction
+}
+
+// TxPoolReader : txpool 을 조회할 수 있는 연결 (*Client, client.Pool, simulated.Backend)
+type TxPoolReader interface {
+	TxPoolContent(ctx context.Context) (*TxPool, error)
+}
+
+// ErrTxPoolUnsupported : 연결이 txpool 조회를 지원하지 않음
+var ErrTxPoolUnsupported = errors.New("txpool content is not supported")
+
+// TxPoolContent : client 가 TxPoolReader 이면 txpool 조회, 아니면 ErrTxPoolUnsupported
+func TxPoolContent(ctx context.Context, client interface{}) (*TxPool, error) {
+	reader, ok := client.(TxPoolReader)
+	if !ok {
+		return nil, ErrTxPoolUnsupported
+	}
+	return reader.TxPoolContent(ctx)
+}
+
+// NonceReleaser : PendingNonceAt 으로 nonce 를 할당하는 연결 (client.NonceManager.Backend)
+type NonceReleaser interface {
+	ReleaseNonce(account common.Address, nonce uint64)
+}
+
+// ReleaseNonce : PendingNonceAt 으로 받은 nonce 를 전송하지 못했을 때 반환 (client 가 NonceReleaser 가 아니면 무시)
+// 서명 전 조회나 서명이 실패하면 호출해야 할당된 nonce 가 비어 이후 트랜잭션이 막히지 않음
+func ReleaseNonce(client interface{}, account common.Address, nonce uint64) {
+	if releaser, ok := client.(NonceReleaser); ok {
+		releaser.ReleaseNonce(account, nonce)
+	}
+}
+
+// IsConnectionError : 노드에 도달하지 못한 오류인지 여부 (재시도, 다른 노드로 failover 대상)
+// 노드가 JSON-RPC 오류로 응답했거나 결과가 없는 경우(NotFound)는 다시 보내도 같은 결과
+func IsConnectionError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		// 4xx는 요청 자체의 문제, 5xx는 노드 문제
+		return httpErr.StatusCode >= 500
+	}
+	return true
+}
+
+// IsAlreadyKnown : 같은 서명 트랜잭션이 이미 txpool 에 있는 경우
+// 연결 오류 후 재전송했다면 앞선 전송이 도달한 것이므로 성공으로 처리
+func IsAlreadyKnown(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "already known")
+}
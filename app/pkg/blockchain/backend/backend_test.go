@@ -0,0 +1,142 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+	"tiny-blockchain-app/app/pkg/metrics"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubBackend : 실패할 횟수를 지정할 수 있는 Backend (사용하지 않는 메서드는 nil 인터페이스로 남김)
+type stubBackend struct {
+	Backend
+	failures int
+	err      error
+	calls    int
+}
+
+func (s *stubBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return 0, s.err
+	}
+	return 7, nil
+}
+
+func (s *stubBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	s.calls++
+	return nil, ethereum.NotFound
+}
+
+func (s *stubBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	s.calls++
+	if s.calls == 1 {
+		return s.err
+	}
+	return errors.New("already known")
+}
+
+// rpcError : 노드가 JSON-RPC 오류로 응답한 경우
+type rpcError struct{}
+
+func (rpcError) Error() string  { return "execution reverted" }
+func (rpcError) ErrorCode() int { return 3 }
+
+func TestWrap_Order(t *testing.T) {
+	var order []string
+	record := func(name string) Middleware {
+		return func(ctx context.Context, method string, call Call) error {
+			order = append(order, name+":"+method)
+			return call(ctx)
+		}
+	}
+
+	wrapped := Wrap(&stubBackend{}, record("outer"), record("inner"))
+	number, err := wrapped.BlockNumber(context.Background())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(7), number)
+	assert.Equal(t, []string{"outer:BlockNumber", "inner:BlockNumber"}, order)
+}
+
+func TestRetry(t *testing.T) {
+	stub := &stubBackend{failures: 2, err: errors.New("connection refused")}
+	wrapped := Wrap(stub, Retry(3, time.Millisecond))
+
+	number, err := wrapped.BlockNumber(context.Background())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(7), number)
+	assert.Equal(t, 3, stub.calls)
+
+	// 시도 횟수를 넘으면 마지막 오류 반환
+	stub = &stubBackend{failures: 5, err: errors.New("connection refused")}
+	_, err = Wrap(stub, Retry(3, time.Millisecond)).BlockNumber(context.Background())
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 3, stub.calls)
+
+	// JSON-RPC 오류, NotFound 는 재시도하지 않음
+	stub = &stubBackend{failures: 1, err: rpcError{}}
+	_, err = Wrap(stub, Retry(3, time.Millisecond)).BlockNumber(context.Background())
+	assert.Equal(t, rpcError{}, err)
+	assert.Equal(t, 1, stub.calls)
+
+	stub = &stubBackend{}
+	_, err = Wrap(stub, Retry(3, time.Millisecond)).TransactionReceipt(context.Background(), common.Hash{})
+	assert.Equal(t, ethereum.NotFound, err)
+	assert.Equal(t, 1, stub.calls)
+}
+
+func TestRetry_SendTransactionAlreadyKnown(t *testing.T) {
+	stub := &stubBackend{err: errors.New("EOF")}
+	wrapped := Wrap(stub, Retry(3, time.Millisecond))
+
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	err := wrapped.SendTransaction(context.Background(), tx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, stub.calls)
+}
+
+func TestRateLimit(t *testing.T) {
+	wrapped := Wrap(&stubBackend{}, RateLimit(1, 1))
+
+	_, err := wrapped.BlockNumber(context.Background())
+	assert.Equal(t, nil, err)
+
+	// 한도를 넘은 호출은 ctx 가 끝나면 실패
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = wrapped.BlockNumber(ctx)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestMetrics(t *testing.T) {
+	wrapped := Wrap(&stubBackend{}, Metrics())
+
+	ok := metrics.BackendCalls.Value("BlockNumber", "ok")
+	notFound := metrics.BackendCalls.Value("TransactionReceipt", "not_found")
+
+	wrapped.BlockNumber(context.Background())
+	wrapped.TransactionReceipt(context.Background(), common.Hash{})
+
+	assert.Equal(t, ok+1, metrics.BackendCalls.Value("BlockNumber", "ok"))
+	assert.Equal(t, notFound+1, metrics.BackendCalls.Value("TransactionReceipt", "not_found"))
+	assert.Equal(t, true, metrics.BackendDuration.Count("BlockNumber") > 0)
+}
+
+func TestIsConnectionError(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, false, IsConnectionError(ctx, nil))
+	assert.Equal(t, true, IsConnectionError(ctx, errors.New("dial tcp: connection refused")))
+	assert.Equal(t, false, IsConnectionError(ctx, ethereum.NotFound))
+	assert.Equal(t, false, IsConnectionError(ctx, rpcError{}))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, false, IsConnectionError(canceled, errors.New("context canceled")))
+}
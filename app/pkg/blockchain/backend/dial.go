@@ -0,0 +1,72 @@
+package backend
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"tiny-blockchain-app/app/pkg/logging"
+	"tiny-blockchain-app/app/pkg/metrics"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client : *ethclient.Client 에 JSON-RPC batch 요청과 txpool 조회를 더한 연결 (BatchCaller, TxPoolReader 구현)
+type Client struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+// NewClient : 이미 연결된 rpc client 로 생성
+func NewClient(c *rpc.Client) *Client {
+	return &Client{Client: ethclient.NewClient(c), rpc: c}
+}
+
+// BatchCallContext : 여러 요청을 하나의 JSON-RPC batch 로 전송 (요청별 오류는 BatchElem.Error)
+func (c *Client) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	return c.rpc.BatchCallContext(ctx, batch)
+}
+
+// TxPoolContent : txpool_content (노드에 txpool API 가 열려 있어야 함)
+func (c *Client) TxPoolContent(ctx context.Context) (*TxPool, error) {
+	var content map[string]map[common.Address]map[string]*types.Transaction
+	if err := c.rpc.CallContext(ctx, &content, "txpool_content"); err != nil {
+		return nil, err
+	}
+	return &TxPool{Pending: sortByNonce(content["pending"]), Queued: sortByNonce(content["queued"])}, nil
+}
+
+// sortByNonce : txpool_content 의 계정별 nonce → 트랜잭션을 nonce 순서 목록으로
+func sortByNonce(accounts map[common.Address]map[string]*types.Transaction) map[common.Address][]*types.Transaction {
+	sorted := make(map[common.Address][]*types.Transaction, len(accounts))
+	for account, txs := range accounts {
+		list := make([]*types.Transaction, 0, len(txs))
+		for _, tx := range txs {
+			list = append(list, tx)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Nonce() < list[j].Nonce() })
+		sorted[account] = list
+	}
+	return sorted
+}
+
+// Dial : http(s) endpoint는 JSON-RPC 호출 메트릭과 (DebugMode일 때) 요청/응답 추적 로그를 기록하는 transport로 연결
+func Dial(endpoint string) (*Client, error) {
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		cli, err := rpc.DialContext(context.Background(), endpoint)
+		if err != nil {
+			return nil, err
+		}
+		return NewClient(cli), nil
+	}
+
+	transport := metrics.NewTransport(logging.NewTraceTransport(nil))
+	cli, err := rpc.DialHTTPWithClient(endpoint, &http.Client{Transport: transport})
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(cli), nil
+}
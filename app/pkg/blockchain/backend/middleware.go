@@ -0,0 +1,307 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+	"tiny-blockchain-app/app/pkg/logging"
+	"tiny-blockchain-app/app/pkg/metrics"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/time/rate"
+)
+
+// Call : backend 메서드 한 번의 호출
+type Call func(ctx context.Context) error
+
+// Middleware : method(Backend 메서드 이름) 호출 앞뒤에 동작을 추가, call 을 호출하면 다음 middleware 로 진행
+type Middleware func(ctx context.Context, method string, call Call) error
+
+// Wrap : middlewares 를 적용한 Backend (앞의 middleware 가 바깥쪽)
+//
+//	backend.Wrap(pool, backend.Logging(logger), backend.Metrics(), backend.RateLimit(50, 10), backend.Retry(3, 200*time.Millisecond))
+func Wrap(next Backend, middlewares ...Middleware) Backend {
+	handler := func(ctx context.Context, method string, call Call) error {
+		return call(ctx)
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		middleware, inner := middlewares[i], handler
+		handler = func(ctx context.Context, method string, call Call) error {
+			return middleware(ctx, method, func(ctx context.Context) error {
+				return inner(ctx, method, call)
+			})
+		}
+	}
+	return &wrapped{next: next, handle: handler}
+}
+
+// Retry : 연결 오류(IsConnectionError)면 backoff 간격을 두 배씩 늘리며 최대 attempts 번까지 호출
+func Retry(attempts int, backoff time.Duration) Middleware {
+	return func(ctx context.Context, method string, call Call) error {
+		for attempt := 1; ; attempt++ {
+			err := call(ctx)
+			if attempt >= attempts || !IsConnectionError(ctx, err) {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff << (attempt - 1)):
+			}
+		}
+	}
+}
+
+// Metrics : 메서드별 호출 수와 지연 시간 기록 (status: ok|not_found|error)
+func Metrics() Middleware {
+	return func(ctx context.Context, method string, call Call) error {
+		start := time.Now()
+		err := call(ctx)
+		metrics.BackendDuration.Observe(time.Since(start).Seconds(), method)
+		metrics.BackendCalls.Inc(method, callStatus(err))
+		return err
+	}
+}
+
+// Logging : 호출을 debug 레벨로, 실패(NotFound 제외)는 warn 레벨로 기록
+func Logging(logger log.Logger) Middleware {
+	return func(ctx context.Context, method string, call Call) error {
+		start := time.Now()
+		err := call(ctx)
+		callLogger := logging.FromContext(ctx, logger)
+		if callStatus(err) == "error" {
+			callLogger.Warn("Backend call failed", "method", method, "elapsed", time.Since(start), "err", err)
+		} else {
+			callLogger.Debug("Backend call", "method", method, "elapsed", time.Since(start))
+		}
+		return err
+	}
+}
+
+// RateLimit : 초당 limit 회(최대 burst 회 연속)로 호출 제한, 한도를 넘으면 ctx 가 끝날 때까지 대기
+func RateLimit(limit float64, burst int) Middleware {
+	limiter := rate.NewLimiter(rate.Limit(limit), burst)
+	return func(ctx context.Context, method string, call Call) error {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		return call(ctx)
+	}
+}
+
+func callStatus(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ethereum.NotFound):
+		return "not_found"
+	default:
+		return "error"
+	}
+}
+
+// wrapped : 모든 Backend 메서드를 handle 을 거쳐 next 로 전달
+type wrapped struct {
+	next   Backend
+	handle func(ctx context.Context, method string, call Call) error
+}
+
+// Close : next 가 연결 종료를 지원하면 (*ethclient.Client, client.Pool) 종료
+func (w *wrapped) Close() {
+	if closer, ok := w.next.(interface{ Close() }); ok {
+		closer.Close()
+	}
+}
+
+// BatchCallContext : next 가 batch 를 지원하지 않으면 handle 을 거치지 않고 ErrBatchUnsupported
+func (w *wrapped) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	if _, ok := w.next.(BatchCaller); !ok {
+		return ErrBatchUnsupported
+	}
+	return w.handle(ctx, "BatchCallContext", func(ctx context.Context) error {
+		return BatchCall(ctx, w.next, batch)
+	})
+}
+
+// TxPoolContent : next 가 txpool 조회를 지원하지 않으면 handle 을 거치지 않고 ErrTxPoolUnsupported
+func (w *wrapped) TxPoolContent(ctx context.Context) (pool *TxPool, err error) {
+	if _, ok := w.next.(TxPoolReader); !ok {
+		return nil, ErrTxPoolUnsupported
+	}
+	err = w.handle(ctx, "TxPoolContent", func(ctx context.Context) error {
+		pool, err = TxPoolContent(ctx, w.next)
+		return err
+	})
+	return pool, err
+}
+
+// ChainReader
+
+func (w *wrapped) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	err = w.handle(ctx, "ChainID", func(ctx context.Context) error {
+		chainID, err = w.next.ChainID(ctx)
+		return err
+	})
+	return chainID, err
+}
+
+func (w *wrapped) BlockNumber(ctx context.Context) (number uint64, err error) {
+	err = w.handle(ctx, "BlockNumber", func(ctx context.Context) error {
+		number, err = w.next.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+func (w *wrapped) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = w.handle(ctx, "HeaderByNumber", func(ctx context.Context) error {
+		header, err = w.next.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (w *wrapped) BlockByNumber(ctx context.Context, number *big.Int) (block *types.Block, err error) {
+	err = w.handle(ctx, "BlockByNumber", func(ctx context.Context) error {
+		block, err = w.next.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+func (w *wrapped) TransactionCount(ctx context.Context, blockHash common.Hash) (count uint, err error) {
+	err = w.handle(ctx, "TransactionCount", func(ctx context.Context) error {
+		count, err = w.next.TransactionCount(ctx, blockHash)
+		return err
+	})
+	return count, err
+}
+
+func (w *wrapped) TransactionByHash(ctx context.Context, txHash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	err = w.handle(ctx, "TransactionByHash", func(ctx context.Context) error {
+		tx, isPending, err = w.next.TransactionByHash(ctx, txHash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+func (w *wrapped) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = w.handle(ctx, "TransactionReceipt", func(ctx context.Context) error {
+		receipt, err = w.next.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+func (w *wrapped) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = w.handle(ctx, "BalanceAt", func(ctx context.Context) error {
+		balance, err = w.next.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+func (w *wrapped) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = w.handle(ctx, "NonceAt", func(ctx context.Context) error {
+		nonce, err = w.next.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+// ContractCaller
+
+func (w *wrapped) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = w.handle(ctx, "CodeAt", func(ctx context.Context) error {
+		code, err = w.next.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (w *wrapped) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (output []byte, err error) {
+	err = w.handle(ctx, "CallContract", func(ctx context.Context) error {
+		output, err = w.next.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return output, err
+}
+
+// ContractTransactor
+
+func (w *wrapped) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = w.handle(ctx, "PendingCodeAt", func(ctx context.Context) error {
+		code, err = w.next.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+func (w *wrapped) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = w.handle(ctx, "PendingNonceAt", func(ctx context.Context) error {
+		nonce, err = w.next.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+func (w *wrapped) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = w.handle(ctx, "SuggestGasPrice", func(ctx context.Context) error {
+		price, err = w.next.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (w *wrapped) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = w.handle(ctx, "SuggestGasTipCap", func(ctx context.Context) error {
+		tip, err = w.next.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+func (w *wrapped) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = w.handle(ctx, "EstimateGas", func(ctx context.Context) error {
+		gas, err = w.next.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction : 재시도로 같은 서명 트랜잭션을 다시 보냈을 때 "already known" 은 성공으로 처리
+func (w *wrapped) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	var attempted bool
+	return w.handle(ctx, "SendTransaction", func(ctx context.Context) error {
+		err := w.next.SendTransaction(ctx, tx)
+		if attempted && IsAlreadyKnown(err) {
+			return nil
+		}
+		attempted = true
+		return err
+	})
+}
+
+// ContractFilterer
+
+func (w *wrapped) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = w.handle(ctx, "FilterLogs", func(ctx context.Context) error {
+		logs, err = w.next.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs : 구독 연결까지만 middleware 적용 (이후 구독 오류는 Subscription.Err 로 전달)
+func (w *wrapped) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (sub ethereum.Subscription, err error) {
+	err = w.handle(ctx, "SubscribeFilterLogs", func(ctx context.Context) error {
+		sub, err = w.next.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return sub, err
+}
@@ -1,33 +1,34 @@
 package blockchain
 
-// import (
-// 	"context"
-// 	"math/big"
+import (
+	"context"
+	"math/big"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
 
-// 	"github.com/ethereum/go-ethereum/core/types"
-// 	"github.com/ethereum/go-ethereum/ethclient"
-// )
+	"github.com/ethereum/go-ethereum/core/types"
+)
 
-// func GetBlockHeader(client *ethclient.Client) (string, error) {
-// 	header, err := client.HeaderByNumber(context.Background(), nil)
-// 	if err != nil {
-// 		return "", nil
-// 	}
-// 	return header.Number.String(), nil
-// }
+func GetBlockHeader(client backend.ChainReader) (string, error) {
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return "", err
+	}
+	return header.Number.String(), nil
+}
 
-// func GetBlock(client *ethclient.Client, blockNumber *big.Int) (*types.Block, error) {
-// 	block, err := client.BlockByNumber(context.Background(), blockNumber)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return block, nil
-// }
+// GetBlock : blockNumber가 nil이면 최신 블록 조회
+func GetBlock(client backend.ChainReader, blockNumber *big.Int) (*types.Block, error) {
+	block, err := client.BlockByNumber(context.Background(), blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
 
-// func GetTransactionCount(client *ethclient.Client, block *types.Block) (uint, error) {
-// 	txCount, err := client.TransactionCount(context.Background(), block.Hash())
-// 	if err != nil {
-// 		return 0, err
-// 	}
-// 	return txCount, nil
-// }
+func GetTransactionCount(client backend.ChainReader, block *types.Block) (uint, error) {
+	txCount, err := client.TransactionCount(context.Background(), block.Hash())
+	if err != nil {
+		return 0, err
+	}
+	return txCount, nil
+}
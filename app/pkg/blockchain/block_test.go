@@ -1,44 +1,52 @@
 package blockchain
 
-// import (
-// 	"math/big"
-// 	"testing"
-// 	"tiny-blockchain-app/config"
-
-// 	"github.com/stretchr/testify/assert"
-// )
-
-// func TestGetBlockHeader(t *testing.T) {
-// 	conf := config.Config{
-// 		Endpoint: "http://127.0.0.1:22001",
-// 	}
-// 	client, _ := NewClient(conf)
-
-// 	blockHeaderNumber, err := GetBlockHeader(client)
-// 	assert.Equal(t, nil, err)
-// 	assert.Equal(t, "0", blockHeaderNumber)
-// }
-
-// func TestGetBlock(t *testing.T) {
-// 	conf := config.Config{
-// 		Endpoint: "http://127.0.0.1:22001",
-// 	}
-// 	client, _ := NewClient(conf)
-
-// 	block, err := GetBlock(client, big.NewInt(0))
-// 	assert.Equal(t, nil, err)
-// 	t.Log(block)
-// }
-
-// func TestGetTransactionCount(t *testing.T) {
-// 	conf := config.Config{
-// 		Endpoint: "http://127.0.0.1:22001",
-// 	}
-// 	client, _ := NewClient(conf)
-
-// 	block, err := GetBlock(client, big.NewInt(0))
-// 	assert.Equal(t, nil, err)
-// 	txCount, err := GetTransactionCount(client, block)
-// 	assert.Equal(t, nil, err)
-// 	assert.Equal(t, uint(0), txCount)
-// }
+import (
+	"math/big"
+	"testing"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestChain(t *testing.T) *simulated.Backend {
+	client, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestGetBlockHeader(t *testing.T) {
+	client := newTestChain(t)
+
+	blockHeaderNumber, err := GetBlockHeader(client)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "0", blockHeaderNumber)
+}
+
+func TestGetBlock(t *testing.T) {
+	client := newTestChain(t)
+	_, err := client.NewAccount(big.NewInt(1))
+	assert.Equal(t, nil, err)
+
+	block, err := GetBlock(client, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(1), block.NumberU64())
+}
+
+func TestGetTransactionCount(t *testing.T) {
+	client := newTestChain(t)
+
+	block, err := GetBlock(client, big.NewInt(0))
+	assert.Equal(t, nil, err)
+	txCount, err := GetTransactionCount(client, block)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint(0), txCount)
+
+	_, err = client.NewAccount(big.NewInt(1))
+	assert.Equal(t, nil, err)
+	block, err = GetBlock(client, big.NewInt(1))
+	assert.Equal(t, nil, err)
+	txCount, err = GetTransactionCount(client, block)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint(1), txCount)
+}
@@ -2,27 +2,62 @@ package client
 
 import (
 	"errors"
+	"time"
 
 	config "tiny-blockchain-app/app/config"
-	"tiny-blockchain-app/app/pkg/wallet"
-
-	"github.com/ethereum/go-ethereum/ethclient"
+	"tiny-blockchain-app/app/pkg/blockchain"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/logging"
 )
 
-type EthereumController struct {
-	Client  *ethclient.Client
-	KeyPair wallet.KeyPair
-}
-
-func NewClient(conf config.Config) (*ethclient.Client, error) {
-	if conf.Endpoint == "" {
+// NewClient : endPoint(없으면 endPoints 의 첫 번째)에 연결
+func NewClient(conf config.Config) (*backend.Client, error) {
+	endpoint := conf.PrimaryEndpoint()
+	if endpoint == "" {
 		return nil, errors.New("no endpoint info")
 	}
 
-	client, err := ethclient.Dial(conf.Endpoint)
+	client, err := backend.Dial(endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	return client, nil
 }
+
+// NewBackend : endPoints(없으면 endPoint)로 Pool 을 만들고 설정에 따른 middleware 적용
+func NewBackend(conf blockchain.Config) (backend.Backend, error) {
+	pool, err := dialPool(conf)
+	if err != nil {
+		return nil, err
+	}
+	return backend.Wrap(pool, Middlewares(conf)...), nil
+}
+
+func dialPool(conf blockchain.Config) (*Pool, error) {
+	endpoints := conf.EndPoints
+	if len(endpoints) == 0 && conf.EndPoint != "" {
+		endpoints = []string{conf.EndPoint}
+	}
+	return DialPool(endpoints)
+}
+
+// Middlewares : 로깅, 메트릭, (rpcRateLimit > 0 이면) rate limit, (rpcRetryCnt > 0 이면) 재시도 순으로 적용
+func Middlewares(conf blockchain.Config) []backend.Middleware {
+	middlewares := []backend.Middleware{
+		backend.Logging(logging.New("backend")),
+		backend.Metrics(),
+	}
+	if conf.RPCRateLimit > 0 {
+		burst := conf.RPCRateBurst
+		if burst < 1 {
+			burst = 1
+		}
+		middlewares = append(middlewares, backend.RateLimit(conf.RPCRateLimit, burst))
+	}
+	if conf.RPCRetryCnt > 0 {
+		interval := time.Duration(conf.RPCRetryIntervalMilliSec) * time.Millisecond
+		middlewares = append(middlewares, backend.Retry(int(conf.RPCRetryCnt)+1, interval))
+	}
+	return middlewares
+}
@@ -2,16 +2,26 @@ package client
 
 import (
 	"context"
-	"math/big"
+	"net/http/httptest"
 	"testing"
+	"time"
 	"tiny-blockchain-app/app/config"
+	"tiny-blockchain-app/app/pkg/blockchain"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewClient(t *testing.T) {
+	backend, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	node := httptest.NewServer(backend.Handler())
+	defer node.Close()
+
 	conf := config.Config{
-		Endpoint: "http://127.0.0.1:22001",
+		Endpoint: node.URL,
 	}
 	client, err := NewClient(conf)
 
@@ -19,8 +29,47 @@ func TestNewClient(t *testing.T) {
 	assert.NotEmpty(t, client)
 
 	chainId, err := client.ChainID(context.Background())
-	bigIntChainId, _ := new(big.Int).SetString("10", 10)
+	expected, _ := backend.ChainID(context.Background())
 
 	assert.NoError(t, err)
-	assert.Equal(t, bigIntChainId, chainId)
+	assert.Equal(t, expected, chainId)
+}
+
+func TestNewClient_NoEndpoint(t *testing.T) {
+	_, err := NewClient(config.Config{})
+	assert.NotEqual(t, nil, err)
+}
+
+func TestNewBackend(t *testing.T) {
+	backend, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	node := httptest.NewServer(backend.Handler())
+	defer node.Close()
+
+	cli, err := NewBackend(blockchain.Config{EndPoints: []string{node.URL}, RPCRetryCnt: 1})
+	assert.Equal(t, nil, err)
+
+	chainId, err := cli.ChainID(context.Background())
+	expected, _ := backend.ChainID(context.Background())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expected, chainId)
+}
+
+func TestNewEthereumController_HealthCheck(t *testing.T) {
+	backend, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	node := httptest.NewServer(backend.Handler())
+	defer node.Close()
+
+	controller, err := NewEthereumController(blockchain.Config{EndPoints: []string{node.URL}, HealthCheckIntervalSec: 1})
+	assert.Equal(t, nil, err)
+
+	// 연결 오류로 비정상 표시된 노드는 다음 health check 에서 복구
+	controller.pool.endpoints[0].setHealthy(false)
+	assert.Eventually(t, func() bool { return len(controller.pool.Healthy()) == 1 }, 3*time.Second, 50*time.Millisecond)
+	assert.Equal(t, nil, controller.Shutdown(context.Background()))
 }
@@ -0,0 +1,256 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"tiny-blockchain-app/app/pkg/batch"
+	"tiny-blockchain-app/app/pkg/blockchain"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/blockchain/event"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// defaultHealthCheckInterval : healthCheckIntervalSec 가 0 일 때 비정상 노드 확인 주기
+const defaultHealthCheckInterval = 10 * time.Second
+
+var (
+	ErrShuttingDown = errors.New("ethereum controller is shutting down")
+	ErrNoSigner     = errors.New("no signer")
+	ErrNoBatchStore = errors.New("batch store is not configured")
+)
+
+// EthereumController : 연결, 서명 계정, nonce, 컨트랙트 목록을 소유하고 토큰/스왑/이벤트/블록 서비스를 제공
+type EthereumController struct {
+	// Client : HTTP 연결 (Pool + middleware), PendingNonceAt 은 Nonces 가 할당, TransactionReceipt 는 Transactions 의 교체 이력을 따라감
+	Client backend.Backend
+	// WebSocket : 이벤트 구독용 연결 (websocket 설정이 없으면 Client)
+	WebSocket backend.Backend
+	Nonces    *NonceManager
+	// Transactions : 전송한 트랜잭션 기록과 speed-up, cancel 교체 이력, nonce 빈칸 확인
+	Transactions *txmanager.Manager
+	// Journal : 전송 전에 기록한 모든 서명 트랜잭션 (Journal.SetStore 로 파일에 저장, Journal.Run 으로 다시 전송)
+	Journal   *journal.Journal
+	Contracts *Registry
+	// Checkpoints : Events.SubscribeDurable 구독의 재시작 블록 저장소 (nil 이면 저장하지 않음)
+	Checkpoints event.CheckpointStore
+	// Batches : Tokens.BatchTransfer 작업 저장소 (nil 이면 일괄 전송 사용 불가)
+	Batches batch.Store
+
+	Tokens *TokenService
+	Swap   *SwapService
+	Events *EventService
+	Blocks *BlockService
+	Reads  *ReadService
+	Txs    *TxService
+
+	// pool : NewEthereumController 로 만든 연결 (health check 대상)
+	pool *Pool
+
+	mu            sync.Mutex
+	signers       map[common.Address]wallet.Signer
+	defaultSigner wallet.Signer
+	subscribers   []trackedSubscriber
+	closers       []func()
+	inflight      sync.WaitGroup
+	closing       bool
+}
+
+// NewEthereumController : 설정의 endPoints 와 websocket 으로 연결 (첫 번째 signer 가 기본 서명 계정)
+// Shutdown 까지 healthCheckIntervalSec 주기로 비정상 노드를 다시 확인
+func NewEthereumController(conf blockchain.Config, signers ...wallet.Signer) (*EthereumController, error) {
+	pool, err := dialPool(conf)
+	if err != nil {
+		return nil, err
+	}
+	httpCli := backend.Wrap(pool, Middlewares(conf)...)
+	interval := time.Duration(conf.HealthCheckIntervalSec) * time.Second
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	ctx, stopHealthCheck := context.WithCancel(context.Background())
+	go pool.StartHealthCheck(ctx, interval)
+	closers := []func(){pool.Close, stopHealthCheck}
+
+	websocketCli := httpCli
+	if conf.WebSocket != "" {
+		ws, err := backend.Dial(conf.WebSocket)
+		if err != nil {
+			for i := len(closers) - 1; i >= 0; i-- {
+				closers[i]()
+			}
+			return nil, err
+		}
+		websocketCli = ws
+		closers = append(closers, ws.Close)
+	}
+
+	controller := NewEthereumControllerWithBackend(httpCli, websocketCli, signers...)
+	controller.pool = pool
+	controller.closers = closers
+	return controller, nil
+}
+
+// NewEthereumControllerWithBackend : 이미 연결된 backend 로 생성 (Shutdown 에서 연결을 닫지 않음)
+func NewEthereumControllerWithBackend(httpCli, websocketCli backend.Backend, signers ...wallet.Signer) *EthereumController {
+	// Transactions 는 노드의 pending nonce 를 조회해야 하므로 NonceManager 아래에서 연결 사용
+	// 교체 트랜잭션도 기록하도록 Journal 이 가장 아래
+	nonces := NewNonceManager(httpCli)
+	txJournal := journal.New(httpCli)
+	transactions := txmanager.New(txJournal.Backend())
+	transactions.Records = txJournal
+	c := &EthereumController{
+		Client:       nonces.Backend(transactions.Backend()),
+		WebSocket:    websocketCli,
+		Nonces:       nonces,
+		Transactions: transactions,
+		Journal:      txJournal,
+		Contracts:    NewRegistry(),
+		signers:      map[common.Address]wallet.Signer{},
+	}
+	for _, signer := range signers {
+		c.AddSigner(signer)
+	}
+
+	c.Tokens = &TokenService{controller: c}
+	c.Swap = &SwapService{controller: c}
+	c.Events = &EventService{controller: c, factory: event.NewEventFactoryWithBackend(c.Client, websocketCli)}
+	c.Blocks = &BlockService{controller: c}
+	c.Reads = &ReadService{controller: c}
+	c.Txs = &TxService{controller: c}
+	return c
+}
+
+// AddSigner : 서명 계정 추가 (처음 추가한 계정이 기본 서명 계정)
+func (c *EthereumController) AddSigner(signer wallet.Signer) {
+	if signer == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.signers[signer.Address()] = signer
+	if c.defaultSigner == nil {
+		c.defaultSigner = signer
+	}
+}
+
+// Signer : address 의 서명 계정
+func (c *EthereumController) Signer(address common.Address) (wallet.Signer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	signer, exist := c.signers[address]
+	if !exist {
+		return nil, ErrNoSigner
+	}
+	return signer, nil
+}
+
+// DefaultSigner : 서명 계정이 없으면 nil
+func (c *EthereumController) DefaultSigner() wallet.Signer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.defaultSigner
+}
+
+// begin : 트랜잭션 작업 시작 (Shutdown 이 시작되었으면 ErrShuttingDown), 끝나면 반환된 함수 호출
+func (c *EthereumController) begin() (func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closing {
+		return nil, ErrShuttingDown
+	}
+	c.inflight.Add(1)
+	return c.inflight.Done, nil
+}
+
+// transact : signer(nil 이면 기본 서명 계정)로 트랜잭션 작업 실행, Shutdown 은 끝날 때까지 기다림
+func (c *EthereumController) transact(signer wallet.Signer, fn func(signer wallet.Signer) error) error {
+	signer = c.signerOr(signer)
+	if signer == nil {
+		return ErrNoSigner
+	}
+	done, err := c.begin()
+	if err != nil {
+		return err
+	}
+	defer done()
+	return fn(signer)
+}
+
+func (c *EthereumController) signerOr(signer wallet.Signer) wallet.Signer {
+	if signer != nil {
+		return signer
+	}
+	return c.DefaultSigner()
+}
+
+// trackedSubscriber : name 이 있으면 Shutdown 에서 checkpoint 저장
+type trackedSubscriber struct {
+	name       string
+	subscriber *event.EventSubscriber
+}
+
+// track : 구독은 Shutdown 에서 해지
+func (c *EthereumController) track(name string, subscriber *event.EventSubscriber) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closing {
+		return ErrShuttingDown
+	}
+	c.subscribers = append(c.subscribers, trackedSubscriber{name: name, subscriber: subscriber})
+	return nil
+}
+
+// Shutdown : 새 트랜잭션을 거부하고 진행 중인 트랜잭션을 ctx 가 끝날 때까지 기다린 뒤 구독 해지, checkpoint 저장, 연결 종료
+// 기다리는 중에 ctx 가 끝나도 나머지는 진행하고 ctx.Err() (없으면 checkpoint 저장 오류) 반환
+func (c *EthereumController) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	if c.closing {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closing = true
+	subscribers, closers := c.subscribers, c.closers
+	c.subscribers, c.closers = nil, nil
+	c.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.inflight.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	for _, tracked := range subscribers {
+		tracked.subscriber.Unsubscribe()
+		if saveErr := c.saveCheckpoint(tracked); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		closers[i]()
+	}
+	return err
+}
+
+func (c *EthereumController) saveCheckpoint(tracked trackedSubscriber) error {
+	if tracked.name == "" || c.Checkpoints == nil {
+		return nil
+	}
+	next := tracked.subscriber.Checkpoint()
+	if next == nil {
+		return nil
+	}
+	return c.Checkpoints.Save(tracked.name, next)
+}
@@ -0,0 +1,341 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+	"tiny-blockchain-app/app/pkg/batch"
+	"tiny-blockchain-app/app/pkg/blockchain/event"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+	"tiny-blockchain-app/app/pkg/contract"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestController : Accounts[0](기본), Accounts[1] 서명 계정으로 "token" 을 배포하고 Accounts[0] 에 1000 발행
+func newTestController(t *testing.T) (*simulated.Backend, *EthereumController) {
+	ctx := context.Background()
+	backend, err := simulated.New(2)
+	assert.Equal(t, nil, err)
+	t.Cleanup(func() { backend.Close() })
+
+	controller := NewEthereumControllerWithBackend(backend, backend.RPCClient(), *backend.Accounts[0], *backend.Accounts[1])
+	_, err = controller.Tokens.Deploy(ctx, "token", contract.ERC20Constructor{Name: "ERC20Burnable", Symbol: "E2B", Decimals: 10})
+	assert.Equal(t, nil, err)
+	_, err = controller.Tokens.Mint(ctx, "token", backend.Accounts[0].PublicKey, big.NewInt(1000))
+	assert.Equal(t, nil, err)
+	return backend, controller
+}
+
+func TestEthereumController_Tokens(t *testing.T) {
+	ctx := context.Background()
+	backend, controller := newTestController(t)
+	owner, user := backend.Accounts[0].PublicKey, backend.Accounts[1].PublicKey
+
+	_, err := controller.Tokens.Transfer(ctx, "token", user, big.NewInt(30))
+	assert.Equal(t, nil, err)
+
+	// 다른 서명 계정으로 전송
+	userTokens, err := controller.Tokens.As(user)
+	assert.Equal(t, nil, err)
+	registered, err := controller.Contracts.Get("token")
+	assert.Equal(t, nil, err)
+	_, err = userTokens.Transfer(ctx, registered.Address.Hex(), owner, big.NewInt(10))
+	assert.Equal(t, nil, err)
+
+	balance, err := controller.Tokens.Balance(ctx, "token", owner)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "980", balance)
+	balance, err = controller.Tokens.Balance(ctx, "token", user)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "20", balance)
+
+	_, err = controller.Tokens.Balance(ctx, "unknown", owner)
+	assert.NotEqual(t, nil, err)
+	_, err = controller.Swap.Owner(context.Background(), "token")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestEthereumController_BatchTransfer(t *testing.T) {
+	ctx := context.Background()
+	backend, controller := newTestController(t)
+	user := backend.Accounts[1].PublicKey
+	rows := []batch.Row{
+		{Line: 1, To: user, Amount: big.NewInt(100)},
+		{Line: 2, To: common.HexToAddress("0x1000"), Amount: big.NewInt(50)},
+	}
+
+	_, err := controller.Tokens.BatchTransfer(context.Background(), "token", "airdrop", rows)
+	assert.Equal(t, ErrNoBatchStore, err)
+
+	controller.Batches = batch.NewFileStore(t.TempDir())
+	job, err := controller.Tokens.BatchTransfer(context.Background(), "token", "airdrop", rows)
+	assert.Equal(t, nil, err)
+	assert.True(t, job.Summary().Complete)
+
+	// 일괄 전송 뒤에도 NonceManager 로 다른 트랜잭션 전송
+	_, err = controller.Tokens.Transfer(ctx, "token", user, big.NewInt(1))
+	assert.Equal(t, nil, err)
+	balance, err := controller.Tokens.Balance(ctx, "token", user)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "101", balance)
+	balance, err = controller.Tokens.Balance(ctx, "token", backend.Accounts[0].PublicKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "849", balance)
+}
+
+func TestEthereumController_Reads(t *testing.T) {
+	ctx := context.Background()
+	backend, controller := newTestController(t)
+	owner, user := backend.Accounts[0].PublicKey, backend.Accounts[1].PublicKey
+	_, err := controller.Tokens.Transfer(ctx, "token", user, big.NewInt(30))
+	assert.Equal(t, nil, err)
+
+	assertReads := func() {
+		balances, err := controller.Reads.BalancesOf(context.Background(), "token", []common.Address{owner, user, common.HexToAddress("0x1000")})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"970", "30", "0"}, []string{balances[0].String(), balances[1].String(), balances[2].String()})
+
+		infos, err := controller.Reads.TokenInfo(context.Background(), "token")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "E2B", infos[0].Symbol)
+		assert.Equal(t, uint8(10), infos[0].Decimals)
+		assert.Equal(t, "1000", infos[0].TotalSupply.String())
+	}
+
+	// Multicall 이 없으면 하나씩 (simulated.Backend 는 batch 미지원), 배포 후에는 Multicall 로 조회
+	assertReads()
+	address, err := controller.Reads.DeployMulticall(ctx, "multicall")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, address, controller.Reads.reader().Multicall)
+	assertReads()
+
+	_, err = controller.Reads.TokenInfo(context.Background(), "multicall")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestEthereumController_Txs(t *testing.T) {
+	backend, controller := newTestController(t)
+	stranger, err := backend.NewAccount(big.NewInt(params.Ether))
+	assert.Equal(t, nil, err)
+	backend.SetTxPool(true)
+	backend.SetAutoCommit(false)
+	ctx := context.Background()
+	user := backend.Accounts[1]
+
+	send := func() *types.Transaction {
+		nonce, err := controller.Client.PendingNonceAt(ctx, user.PublicKey)
+		assert.Equal(t, nil, err)
+		price, err := controller.Client.SuggestGasPrice(ctx)
+		assert.Equal(t, nil, err)
+		chainID, _ := controller.Client.ChainID(ctx)
+		tx, err := user.SignTx(types.NewTransaction(nonce, common.HexToAddress("0x1000"), big.NewInt(1), params.TxGas, price, nil), chainID)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, controller.Client.SendTransaction(ctx, tx))
+		return tx
+	}
+
+	// 보낸 계정(Accounts[1])으로 서명
+	original := send()
+	replacement, err := controller.Txs.SpeedUp(ctx, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, original.Nonce(), replacement.Nonce())
+	cancelled := send()
+	cancel, err := controller.Txs.Cancel(ctx, cancelled.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, user.PublicKey, *cancel.To())
+
+	backend.Commit()
+	receipt, err := controller.Client.TransactionReceipt(ctx, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, replacement.Hash(), receipt.TxHash)
+	status, err := controller.Txs.Status(ctx, cancelled.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, txmanager.StateMined, status.State)
+	assert.True(t, status.Cancelled)
+
+	// 교체 후에도 nonce 가 이어짐
+	next := send()
+	assert.Equal(t, cancelled.Nonce()+1, next.Nonce())
+
+	// 서명 계정이 아닌 계정이 보낸 트랜잭션
+	chainID, _ := backend.ChainID(ctx)
+	tx, err := stranger.SignTx(types.NewTransaction(0, user.PublicKey, big.NewInt(1), params.TxGas, replacement.GasPrice(), nil), chainID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, backend.SendTransaction(ctx, tx))
+	_, err = controller.Txs.SpeedUp(ctx, tx.Hash())
+	assert.Equal(t, ErrNoSigner, err)
+}
+
+func TestEthereumController_NonceGaps(t *testing.T) {
+	backend, controller := newTestController(t)
+	backend.SetTxPool(true)
+	backend.SetAutoCommit(false)
+	ctx := context.Background()
+	user := backend.Accounts[1]
+	chainID, _ := backend.ChainID(ctx)
+	price, _ := backend.SuggestGasPrice(ctx)
+
+	// 재시작 등으로 nonce 0 을 잃고 nonce 1 만 노드에 남음
+	stuck, err := user.SignTx(types.NewTransaction(1, common.HexToAddress("0x1000"), big.NewInt(1), params.TxGas, price, nil), chainID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, backend.SendTransaction(ctx, stuck))
+
+	report, err := controller.Txs.Nonces(ctx, user.PublicKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []uint64{0}, report.Gaps)
+	assert.Equal(t, stuck.Hash(), report.Stuck[0].Hash)
+
+	_, fills, err := controller.Txs.RepairGaps(ctx, user.PublicKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(fills))
+	assert.False(t, fills[0].Resubmitted)
+
+	// 다음 할당은 노드의 pending nonce 기준
+	nonce, err := controller.Client.PendingNonceAt(ctx, user.PublicKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(2), nonce)
+
+	backend.Commit()
+	receipt, err := backend.TransactionReceipt(ctx, stuck.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	_, _, err = controller.Txs.RepairGaps(ctx, common.HexToAddress("0x1000"))
+	assert.Equal(t, ErrNoSigner, err)
+}
+
+func TestEthereumController_Journal(t *testing.T) {
+	ctx := context.Background()
+	backend, controller := newTestController(t)
+	owner := backend.Accounts[0].PublicKey
+
+	receipt, err := controller.Tokens.Transfer(ctx, "token", backend.Accounts[1].PublicKey, big.NewInt(30))
+	assert.Equal(t, nil, err)
+
+	// 배포, 발행, 전송 모두 전송 전에 기록
+	entries := controller.Journal.List(journal.Filter{From: &owner})
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, receipt.TxHash, entries[2].Hash)
+	assert.Equal(t, journal.StatusPending, entries[2].Status)
+
+	result, err := controller.Journal.Check(context.Background())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, result.Mined)
+	assert.Equal(t, journal.StatusMined, controller.Journal.Entry(receipt.TxHash).Status)
+}
+
+func TestEthereumController_EventsAndBlocks(t *testing.T) {
+	ctx := context.Background()
+	backend, controller := newTestController(t)
+	user := backend.Accounts[1].PublicKey
+
+	request, err := controller.Events.Request("token", "Transfer", map[string][]interface{}{"to": {user.Hex()}})
+	assert.Equal(t, nil, err)
+	_, outch, errch, err := controller.Events.Subscribe(request)
+	assert.Equal(t, nil, err)
+
+	// 구독이 등록될 때까지 대기 후 전송
+	time.Sleep(100 * time.Millisecond)
+	receipt, err := controller.Tokens.Transfer(ctx, "token", user, big.NewInt(7))
+	assert.Equal(t, nil, err)
+
+	select {
+	case event := <-outch:
+		assert.Equal(t, big.NewInt(7), event.Event["value"])
+	case err := <-errch:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	events, err := controller.Events.History(request, nil, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(events))
+
+	latest, err := controller.Blocks.Latest()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, receipt.BlockNumber.String(), latest)
+
+	block, err := controller.Blocks.Get(receipt.BlockNumber)
+	assert.Equal(t, nil, err)
+	count, err := controller.Blocks.TransactionCount(block)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint(1), count)
+}
+
+func TestEthereumController_Shutdown(t *testing.T) {
+	ctx := context.Background()
+	backend, controller := newTestController(t)
+
+	request, err := controller.Events.Request("token", "Transfer", nil)
+	assert.Equal(t, nil, err)
+	_, _, _, err = controller.Events.Subscribe(request)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, nil, controller.Shutdown(context.Background()))
+
+	_, err = controller.Tokens.Transfer(ctx, "token", backend.Accounts[1].PublicKey, big.NewInt(1))
+	assert.Equal(t, ErrShuttingDown, err)
+	_, _, _, err = controller.Events.Subscribe(request)
+	assert.Equal(t, ErrShuttingDown, err)
+
+	// 조회는 Shutdown 후에도 연결이 열려있으면 가능
+	_, err = controller.Tokens.Balance(ctx, "token", backend.Accounts[0].PublicKey)
+	assert.Equal(t, nil, err)
+}
+
+func TestEthereumController_NoSigner(t *testing.T) {
+	ctx := context.Background()
+	backend, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	controller := NewEthereumControllerWithBackend(backend, backend)
+	_, err = controller.Tokens.Deploy(ctx, "token", contract.ERC20Constructor{Name: "ERC20Burnable", Symbol: "E2B", Decimals: 10})
+	assert.Equal(t, ErrNoSigner, err)
+	_, err = controller.Tokens.As(backend.Accounts[0].PublicKey)
+	assert.Equal(t, ErrNoSigner, err)
+}
+
+func TestEthereumController_SubscribeDurable(t *testing.T) {
+	ctx := context.Background()
+	backend, controller := newTestController(t)
+	controller.Checkpoints = event.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+
+	user := backend.Accounts[1].PublicKey
+	request, err := controller.Events.Request("token", "Transfer", map[string][]interface{}{"to": {user.Hex()}})
+	assert.Equal(t, nil, err)
+	subscriber, outch, _, err := controller.Events.SubscribeDurable("transfer", request)
+	assert.Equal(t, nil, err)
+	// 이벤트를 받지 못한 구독도 구독 시점의 다음 블록부터 저장
+	head, err := backend.BlockNumber(ctx)
+	assert.Equal(t, nil, err)
+	idleRequest, err := controller.Events.Request("token", "Transfer", map[string][]interface{}{"to": {common.HexToAddress("0x1000").Hex()}})
+	assert.Equal(t, nil, err)
+	_, _, _, err = controller.Events.SubscribeDurable("idle", idleRequest)
+	assert.Equal(t, nil, err)
+
+	time.Sleep(100 * time.Millisecond)
+	receipt, err := controller.Tokens.Transfer(ctx, "token", user, big.NewInt(7))
+	assert.Equal(t, nil, err)
+	<-outch
+	// checkpoint 는 이벤트를 전달한 직후 갱신
+	assert.Eventually(t, func() bool { return subscriber.Checkpoint() != nil }, time.Second, 10*time.Millisecond)
+
+	// Shutdown 에서 다음에 전달할 블록 저장
+	assert.Equal(t, nil, controller.Shutdown(context.Background()))
+	next, err := controller.Checkpoints.Load("transfer")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, new(big.Int).Add(receipt.BlockNumber, big.NewInt(1)), next)
+	next, err = controller.Checkpoints.Load("idle")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, new(big.Int).SetUint64(head+1), next)
+}
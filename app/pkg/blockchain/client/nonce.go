@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// NonceManager : 계정별 다음 nonce 를 로컬에서 할당
+// 같은 계정으로 동시에 트랜잭션을 보낼 때 노드의 pending nonce 를 각자 조회하면 같은 nonce 를 받아 충돌하므로
+// 처음 한 번만 노드에서 조회하고 이후에는 로컬에서 1씩 증가시킴
+type NonceManager struct {
+	mu     sync.Mutex
+	source backend.Transactor
+	next   map[common.Address]uint64
+	// unsent : 할당 후 아직 전송하지 않은 nonce (Release 대상)
+	unsent map[common.Address]map[uint64]bool
+	// stale : 노드 기준으로 다시 맞춰야 하지만 unsent 가 남아 있어 미룬 계정
+	stale map[common.Address]bool
+}
+
+func NewNonceManager(source backend.Transactor) *NonceManager {
+	return &NonceManager{
+		source: source,
+		next:   map[common.Address]uint64{},
+		unsent: map[common.Address]map[uint64]bool{},
+		stale:  map[common.Address]bool{},
+	}
+}
+
+// Next : address 가 사용할 nonce 를 할당
+func (m *NonceManager) Next(ctx context.Context, address common.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonce, exist := m.next[address]
+	if !exist {
+		pending, err := m.source.PendingNonceAt(ctx, address)
+		if err != nil {
+			return 0, err
+		}
+		nonce = pending
+	}
+	m.next[address] = nonce + 1
+	if m.unsent[address] == nil {
+		m.unsent[address] = map[uint64]bool{}
+	}
+	m.unsent[address][nonce] = true
+	return nonce, nil
+}
+
+// Release : 할당한 nonce 를 전송하지 못함 (서명 전 조회, 서명 실패 등)
+// 마지막으로 할당한 nonce 이면 다음 할당에 다시 사용하고, 이후 nonce 가 할당되어 있으면 비는 nonce 를 채우도록 노드 기준으로 다시 맞춤
+// 이미 전송을 시도한 nonce 는 무시
+func (m *NonceManager) Release(address common.Address, nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.unsent[address][nonce] {
+		return
+	}
+	delete(m.unsent[address], nonce)
+	if m.next[address] == nonce+1 {
+		m.next[address] = nonce
+	} else {
+		m.stale[address] = true
+	}
+	m.resetIfIdle(address)
+}
+
+// sent : 전송을 시도한 nonce 는 Release 대상에서 제외
+func (m *NonceManager) sent(address common.Address, nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.unsent[address], nonce)
+	m.resetIfIdle(address)
+}
+
+// Reset : 다음 할당 때 노드의 pending nonce 로 다시 맞춤 (전송 실패, nonce 충돌 시)
+// 할당 후 아직 전송하지 않은 nonce 가 있으면 같은 nonce 를 다시 할당하지 않도록 모두 전송하거나 Release 할 때까지 미룸
+func (m *NonceManager) Reset(address common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stale[address] = true
+	m.resetIfIdle(address)
+}
+
+// resetIfIdle : 미룬 계정에 아직 전송하지 않은 nonce 가 없으면 로컬 할당을 지움 (m.mu 를 잡은 상태에서 호출)
+func (m *NonceManager) resetIfIdle(address common.Address) {
+	if !m.stale[address] || len(m.unsent[address]) > 0 {
+		return
+	}
+	delete(m.next, address)
+	delete(m.unsent, address)
+	delete(m.stale, address)
+}
+
+// Backend : PendingNonceAt 을 Next 로 대체한 backend
+// contract 패키지의 트랜잭션 함수는 전송마다 PendingNonceAt 을 한 번 호출하므로 그대로 로컬 할당을 사용
+func (m *NonceManager) Backend(next backend.Backend) backend.Backend {
+	return &nonceBackend{Backend: next, nonces: m}
+}
+
+type nonceBackend struct {
+	backend.Backend
+	nonces *NonceManager
+}
+
+func (b *nonceBackend) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	return backend.BatchCall(ctx, b.Backend, batch)
+}
+
+func (b *nonceBackend) TxPoolContent(ctx context.Context) (*backend.TxPool, error) {
+	return backend.TxPoolContent(ctx, b.Backend)
+}
+
+func (b *nonceBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return b.nonces.Next(ctx, account)
+}
+
+func (b *nonceBackend) ReleaseNonce(account common.Address, nonce uint64) {
+	b.nonces.Release(account, nonce)
+}
+
+// SendTransaction : 전송에 실패하면 할당한 nonce 가 사용되지 않았으므로 노드 기준으로 다시 맞춤
+func (b *nonceBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	from, senderErr := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if senderErr == nil {
+		b.nonces.sent(from, tx.Nonce())
+	}
+	err := b.Backend.SendTransaction(ctx, tx)
+	if err != nil && senderErr == nil {
+		b.nonces.Reset(from)
+	}
+	return err
+}
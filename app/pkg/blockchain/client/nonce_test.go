@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	"tiny-blockchain-app/app/pkg/contract"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonceManager_Next(t *testing.T) {
+	backend, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	nonces := NewNonceManager(backend)
+	account := backend.Accounts[0].PublicKey
+
+	// 동시에 할당해도 중복 없이 0 부터 연속
+	var mu sync.Mutex
+	allocated := map[uint64]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := nonces.Next(context.Background(), account)
+			assert.Equal(t, nil, err)
+			mu.Lock()
+			allocated[nonce] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	for nonce := uint64(0); nonce < 20; nonce++ {
+		assert.True(t, allocated[nonce])
+	}
+
+	// 할당한 nonce 를 모두 전송한 뒤 Reset 하면 노드의 pending nonce (노드가 받은 트랜잭션이 없으므로 0)
+	for nonce := uint64(0); nonce < 20; nonce++ {
+		nonces.sent(account, nonce)
+	}
+	nonces.Reset(account)
+	nonce, err := nonces.Next(context.Background(), account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(0), nonce)
+}
+
+// failingBackend : SendTransaction 이 항상 실패
+type failingBackend struct {
+	*simulated.Backend
+}
+
+func (b failingBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return errors.New("send failed")
+}
+
+func TestNonceManager_ResetOnSendFailure(t *testing.T) {
+	backend, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	nonces := NewNonceManager(backend)
+	cli := nonces.Backend(failingBackend{backend})
+	owner := *backend.Accounts[0]
+
+	_, _, err = backend.DeployERC20Burnable(owner, "ERC20Burnable", "E2B", 10)
+	assert.Equal(t, nil, err)
+
+	// 로컬 할당 nonce 를 앞당겨 둔 상태에서 전송이 실패하면 노드 기준(1)으로 다시 맞춤
+	for i := 0; i < 3; i++ {
+		nonce, err := nonces.Next(context.Background(), owner.PublicKey)
+		assert.Equal(t, nil, err)
+		nonces.sent(owner.PublicKey, nonce)
+	}
+	chainID, _ := backend.ChainID(context.Background())
+	tx, err := types.SignNewTx(owner.PrivateKey, types.LatestSignerForChainID(chainID), &types.LegacyTx{Nonce: 3, Gas: 21000})
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, nil, cli.SendTransaction(context.Background(), tx))
+
+	nonce, err := cli.PendingNonceAt(context.Background(), owner.PublicKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(1), nonce)
+}
+
+func TestNonceManager_Release(t *testing.T) {
+	backend, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	nonces := NewNonceManager(backend)
+	account := backend.Accounts[0].PublicKey
+	next := func() uint64 {
+		nonce, err := nonces.Next(context.Background(), account)
+		assert.Equal(t, nil, err)
+		return nonce
+	}
+
+	// 마지막으로 할당한 nonce 는 다음 할당에 다시 사용
+	assert.Equal(t, uint64(0), next())
+	assert.Equal(t, uint64(1), next())
+	nonces.Release(account, 1)
+	assert.Equal(t, uint64(1), next())
+
+	// 이후 nonce 가 할당되어 있으면 그 nonce 를 전송하거나 반환한 뒤 노드 기준으로 다시 맞춰 비는 nonce 를 채움
+	nonces.Release(account, 0)
+	assert.Equal(t, uint64(2), next())
+	nonces.sent(account, 1)
+	nonces.Release(account, 2)
+	assert.Equal(t, uint64(0), next())
+
+	// 할당하지 않은 nonce 는 무시
+	nonces.Release(account, 5)
+	assert.Equal(t, uint64(1), next())
+}
+
+func TestNonceManager_ResetOutstanding(t *testing.T) {
+	backend, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	ctx := context.Background()
+	nonces := NewNonceManager(backend)
+	account := backend.Accounts[0].PublicKey
+	for i := uint64(0); i < 2; i++ {
+		nonce, err := nonces.Next(ctx, account)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, i, nonce)
+	}
+
+	// 아직 전송하지 않은 nonce(1)가 있으면 같은 nonce 를 다시 할당하지 않음
+	nonces.sent(account, 0)
+	nonces.Reset(account)
+	nonce, err := nonces.Next(ctx, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(2), nonce)
+
+	// 모두 전송하면 노드 기준(0)으로 다시 맞춤
+	nonces.sent(account, 1)
+	nonces.sent(account, 2)
+	nonce, err = nonces.Next(ctx, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(0), nonce)
+}
+
+// flakyBackend : SuggestGasPrice 가 실패하도록 설정할 수 있는 backend
+type flakyBackend struct {
+	*simulated.Backend
+	gasPriceErr error
+}
+
+func (b *flakyBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if b.gasPriceErr != nil {
+		return nil, b.gasPriceErr
+	}
+	return b.Backend.SuggestGasPrice(ctx)
+}
+
+// failingSigner : 서명이 항상 실패 (원격 서명자 연결 실패 등)
+type failingSigner struct {
+	wallet.Signer
+}
+
+func (failingSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, errors.New("sign failed")
+}
+
+func TestNonceManager_ReleaseBeforeSend(t *testing.T) {
+	backend, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	ctx := context.Background()
+	flaky := &flakyBackend{Backend: backend}
+	nonces := NewNonceManager(backend)
+	cli := nonces.Backend(flaky)
+	owner := backend.Accounts[0]
+
+	// nonce 할당 후 gas price 조회, 서명이 실패해도 nonce 가 비지 않음
+	flaky.gasPriceErr = errors.New("gas price unavailable")
+	_, err = contract.GetAuth(ctx, cli, owner)
+	assert.NotEqual(t, nil, err)
+	flaky.gasPriceErr = nil
+	_, err = contract.TransferERC20UsingABIGen(ctx, cli, failingSigner{owner}, "0x1000", common.HexToAddress("0x2000").Hex(), big.NewInt(1))
+	assert.NotEqual(t, nil, err)
+
+	response, err := contract.DeployERC20Burnable(ctx, cli, owner, contract.ERC20Constructor{Name: "ERC20Burnable", Symbol: "E2B", Decimals: 10})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(0), response.Tx.Nonce())
+
+	// 전송을 시도한 nonce 는 반환하지 않음
+	nonces.Release(owner.PublicKey, 0)
+	nonce, err := cli.PendingNonceAt(ctx, owner.PublicKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(1), nonce)
+}
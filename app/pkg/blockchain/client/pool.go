@@ -0,0 +1,443 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	config "tiny-blockchain-app/app/config"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/logging"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var _ backend.Backend = (*Pool)(nil)
+
+var poolLogger = logging.New("client")
+
+// ErrNoEndpoint : 요청을 보낼 수 있는 노드가 없음
+var ErrNoEndpoint = errors.New("no available endpoint")
+
+// maxSentTracked : 전송한 노드를 기억하는 최근 트랜잭션 수
+const maxSentTracked = 4096
+
+type poolEndpoint struct {
+	url     string
+	client  *backend.Client
+	healthy int32
+}
+
+func (e *poolEndpoint) isHealthy() bool {
+	return atomic.LoadInt32(&e.healthy) == 1
+}
+
+func (e *poolEndpoint) setHealthy(healthy bool) {
+	var value int32
+	if healthy {
+		value = 1
+	}
+	atomic.StoreInt32(&e.healthy, value)
+}
+
+// Pool : 여러 노드에 연결하여 읽기는 round-robin, 쓰기는 우선 노드로 보내고
+// 연결 오류가 나면 다음 노드로 재시도하는 클라이언트
+// 전송 직후에는 다른 노드에 트랜잭션이 아직 없을 수 있으므로 전송한 트랜잭션(receipt)과 계정(nonce) 조회는 받은 노드로 보냄
+// backend.Backend 를 구현하므로 *ethclient.Client 대신 사용 가능
+type Pool struct {
+	endpoints []*poolEndpoint
+	next      uint64
+
+	mu sync.Mutex
+	// sentTx, sentFrom : 트랜잭션과 보낸 계정의 마지막 트랜잭션을 받은 노드, sentOrder : sentTx 를 넣은 순서 (maxSentTracked 를 넘으면 오래된 것부터 삭제)
+	sentTx    map[common.Hash]*poolEndpoint
+	sentFrom  map[common.Address]*poolEndpoint
+	sentOrder []common.Hash
+}
+
+// NewPool : conf.Endpoints(없으면 conf.Endpoint)로 Pool 생성
+func NewPool(conf config.Config) (*Pool, error) {
+	endpoints := conf.Endpoints
+	if len(endpoints) == 0 && conf.Endpoint != "" {
+		endpoints = []string{conf.Endpoint}
+	}
+	return DialPool(endpoints)
+}
+
+// DialPool : endpoints 순서가 쓰기 우선순위 (첫 번째가 우선 노드)
+func DialPool(endpoints []string) (*Pool, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoint info")
+	}
+
+	pool := &Pool{sentTx: map[common.Hash]*poolEndpoint{}, sentFrom: map[common.Address]*poolEndpoint{}}
+	for _, url := range endpoints {
+		client, err := backend.Dial(url)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+		endpoint := &poolEndpoint{url: url, client: client}
+		endpoint.setHealthy(true)
+		pool.endpoints = append(pool.endpoints, endpoint)
+	}
+	return pool, nil
+}
+
+// Close : 모든 연결 종료
+func (p *Pool) Close() {
+	for _, endpoint := range p.endpoints {
+		endpoint.client.Close()
+	}
+}
+
+// Healthy : 정상 상태인 endpoint 목록
+func (p *Pool) Healthy() []string {
+	urls := []string{}
+	for _, endpoint := range p.endpoints {
+		if endpoint.isHealthy() {
+			urls = append(urls, endpoint.url)
+		}
+	}
+	return urls
+}
+
+// HealthCheck : 모든 노드에 eth_blockNumber를 호출하여 상태 갱신
+func (p *Pool) HealthCheck(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, endpoint := range p.endpoints {
+		wg.Add(1)
+		go func(endpoint *poolEndpoint) {
+			defer wg.Done()
+			_, err := endpoint.client.BlockNumber(ctx)
+			endpoint.setHealthy(err == nil)
+		}(endpoint)
+	}
+	wg.Wait()
+}
+
+// StartHealthCheck : ctx가 종료될 때까지 interval 주기로 HealthCheck
+func (p *Pool) StartHealthCheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.HealthCheck(ctx)
+		}
+	}
+}
+
+// readOrder : 다음 round-robin 위치부터 정상 노드, 그 뒤에 비정상 노드 순서
+func (p *Pool) readOrder() []*poolEndpoint {
+	start := int(atomic.AddUint64(&p.next, 1)-1) % len(p.endpoints)
+	rotated := append(append([]*poolEndpoint{}, p.endpoints[start:]...), p.endpoints[:start]...)
+	return healthyFirst(rotated)
+}
+
+// writeOrder : 설정 순서 그대로 정상 노드, 그 뒤에 비정상 노드 순서
+func (p *Pool) writeOrder() []*poolEndpoint {
+	return healthyFirst(p.endpoints)
+}
+
+// stickyOrder : endpoint 가 있으면 먼저, 그 뒤에 readOrder (endpoint 가 연결 오류면 다른 노드로 재시도)
+func (p *Pool) stickyOrder(endpoint *poolEndpoint) []*poolEndpoint {
+	order := p.readOrder()
+	if endpoint == nil {
+		return order
+	}
+	sticky := []*poolEndpoint{endpoint}
+	for _, other := range order {
+		if other != endpoint {
+			sticky = append(sticky, other)
+		}
+	}
+	return sticky
+}
+
+// remember : tx 를 받은 노드 기록
+func (p *Pool) remember(tx *types.Transaction, client *backend.Client) {
+	var accepted *poolEndpoint
+	for _, endpoint := range p.endpoints {
+		if endpoint.client == client {
+			accepted = endpoint
+		}
+	}
+	if accepted == nil {
+		return
+	}
+	from, fromErr := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, exist := p.sentTx[tx.Hash()]; !exist {
+		p.sentOrder = append(p.sentOrder, tx.Hash())
+	}
+	p.sentTx[tx.Hash()] = accepted
+	if fromErr == nil {
+		p.sentFrom[from] = accepted
+	}
+	if len(p.sentOrder) > maxSentTracked {
+		delete(p.sentTx, p.sentOrder[0])
+		p.sentOrder = p.sentOrder[1:]
+	}
+}
+
+func (p *Pool) sentTo(hash common.Hash) *poolEndpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sentTx[hash]
+}
+
+func (p *Pool) sentBy(account common.Address) *poolEndpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sentFrom[account]
+}
+
+func healthyFirst(endpoints []*poolEndpoint) []*poolEndpoint {
+	ordered := make([]*poolEndpoint, 0, len(endpoints))
+	var unhealthy []*poolEndpoint
+	for _, endpoint := range endpoints {
+		if endpoint.isHealthy() {
+			ordered = append(ordered, endpoint)
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+	return append(ordered, unhealthy...)
+}
+
+// try : 연결 오류가 나면 해당 노드를 비정상으로 표시하고 다음 노드로 재시도
+// 노드가 JSON-RPC 오류로 응답한 경우는 다른 노드도 같은 결과이므로 재시도하지 않음
+func (p *Pool) try(ctx context.Context, endpoints []*poolEndpoint, call func(*backend.Client) error) error {
+	err := ErrNoEndpoint
+	for _, endpoint := range endpoints {
+		err = call(endpoint.client)
+		if !backend.IsConnectionError(ctx, err) {
+			if err == nil {
+				endpoint.setHealthy(true)
+			}
+			return err
+		}
+		logging.FromContext(ctx, poolLogger).Warn("Endpoint unavailable, failing over", "endpoint", endpoint.url, "err", err)
+		endpoint.setHealthy(false)
+	}
+	return err
+}
+
+func (p *Pool) read(ctx context.Context, call func(*backend.Client) error) error {
+	return p.try(ctx, p.readOrder(), call)
+}
+
+func (p *Pool) write(ctx context.Context, call func(*backend.Client) error) error {
+	return p.try(ctx, p.writeOrder(), call)
+}
+
+func (p *Pool) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		chainID, err = c.ChainID(ctx)
+		return err
+	})
+	return chainID, err
+}
+
+func (p *Pool) BlockNumber(ctx context.Context) (number uint64, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		number, err = c.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+func (p *Pool) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		balance, err = c.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+// NonceAt : 이 Pool 로 트랜잭션을 보낸 계정은 마지막 트랜잭션을 받은 노드에서 조회
+func (p *Pool) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = p.try(ctx, p.stickyOrder(p.sentBy(account)), func(c *backend.Client) error {
+		nonce, err = c.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+// TransactionReceipt : 이 Pool 로 보낸 트랜잭션은 받은 노드에서 조회
+func (p *Pool) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = p.try(ctx, p.stickyOrder(p.sentTo(txHash)), func(c *backend.Client) error {
+		receipt, err = c.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+// TransactionByHash : 이 Pool 로 보낸 트랜잭션은 받은 노드에서 조회
+func (p *Pool) TransactionByHash(ctx context.Context, txHash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	err = p.try(ctx, p.stickyOrder(p.sentTo(txHash)), func(c *backend.Client) error {
+		tx, isPending, err = c.TransactionByHash(ctx, txHash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+func (p *Pool) BlockByNumber(ctx context.Context, number *big.Int) (block *types.Block, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		block, err = c.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+func (p *Pool) TransactionCount(ctx context.Context, blockHash common.Hash) (count uint, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		count, err = c.TransactionCount(ctx, blockHash)
+		return err
+	})
+	return count, err
+}
+
+// ContractCaller
+
+func (p *Pool) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		code, err = c.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (p *Pool) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (output []byte, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		output, err = c.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return output, err
+}
+
+// ContractTransactor
+
+func (p *Pool) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		header, err = c.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+// PendingCodeAt : pending 상태는 노드마다 다를 수 있으므로 트랜잭션을 보낼 노드에서 조회
+func (p *Pool) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = p.write(ctx, func(c *backend.Client) error {
+		code, err = c.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+// PendingNonceAt : pending nonce 는 노드마다 다를 수 있으므로 트랜잭션을 보낼 노드에서 조회
+func (p *Pool) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = p.write(ctx, func(c *backend.Client) error {
+		nonce, err = c.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+func (p *Pool) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		price, err = c.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (p *Pool) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		tip, err = c.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+func (p *Pool) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		gas, err = c.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction : 우선 노드로 전송, 연결 오류면 다음 노드로 같은 서명 트랜잭션을 재전송
+// 앞선 전송이 실제로는 도달했을 수 있으므로 "already known" 응답은 성공으로 처리
+func (p *Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	var attempted bool
+	return p.write(ctx, func(c *backend.Client) error {
+		err := c.SendTransaction(ctx, tx)
+		if attempted && backend.IsAlreadyKnown(err) {
+			err = nil
+		}
+		attempted = true
+		if err == nil {
+			p.remember(tx, c)
+		}
+		return err
+	})
+}
+
+// BatchCallContext : batch 전체를 한 노드로 보냄 (연결 오류면 다음 노드로 재시도)
+func (p *Pool) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	return p.read(ctx, func(c *backend.Client) error {
+		return c.BatchCallContext(ctx, batch)
+	})
+}
+
+// TxPoolContent : 트랜잭션을 전송하는 노드(PendingNonceAt 과 같은 순서)의 txpool
+func (p *Pool) TxPoolContent(ctx context.Context) (pool *backend.TxPool, err error) {
+	err = p.write(ctx, func(c *backend.Client) error {
+		pool, err = c.TxPoolContent(ctx)
+		return err
+	})
+	return pool, err
+}
+
+// ContractFilterer
+
+func (p *Pool) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = p.read(ctx, func(c *backend.Client) error {
+		logs, err = c.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs : 구독을 지원하는 (ws) 노드 중 우선순위가 가장 높은 노드로 연결
+func (p *Pool) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	err := ErrNoEndpoint
+	for _, endpoint := range p.writeOrder() {
+		var sub ethereum.Subscription
+		sub, err = endpoint.client.SubscribeFilterLogs(ctx, query, ch)
+		if err == nil {
+			return sub, nil
+		}
+		if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			continue
+		}
+		if !backend.IsConnectionError(ctx, err) {
+			return nil, err
+		}
+		endpoint.setHealthy(false)
+	}
+	return nil, err
+}
@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubEth : 호출 횟수를 세는 eth 네임스페이스
+type stubEth struct {
+	calls int64
+	sent  int64
+	known bool
+}
+
+func (s *stubEth) BlockNumber() hexutil.Uint64 {
+	atomic.AddInt64(&s.calls, 1)
+	return 100
+}
+
+func (s *stubEth) ChainId() *hexutil.Big {
+	atomic.AddInt64(&s.calls, 1)
+	return (*hexutil.Big)(big.NewInt(10))
+}
+
+func (s *stubEth) GetTransactionCount(address common.Address, block string) hexutil.Uint64 {
+	atomic.AddInt64(&s.calls, 1)
+	return 7
+}
+
+func (s *stubEth) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	atomic.AddInt64(&s.calls, 1)
+	return nil, errors.New("execution reverted")
+}
+
+// GetTransactionReceipt : 항상 없음 (null)
+func (s *stubEth) GetTransactionReceipt(hash common.Hash) *map[string]interface{} {
+	atomic.AddInt64(&s.calls, 1)
+	return nil
+}
+
+func (s *stubEth) SendRawTransaction(raw hexutil.Bytes) (common.Hash, error) {
+	atomic.AddInt64(&s.sent, 1)
+	if s.known {
+		return common.Hash{}, errors.New("already known")
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+func newStubNode(t *testing.T) (*stubEth, string) {
+	service := &stubEth{}
+	server := rpc.NewServer()
+	assert.Equal(t, nil, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return service, httpServer.URL
+}
+
+func downEndpoint() string {
+	server := httptest.NewServer(nil)
+	server.Close()
+	return server.URL
+}
+
+func testTx() *types.Transaction {
+	return types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+}
+
+func TestPool_RoundRobin(t *testing.T) {
+	node1, url1 := newStubNode(t)
+	node2, url2 := newStubNode(t)
+	pool, err := DialPool([]string{url1, url2})
+	assert.Equal(t, nil, err)
+	defer pool.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		number, err := pool.BlockNumber(ctx)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, uint64(100), number)
+	}
+	assert.Equal(t, int64(2), atomic.LoadInt64(&node1.calls))
+	assert.Equal(t, int64(2), atomic.LoadInt64(&node2.calls))
+}
+
+func TestPool_Failover(t *testing.T) {
+	down := downEndpoint()
+	node, url := newStubNode(t)
+	pool, err := DialPool([]string{down, url})
+	assert.Equal(t, nil, err)
+	defer pool.Close()
+
+	ctx := context.Background()
+	chainID, err := pool.ChainID(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, big.NewInt(10), chainID)
+
+	// 쓰기는 우선 노드가 죽으면 다음 노드로
+	nonce, err := pool.PendingNonceAt(ctx, common.Address{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(7), nonce)
+	assert.Equal(t, nil, pool.SendTransaction(ctx, testTx()))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&node.sent))
+	assert.Equal(t, []string{url}, pool.Healthy())
+
+	pool.HealthCheck(ctx)
+	assert.Equal(t, []string{url}, pool.Healthy())
+}
+
+func TestPool_BatchFailover(t *testing.T) {
+	node, url := newStubNode(t)
+	pool, err := DialPool([]string{downEndpoint(), url})
+	assert.Equal(t, nil, err)
+	defer pool.Close()
+
+	// batch 전체를 살아있는 노드로 보내고, 호출별 오류는 BatchElem.Error
+	var number hexutil.Uint64
+	var output hexutil.Bytes
+	batch := []rpc.BatchElem{
+		{Method: "eth_blockNumber", Result: &number},
+		{Method: "eth_call", Args: []interface{}{map[string]interface{}{"to": common.Address{}}, "latest"}, Result: &output},
+	}
+	assert.Equal(t, nil, pool.BatchCallContext(context.Background(), batch))
+	assert.Equal(t, hexutil.Uint64(100), number)
+	assert.Equal(t, nil, batch[0].Error)
+	assert.NotEqual(t, nil, batch[1].Error)
+	assert.Equal(t, int64(2), atomic.LoadInt64(&node.calls))
+}
+
+func TestPool_WritePreferred(t *testing.T) {
+	node1, url1 := newStubNode(t)
+	node2, url2 := newStubNode(t)
+	pool, err := DialPool([]string{url1, url2})
+	assert.Equal(t, nil, err)
+	defer pool.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, nil, pool.SendTransaction(ctx, testTx()))
+	}
+	assert.Equal(t, int64(3), atomic.LoadInt64(&node1.sent))
+	assert.Equal(t, int64(0), atomic.LoadInt64(&node2.sent))
+
+	// 노드가 응답한 오류는 다른 노드로 재시도하지 않음
+	node1.known = true
+	assert.NotEqual(t, nil, pool.SendTransaction(ctx, testTx()))
+	assert.Equal(t, int64(0), atomic.LoadInt64(&node2.sent))
+}
+
+func TestPool_StickyAfterSend(t *testing.T) {
+	node1, url1 := newStubNode(t)
+	node2, url2 := newStubNode(t)
+	pool, err := DialPool([]string{url1, url2})
+	assert.Equal(t, nil, err)
+	defer pool.Close()
+
+	key, err := crypto.GenerateKey()
+	assert.Equal(t, nil, err)
+	tx, err := types.SignTx(testTx(), types.NewEIP155Signer(big.NewInt(10)), key)
+	assert.Equal(t, nil, err)
+	ctx := context.Background()
+	assert.Equal(t, nil, pool.SendTransaction(ctx, tx))
+
+	// 전송 직후 receipt, nonce 조회는 round-robin 대신 트랜잭션을 받은 노드로
+	for i := 0; i < 2; i++ {
+		_, err = pool.TransactionReceipt(ctx, tx.Hash())
+		assert.True(t, errors.Is(err, ethereum.NotFound))
+		nonce, err := pool.NonceAt(ctx, crypto.PubkeyToAddress(key.PublicKey), nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, uint64(7), nonce)
+	}
+	assert.Equal(t, int64(4), atomic.LoadInt64(&node1.calls))
+	assert.Equal(t, int64(0), atomic.LoadInt64(&node2.calls))
+
+	// 다른 트랜잭션은 round-robin
+	for i := 0; i < 2; i++ {
+		_, err = pool.TransactionReceipt(ctx, common.HexToHash("0x01"))
+		assert.True(t, errors.Is(err, ethereum.NotFound))
+	}
+	assert.Equal(t, int64(5), atomic.LoadInt64(&node1.calls))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&node2.calls))
+}
+
+func TestPool_NoRetryOnRPCError(t *testing.T) {
+	node1, url1 := newStubNode(t)
+	node2, url2 := newStubNode(t)
+	pool, err := DialPool([]string{url1, url2})
+	assert.Equal(t, nil, err)
+	defer pool.Close()
+
+	_, err = pool.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&node1.calls)+atomic.LoadInt64(&node2.calls))
+	assert.Equal(t, 2, len(pool.Healthy()))
+}
+
+func TestPool_AllDown(t *testing.T) {
+	pool, err := DialPool([]string{downEndpoint(), downEndpoint()})
+	assert.Equal(t, nil, err)
+	defer pool.Close()
+
+	_, err = pool.BlockNumber(context.Background())
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 0, len(pool.Healthy()))
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"tiny-blockchain-app/app/pkg/blockchain"
+	"tiny-blockchain-app/app/pkg/contract"
+	"tiny-blockchain-app/app/pkg/metrics"
+	smartcontract "tiny-blockchain-app/smartcontract/golang"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// 등록할 수 있는 컨트랙트 종류
+const (
+	ContractERC20Burnable = "ERC20Burnable"
+	ContractSwap          = "Swap"
+	// ContractMulticall : contract.DeployMulticall 로 배포한 묶음 조회 컨트랙트 (ABI 없음)
+	ContractMulticall = "Multicall"
+)
+
+// RegisteredContract : 이름으로 찾을 수 있도록 등록된 배포 컨트랙트
+type RegisteredContract struct {
+	Name    string
+	Type    string
+	Address common.Address
+	ABI     abi.ABI
+}
+
+// Registry : 이름 → 컨트랙트(종류, 주소, ABI) 목록
+type Registry struct {
+	mu        sync.RWMutex
+	contracts map[string]RegisteredContract
+}
+
+func NewRegistry() *Registry {
+	return &Registry{contracts: map[string]RegisteredContract{}}
+}
+
+// Load : 설정 파일의 contracts 목록 등록
+func (r *Registry) Load(contracts []blockchain.ContractConfig) error {
+	for _, c := range contracts {
+		if !common.IsHexAddress(c.Address) {
+			return fmt.Errorf("invalid address for contract %q: %s", c.Name, c.Address)
+		}
+		if err := r.Register(c.Name, c.Type, common.HexToAddress(c.Address)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Register : 같은 이름이 있으면 덮어씀 (재배포)
+func (r *Registry) Register(name, contractType string, address common.Address) error {
+	if name == "" {
+		return fmt.Errorf("contract name is required")
+	}
+	contractABI, err := abiOf(contractType)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contracts[name] = RegisteredContract{Name: name, Type: contractType, Address: address, ABI: contractABI}
+	metrics.LabelContract(address.Hex(), name)
+	return nil
+}
+
+// Get : 이름으로 조회
+func (r *Registry) Get(name string) (RegisteredContract, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	registered, exist := r.contracts[name]
+	if !exist {
+		return RegisteredContract{}, fmt.Errorf("contract %q is not registered", name)
+	}
+	return registered, nil
+}
+
+// Resolve : 16진수 주소는 그대로, 아니면 contractType 으로 등록된 이름의 주소
+func (r *Registry) Resolve(nameOrAddress, contractType string) (common.Address, error) {
+	if common.IsHexAddress(nameOrAddress) {
+		return common.HexToAddress(nameOrAddress), nil
+	}
+	registered, err := r.Get(nameOrAddress)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if registered.Type != contractType {
+		return common.Address{}, fmt.Errorf("contract %q is %s, not %s", nameOrAddress, registered.Type, contractType)
+	}
+	return registered.Address, nil
+}
+
+// List : 이름 순 목록
+func (r *Registry) List() []RegisteredContract {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	contracts := make([]RegisteredContract, 0, len(r.contracts))
+	for _, registered := range r.contracts {
+		contracts = append(contracts, registered)
+	}
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].Name < contracts[j].Name
+	})
+	return contracts
+}
+
+func abiOf(contractType string) (abi.ABI, error) {
+	switch contractType {
+	case ContractERC20Burnable:
+		return abi.JSON(strings.NewReader(smartcontract.ERC20BurnableMetaData.ABI))
+	case ContractSwap:
+		return abi.JSON(strings.NewReader(contract.SwapABI))
+	case ContractMulticall:
+		return abi.ABI{}, nil
+	default:
+		return abi.ABI{}, fmt.Errorf("unknown contract type %q", contractType)
+	}
+}
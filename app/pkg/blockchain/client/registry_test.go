@@ -0,0 +1,31 @@
+package client
+
+import (
+	"testing"
+	"tiny-blockchain-app/app/pkg/blockchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	token := "0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9"
+
+	assert.Equal(t, nil, registry.Load([]blockchain.ContractConfig{{Name: "token", Type: ContractERC20Burnable, Address: token}}))
+	assert.NotEqual(t, nil, registry.Register("swap", "ERC1400", common.HexToAddress(token)))
+
+	address, err := registry.Resolve("token", ContractERC20Burnable)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, token, address.Hex())
+
+	_, err = registry.Resolve("token", ContractSwap)
+	assert.NotEqual(t, nil, err)
+
+	// 16진수 주소는 등록 여부와 관계없이 그대로 사용
+	address, err = registry.Resolve(token, ContractSwap)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, token, address.Hex())
+
+	assert.Equal(t, 1, len(registry.List()))
+}
@@ -0,0 +1,380 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"tiny-blockchain-app/app/pkg/batch"
+	"tiny-blockchain-app/app/pkg/blockchain"
+	"tiny-blockchain-app/app/pkg/blockchain/event"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+	"tiny-blockchain-app/app/pkg/contract"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TokenService : ERC20Burnable 토큰 (token 은 Registry 에 등록된 이름 또는 16진수 주소)
+type TokenService struct {
+	controller *EthereumController
+	// signer : nil 이면 controller 의 기본 서명 계정
+	signer wallet.Signer
+}
+
+// As : address 계정으로 서명하는 TokenService
+func (s *TokenService) As(address common.Address) (*TokenService, error) {
+	signer, err := s.controller.Signer(address)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenService{controller: s.controller, signer: signer}, nil
+}
+
+// Deploy : 배포 후 name 이 있으면 Registry 에 등록
+func (s *TokenService) Deploy(ctx context.Context, name string, c contract.ERC20Constructor) (common.Address, error) {
+	var address common.Address
+	err := s.transact(func(signer wallet.Signer) error {
+		response, err := contract.DeployERC20Burnable(ctx, s.controller.Client, signer, c)
+		if err != nil {
+			return err
+		}
+		address = response.Address
+		if name == "" {
+			return nil
+		}
+		return s.controller.Contracts.Register(name, ContractERC20Burnable, address)
+	})
+	return address, err
+}
+
+func (s *TokenService) Mint(ctx context.Context, token string, to common.Address, amount *big.Int) (receipt *types.Receipt, err error) {
+	err = s.transactOn(token, func(signer wallet.Signer, address common.Address) error {
+		receipt, err = contract.MintERC20Burnable(ctx, s.controller.Client, signer, address.Hex(), to.Hex(), amount)
+		return err
+	})
+	return receipt, err
+}
+
+func (s *TokenService) Transfer(ctx context.Context, token string, to common.Address, amount *big.Int) (receipt *types.Receipt, err error) {
+	err = s.transactOn(token, func(signer wallet.Signer, address common.Address) error {
+		receipt, err = contract.TransferERC20UsingABIGen(ctx, s.controller.Client, signer, address.Hex(), to.Hex(), amount)
+		return err
+	})
+	return receipt, err
+}
+
+func (s *TokenService) Approve(ctx context.Context, token string, spender common.Address, amount *big.Int) (receipt *types.Receipt, err error) {
+	err = s.transactOn(token, func(signer wallet.Signer, address common.Address) error {
+		receipt, err = contract.ApproveERc20UsingABIGen(ctx, s.controller.Client, signer, address.Hex(), spender, amount)
+		return err
+	})
+	return receipt, err
+}
+
+func (s *TokenService) Burn(ctx context.Context, token string, amount *big.Int) (receipt *types.Receipt, err error) {
+	err = s.transactOn(token, func(signer wallet.Signer, address common.Address) error {
+		receipt, err = contract.BurnERC20Burnable(ctx, s.controller.Client, signer, address.Hex(), amount)
+		return err
+	})
+	return receipt, err
+}
+
+func (s *TokenService) Pause(ctx context.Context, token string, pause bool) (receipt *types.Receipt, err error) {
+	err = s.transactOn(token, func(signer wallet.Signer, address common.Address) error {
+		receipt, err = contract.PauseERC20Burnable(ctx, s.controller.Client, signer, address.Hex(), pause)
+		return err
+	})
+	return receipt, err
+}
+
+// BatchTransfer : rows 의 수신자에게 일괄 전송, 같은 id 로 다시 호출하면 중단된 작업을 이어서 처리
+// 각 행의 결과는 반환된 Job 과 Batches 에 저장됨 (전송 중 오류가 나도 그때까지의 Job 반환)
+func (s *TokenService) BatchTransfer(ctx context.Context, token string, id string, rows []batch.Row) (job *batch.Job, err error) {
+	if s.controller.Batches == nil {
+		return nil, ErrNoBatchStore
+	}
+	err = s.transactOn(token, func(signer wallet.Signer, address common.Address) error {
+		transferer, err := batch.NewTransferer(s.controller.Client, signer, s.controller.Batches)
+		if err != nil {
+			return err
+		}
+		if s.controller.Journal != nil {
+			transferer.Records = s.controller.Journal
+		}
+		job, err = transferer.Run(ctx, id, address, rows)
+		return err
+	})
+	return job, err
+}
+
+func (s *TokenService) Balance(ctx context.Context, token string, owner common.Address) (string, error) {
+	address, err := s.controller.Contracts.Resolve(token, ContractERC20Burnable)
+	if err != nil {
+		return "", err
+	}
+	return contract.BalanceERC20Burnable(ctx, s.controller.Client, address.Hex(), owner.Hex())
+}
+
+func (s *TokenService) transactOn(token string, fn func(signer wallet.Signer, address common.Address) error) error {
+	address, err := s.controller.Contracts.Resolve(token, ContractERC20Burnable)
+	if err != nil {
+		return err
+	}
+	return s.transact(func(signer wallet.Signer) error {
+		return fn(signer, address)
+	})
+}
+
+func (s *TokenService) transact(fn func(signer wallet.Signer) error) error {
+	return s.controller.transact(s.signer, fn)
+}
+
+// SwapService : Swap 컨트랙트 (swap 은 Registry 에 등록된 이름 또는 16진수 주소)
+type SwapService struct {
+	controller *EthereumController
+	signer     wallet.Signer
+}
+
+// As : address 계정으로 서명하는 SwapService
+func (s *SwapService) As(address common.Address) (*SwapService, error) {
+	signer, err := s.controller.Signer(address)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapService{controller: s.controller, signer: signer}, nil
+}
+
+// Available : 교환 가능 여부 (불가능하면 revert 사유를 error 로 반환)
+func (s *SwapService) Available(ctx context.Context, swap string, order contract.SwapOrder) error {
+	address, err := s.controller.Contracts.Resolve(swap, ContractSwap)
+	if err != nil {
+		return err
+	}
+	var from common.Address
+	if signer := s.controller.signerOr(s.signer); signer != nil {
+		from = signer.Address()
+	}
+	return contract.IsSwapAvailable(ctx, s.controller.Client, address, from, order)
+}
+
+// Swap : swapToken 실행 (서명 계정이 Swap 컨트랙트 owner 여야 함)
+func (s *SwapService) Swap(ctx context.Context, swap string, order contract.SwapOrder) (receipt *types.Receipt, err error) {
+	address, err := s.controller.Contracts.Resolve(swap, ContractSwap)
+	if err != nil {
+		return nil, err
+	}
+	err = s.controller.transact(s.signer, func(signer wallet.Signer) error {
+		receipt, err = contract.SwapToken(ctx, s.controller.Client, signer, address, order)
+		return err
+	})
+	return receipt, err
+}
+
+func (s *SwapService) Owner(ctx context.Context, swap string) (common.Address, error) {
+	address, err := s.controller.Contracts.Resolve(swap, ContractSwap)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return contract.SwapOwner(ctx, s.controller.Client, address)
+}
+
+// EventService : 이벤트 조회(HTTP)와 구독(WebSocket), 구독은 Shutdown 에서 해지
+type EventService struct {
+	controller *EthereumController
+	factory    *event.EventFactory
+}
+
+// Request : Registry 에 등록된 컨트랙트 이름으로 EventRequest 생성
+func (s *EventService) Request(name string, eventName string, rules map[string][]interface{}) (event.EventRequest, error) {
+	registered, err := s.controller.Contracts.Get(name)
+	if err != nil {
+		return event.EventRequest{}, err
+	}
+	return event.EventRequest{
+		ABI:       registered.ABI,
+		Addresses: []common.Address{registered.Address},
+		Events: event.EventDescription{
+			Name:  eventName,
+			Rules: rules,
+		},
+	}, nil
+}
+
+// History : from, to 가 nil 이면 전체 블록
+func (s *EventService) History(request event.EventRequest, from, to *big.Int) ([]event.EventResponse, error) {
+	return s.factory.NewEventHistoryFinder(request, from, to).History()
+}
+
+// Subscribe : 구독을 끝낼 때는 반환된 subscriber 의 Unsubscribe 호출
+func (s *EventService) Subscribe(request event.EventRequest) (*event.EventSubscriber, chan event.EventResponse, chan error, error) {
+	return s.subscribe("", s.factory.NewEventSubscriber(request))
+}
+
+// SubscribeDurable : Checkpoints 에 name 으로 저장된 블록부터 구독, Shutdown 에서 다음 블록을 저장
+// 저장된 블록이 없으면 구독 시점의 최신 블록 다음부터 (이벤트를 받기 전에 종료해도 재시작 때 그 사이 이벤트를 전달)
+func (s *EventService) SubscribeDurable(name string, request event.EventRequest) (*event.EventSubscriber, chan event.EventResponse, chan error, error) {
+	subscriber := s.factory.NewEventSubscriber(request)
+	if s.controller.Checkpoints != nil {
+		from, err := s.controller.Checkpoints.Load(name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if from == nil {
+			head, err := s.controller.Client.BlockNumber(context.Background())
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			from = new(big.Int).SetUint64(head + 1)
+		}
+		subscriber.StartFrom(from)
+	}
+	return s.subscribe(name, subscriber)
+}
+
+func (s *EventService) subscribe(name string, subscriber *event.EventSubscriber) (*event.EventSubscriber, chan event.EventResponse, chan error, error) {
+	if err := s.controller.track(name, subscriber); err != nil {
+		return nil, nil, nil, err
+	}
+	outch, errch, err := subscriber.Subscribe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return subscriber, outch, errch, nil
+}
+
+// BlockService : 블록, 트랜잭션 조회
+type BlockService struct {
+	controller *EthereumController
+}
+
+// Latest : 최신 블록 번호
+func (s *BlockService) Latest() (string, error) {
+	return blockchain.GetBlockHeader(s.controller.Client)
+}
+
+// Get : number 가 nil 이면 최신 블록
+func (s *BlockService) Get(number *big.Int) (*types.Block, error) {
+	return blockchain.GetBlock(s.controller.Client, number)
+}
+
+func (s *BlockService) TransactionCount(block *types.Block) (uint, error) {
+	return blockchain.GetTransactionCount(s.controller.Client, block)
+}
+
+func (s *BlockService) Receipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return s.controller.Client.TransactionReceipt(ctx, txHash)
+}
+
+// ReadService : 여러 컨트랙트 조회를 묶어서 실행
+// Registry 에 Multicall 컨트랙트가 등록되어 있으면 사용하고 (여러 개면 이름 순 첫 번째), 없으면 JSON-RPC batch
+type ReadService struct {
+	controller *EthereumController
+}
+
+// DeployMulticall : Multicall 컨트랙트 배포 후 name 으로 등록 (이후 조회부터 사용)
+func (s *ReadService) DeployMulticall(ctx context.Context, name string) (common.Address, error) {
+	var address common.Address
+	err := s.controller.transact(nil, func(signer wallet.Signer) error {
+		response, err := contract.DeployMulticall(ctx, s.controller.Client, signer)
+		if err != nil {
+			return err
+		}
+		address = response.Address
+		return s.controller.Contracts.Register(name, ContractMulticall, address)
+	})
+	return address, err
+}
+
+// Calls : calls 순서대로 결과 (호출별 revert 는 CallResult.Err)
+func (s *ReadService) Calls(ctx context.Context, calls []contract.Call) ([]contract.CallResult, error) {
+	return s.reader().Calls(ctx, calls)
+}
+
+// BalancesOf : holders 순서대로 token 잔액
+func (s *ReadService) BalancesOf(ctx context.Context, token string, holders []common.Address) ([]*big.Int, error) {
+	address, err := s.controller.Contracts.Resolve(token, ContractERC20Burnable)
+	if err != nil {
+		return nil, err
+	}
+	return s.reader().BalancesOf(ctx, address, holders)
+}
+
+// TokenInfo : tokens 순서대로 name, symbol, decimals, totalSupply
+func (s *ReadService) TokenInfo(ctx context.Context, tokens ...string) ([]contract.TokenInfo, error) {
+	addresses := make([]common.Address, len(tokens))
+	for i, token := range tokens {
+		address, err := s.controller.Contracts.Resolve(token, ContractERC20Burnable)
+		if err != nil {
+			return nil, err
+		}
+		addresses[i] = address
+	}
+	return s.reader().TokenInfo(ctx, addresses...)
+}
+
+func (s *ReadService) reader() *contract.Reader {
+	reader := contract.NewReader(s.controller.Client)
+	for _, registered := range s.controller.Contracts.List() {
+		if registered.Type == ContractMulticall {
+			reader.Multicall = registered.Address
+			break
+		}
+	}
+	return reader
+}
+
+// TxService : 전송한 트랜잭션의 상태 조회와 교체 (트랜잭션을 보낸 계정이 서명 계정이어야 함)
+type TxService struct {
+	controller *EthereumController
+}
+
+// SpeedUp : 같은 내용을 더 높은 가격으로 다시 전송
+func (s *TxService) SpeedUp(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	return s.replace(ctx, hash, s.controller.Transactions.SpeedUp)
+}
+
+// Cancel : 같은 nonce 로 자기 자신에게 0 을 보내는 트랜잭션을 더 높은 가격으로 전송
+func (s *TxService) Cancel(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	return s.replace(ctx, hash, s.controller.Transactions.Cancel)
+}
+
+// Nonces : account 의 채굴된 nonce, 노드 pending nonce, txpool 의 비어 있는 nonce 와 막힌 트랜잭션
+func (s *TxService) Nonces(ctx context.Context, account common.Address) (*txmanager.NonceReport, error) {
+	return s.controller.Transactions.Inspect(ctx, account)
+}
+
+// RepairGaps : account(서명 계정)의 비어 있는 nonce 를 기록된 트랜잭션 또는 자기 자신에게 보내는 0 전송으로 채움
+// 이후 nonce 할당은 노드 기준으로 다시 맞춤
+func (s *TxService) RepairGaps(ctx context.Context, account common.Address) (report *txmanager.NonceReport, fills []txmanager.Fill, err error) {
+	signer, err := s.controller.Signer(account)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = s.controller.transact(signer, func(signer wallet.Signer) error {
+		defer s.controller.Nonces.Reset(account)
+		report, fills, err = s.controller.Transactions.RepairGaps(ctx, signer)
+		return err
+	})
+	return report, fills, err
+}
+
+// Status : 교체 이력을 포함한 상태
+func (s *TxService) Status(ctx context.Context, hash common.Hash) (*txmanager.Status, error) {
+	return s.controller.Transactions.Status(ctx, hash)
+}
+
+func (s *TxService) replace(ctx context.Context, hash common.Hash, fn func(context.Context, wallet.Signer, common.Hash) (*types.Transaction, error)) (tx *types.Transaction, err error) {
+	from, err := s.controller.Transactions.Sender(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := s.controller.Signer(from)
+	if err != nil {
+		return nil, err
+	}
+	err = s.controller.transact(signer, func(signer wallet.Signer) error {
+		tx, err = fn(ctx, signer, hash)
+		return err
+	})
+	return tx, err
+}
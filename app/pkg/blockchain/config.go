@@ -2,10 +2,29 @@ package blockchain
 
 type Config struct {
 	EndPoint                   string
+	EndPoints                  []string
 	WebSocket                  string
 	TxTimeoutSec               uint64
 	CheckTxReceiptTimeMilliSec uint64
 	NonceErrRetryCnt           uint8
 	DebugMode                  bool
 	UserLockEnable             bool
+
+	// RPC 호출 middleware (client.NewBackend)
+	// RPCRetryCnt : 연결 오류 시 재시도 횟수, RPCRetryIntervalMilliSec 부터 두 배씩 대기
+	// RPCRateLimit : 초당 호출 수 제한 (0이면 제한 없음), RPCRateBurst : 연속 허용 호출 수
+	RPCRetryCnt              uint8
+	RPCRetryIntervalMilliSec uint64
+	RPCRateLimit             float64
+	RPCRateBurst             int
+
+	// HealthCheckIntervalSec : client.Pool 이 비정상으로 표시한 노드를 다시 확인하는 주기 (0 이면 10초)
+	HealthCheckIntervalSec uint64
+}
+
+// ContractConfig : client.Registry 에 이름으로 등록할 배포 컨트랙트 (Type : ERC20Burnable | Swap | Multicall)
+type ContractConfig struct {
+	Name    string
+	Type    string
+	Address string
 }
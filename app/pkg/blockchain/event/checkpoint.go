@@ -0,0 +1,86 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CheckpointStore : 구독 이름별로 다음에 전달할 이벤트의 블록 저장 (재시작 시 EventSubscriber.StartFrom 에 사용)
+type CheckpointStore interface {
+	// Load : 저장된 적이 없으면 nil
+	Load(name string) (*big.Int, error)
+	Save(name string, next *big.Int) error
+}
+
+// FileCheckpointStore : 하나의 JSON 파일({"name": "block"})에 저장
+type FileCheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (s *FileCheckpointStore) Load(name string) (*big.Int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	value, exist := checkpoints[name]
+	if !exist {
+		return nil, nil
+	}
+	next, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, errors.New("invalid checkpoint for " + name + ": " + value)
+	}
+	return next, nil
+}
+
+// Save : 임시 파일에 쓴 뒤 교체하므로 저장 중에 종료되어도 기존 파일은 유지
+func (s *FileCheckpointStore) Save(name string, next *big.Int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+	checkpoints[name] = next.String()
+
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func (s *FileCheckpointStore) read() (map[string]string, error) {
+	checkpoints := map[string]string{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "data", "checkpoints.json"))
+
+	next, err := store.Load("transfer")
+	assert.Equal(t, nil, err)
+	assert.Nil(t, next)
+
+	assert.Equal(t, nil, store.Save("transfer", big.NewInt(10)))
+	assert.Equal(t, nil, store.Save("approval", big.NewInt(3)))
+	assert.Equal(t, nil, store.Save("transfer", big.NewInt(12)))
+
+	next, err = store.Load("transfer")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, big.NewInt(12), next)
+	next, err = store.Load("approval")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, big.NewInt(3), next)
+}
+
+func TestSubscriber_StartFrom(t *testing.T) {
+	backend, erc20Address := newTestChain(t)
+	eventFactory := NewEventFactoryWithBackend(backend.RPCClient(), backend.RPCClient())
+
+	// mint, Accounts[1], Accounts[2] 전송 중 Accounts[2] 전송 블록부터
+	history, err := eventFactory.NewEventHistoryFinder(newRequest(t, erc20Address, nil), nil, nil).History()
+	assert.Equal(t, nil, err)
+	from := new(big.Int).SetUint64(history[2].BlockNumber)
+
+	subscriber := eventFactory.NewEventSubscriber(newRequest(t, erc20Address, nil))
+	subscriber.StartFrom(from)
+	outch, errch, err := subscriber.Subscribe()
+	assert.Equal(t, nil, err)
+	defer subscriber.Unsubscribe()
+
+	select {
+	case event := <-outch:
+		assert.Equal(t, big.NewInt(20), event.Event["value"])
+	case err := <-errch:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+	assert.Equal(t, new(big.Int).Add(from, big.NewInt(1)), subscriber.Checkpoint())
+}
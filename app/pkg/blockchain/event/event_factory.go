@@ -2,18 +2,19 @@ package event
 
 import (
 	"tiny-blockchain-app/app/pkg/blockchain"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type EventFactory struct {
-	httpCli      *ethclient.Client
-	websocketCli *ethclient.Client
+	httpCli      backend.LogSubscriber
+	websocketCli backend.LogSubscriber
 }
 
 func NewEventFactory(conf blockchain.Config) (*EventFactory, error) {
 
-	httpCli, err := ethclient.Dial(conf.EndPoint)
+	httpCli, err := backend.Dial(conf.EndPoint)
 	if err != nil {
 		return nil, err
 	}
@@ -23,8 +24,13 @@ func NewEventFactory(conf blockchain.Config) (*EventFactory, error) {
 		return nil, err
 	}
 
+	return NewEventFactoryWithBackend(httpCli, websocketCli), nil
+}
+
+// NewEventFactoryWithBackend : 이미 연결된 backend로 생성 (history는 httpCli, 구독은 websocketCli 사용)
+func NewEventFactoryWithBackend(httpCli backend.LogSubscriber, websocketCli backend.LogSubscriber) *EventFactory {
 	return &EventFactory{
 		httpCli:      httpCli,
 		websocketCli: websocketCli,
-	}, nil
+	}
 }
@@ -6,25 +6,34 @@ import (
 	"math/big"
 	"reflect"
 	"strconv"
+	"sync"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/logging"
+	"tiny-blockchain-app/app/pkg/metrics"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 // Structs
 type EventSubscriber struct {
-	cli     *ethclient.Client
+	cli     backend.LogSubscriber
 	request EventRequest
 	outch   chan EventResponse
 	errch   chan error
 	subch   chan bool
+	once    sync.Once
+
+	// next : 다음에 전달할 이벤트의 블록 (StartFrom 으로 지정하거나 마지막으로 전달한 블록 + 1)
+	mu   sync.Mutex
+	next *big.Int
 }
 
 type EventHistoryFinder struct {
-	cli     *ethclient.Client
+	cli     backend.LogSubscriber
 	request EventRequest
 	from    *big.Int
 	to      *big.Int
@@ -50,6 +59,11 @@ type EventResponse struct {
 	Event       map[string]interface{} // Event rules
 }
 
+// resubscribeInterval : 구독이 끊겼을 때 재구독 시도 간격
+var resubscribeInterval = time.Second
+
+var logger = logging.New("event")
+
 type abiInput struct {
 	Indexed bool
 	Type    string
@@ -91,16 +105,42 @@ func (e *EventSubscriber) Subscribe() (chan EventResponse, chan error, error) {
 		if err != nil {
 			return
 		}
+		// 시작 블록이 지정되어 있으면 그 블록부터 구독 시작 전까지의 이벤트 먼저 전달
+		if from := e.Checkpoint(); from != nil {
+			e.setCheckpoint(e.backfill(query, from))
+		}
 		for {
 			select {
 			case err := <-sub.Err():
-				e.errch <- err
+				logger.Warn("Event subscription dropped", "event", e.request.Events.Name, "err", err)
+				if !e.fail(err) {
+					return
+				}
+				// 연결이 끊기면 다시 구독하고, 끊긴 동안의 이벤트는 마지막으로 받은 블록 다음부터 조회하여 전달
+				sub = e.resubscribe(query, logs)
+				if sub == nil {
+					return
+				}
+				if next := e.Checkpoint(); next != nil {
+					e.setCheckpoint(e.backfill(query, next))
+				}
 			case vLog := <-logs:
+				// backfill 로 이미 전달한 블록의 이벤트는 건너뜀
+				if next := e.Checkpoint(); next != nil && vLog.BlockNumber < next.Uint64() {
+					continue
+				}
 				event, err := getEvent(e.request, vLog)
-				if err != nil {
-					e.errch <- err
+				if err != nil && !e.fail(err) {
+					sub.Unsubscribe()
+					return
+				}
+				logger.Debug("Event received", "event", event.Name, "block", vLog.BlockNumber, "tx", vLog.TxHash, "index", vLog.Index)
+				e.observeLag(vLog.BlockNumber)
+				if !e.send(event) {
+					sub.Unsubscribe()
+					return
 				}
-				e.outch <- event
+				e.setCheckpoint(new(big.Int).SetUint64(vLog.BlockNumber + 1))
 			case <-e.subch:
 				sub.Unsubscribe() // Unsubscribe cancels the sending of events to the data channel and closes the error channel.
 				return
@@ -110,6 +150,110 @@ func (e *EventSubscriber) Subscribe() (chan EventResponse, chan error, error) {
 	return e.outch, e.errch, nil
 }
 
+// resubscribe : 재구독에 성공하면 새 구독, 그 전에 구독이 해지되면 nil 반환
+func (e *EventSubscriber) resubscribe(query ethereum.FilterQuery, logs chan types.Log) ethereum.Subscription {
+	for {
+		select {
+		case <-e.subch:
+			return nil
+		case <-time.After(resubscribeInterval):
+		}
+
+		metrics.EventReconnects.Inc(e.request.Events.Name)
+		sub, err := e.cli.SubscribeFilterLogs(context.Background(), query, logs)
+		if err == nil {
+			logger.Info("Event subscription restored", "event", e.request.Events.Name)
+			return sub
+		}
+		logger.Warn("Failed to resubscribe events", "event", e.request.Events.Name, "err", err)
+		if !e.fail(err) {
+			return nil
+		}
+	}
+}
+
+// backfill : from 블록부터 최신 블록까지의 이벤트를 전달하고 다음에 조회할 블록 반환
+func (e *EventSubscriber) backfill(query ethereum.FilterQuery, from *big.Int) *big.Int {
+	query.FromBlock = from
+	logs, err := e.cli.FilterLogs(context.Background(), query)
+	if err != nil {
+		e.fail(err)
+		return from
+	}
+	next := from
+	for _, vLog := range logs {
+		event, err := getEvent(e.request, vLog)
+		if err != nil {
+			if !e.fail(err) {
+				return next
+			}
+			continue
+		}
+		if !e.send(event) {
+			return next
+		}
+		next = new(big.Int).SetUint64(vLog.BlockNumber + 1)
+	}
+	return next
+}
+
+// StartFrom : Subscribe 전에 호출하면 from 블록부터의 이벤트를 먼저 전달 (저장된 checkpoint 에서 재시작)
+func (e *EventSubscriber) StartFrom(from *big.Int) {
+	e.setCheckpoint(from)
+}
+
+// Checkpoint : 다음에 전달할 이벤트의 블록, 전달한 이벤트가 없고 StartFrom 도 호출하지 않았으면 nil
+func (e *EventSubscriber) Checkpoint() *big.Int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.next == nil {
+		return nil
+	}
+	return new(big.Int).Set(e.next)
+}
+
+func (e *EventSubscriber) setCheckpoint(next *big.Int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.next = next
+}
+
+// Unsubscribe : 구독 해지 (여러 번 호출해도 안전), 이후 이벤트와 오류는 전달하지 않음
+func (e *EventSubscriber) Unsubscribe() {
+	e.once.Do(func() {
+		close(e.subch)
+	})
+}
+
+// send : 이벤트 전달, 구독이 해지되었으면 false
+func (e *EventSubscriber) send(event EventResponse) bool {
+	select {
+	case e.outch <- event:
+		return true
+	case <-e.subch:
+		return false
+	}
+}
+
+// fail : 오류 전달, 구독이 해지되었으면 false
+func (e *EventSubscriber) fail(err error) bool {
+	select {
+	case e.errch <- err:
+		return true
+	case <-e.subch:
+		return false
+	}
+}
+
+// observeLag : 최신 블록과 전달한 이벤트 블록의 차이 기록
+func (e *EventSubscriber) observeLag(blockNumber uint64) {
+	head, err := e.cli.BlockNumber(context.Background())
+	if err != nil || head < blockNumber {
+		return
+	}
+	metrics.EventLag.Set(float64(head-blockNumber), e.request.Events.Name)
+}
+
 // History
 func (e *EventHistoryFinder) History() ([]EventResponse, error) {
 
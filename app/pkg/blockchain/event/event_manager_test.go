@@ -1,10 +1,12 @@
 package event
 
 import (
-	"fmt"
+	"math/big"
 	"strings"
 	"testing"
-	"tiny-blockchain-app/app/pkg/blockchain"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	smartcontract "tiny-blockchain-app/smartcontract/golang"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -12,49 +14,126 @@ import (
 )
 
 var erc20ABI string = "[{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name_\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol_\",\"type\":\"string\"},{\"internalType\":\"uint8\",\"name\":\"decimals_\",\"type\":\"uint8\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Burn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Mint\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Paused\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"UnPaused\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"paused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unPause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
-var erc20Address string = "0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9"
 
-func TestHistoryFinder_Transfer_Filtered(t *testing.T) {
+// newTestChain : ERC20Burnable을 배포하고 Accounts[0] -> Accounts[1], Accounts[2] 로 전송한 시뮬레이션 체인
+func newTestChain(t *testing.T) (*simulated.Backend, common.Address) {
+	backend, err := simulated.New(3)
+	assert.Equal(t, nil, err)
+	t.Cleanup(func() { backend.Close() })
 
-	eventFactory, err := NewEventFactory(
-		blockchain.Config{
-			EndPoint:  "http://127.0.0.1:22001",
-			WebSocket: "ws://127.0.0.1:32001",
-		},
-	)
+	owner := backend.Accounts[0]
+	erc20Address, _, err := backend.DeployERC20Burnable(*owner, "ERC20Burnable", "E2B", 10)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, backend.MintERC20Burnable(*owner, erc20Address, owner.PublicKey, big.NewInt(1000)))
+
+	transfer(t, backend, erc20Address, backend.Accounts[1].PublicKey, 10)
+	transfer(t, backend, erc20Address, backend.Accounts[2].PublicKey, 20)
+	return backend, erc20Address
+}
+
+func transfer(t *testing.T, backend *simulated.Backend, token common.Address, to common.Address, amount int64) {
+	instance, err := smartcontract.NewERC20Burnable(token, backend)
+	assert.Equal(t, nil, err)
+	tx, err := instance.Transfer(backend.TransactOpts(*backend.Accounts[0]), to, big.NewInt(amount))
+	assert.Equal(t, nil, err)
+	_, err = backend.Wait(tx)
 	assert.Equal(t, nil, err)
+}
 
+func newRequest(t *testing.T, erc20Address common.Address, rules map[string][]interface{}) EventRequest {
 	// convert string to abi
 	contractABI, err := abi.JSON(strings.NewReader(string(erc20ABI)))
 	assert.Equal(t, nil, err)
 
 	// set contract base info
 	addresses := make([]common.Address, 0)
-	addresses = append(addresses, common.HexToAddress(erc20Address))
-
-	desc := EventDescription{
-		Name: "Transfer",
-		Rules: map[string][]interface{}{
-			// "to":   {"0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448"},
-			"from": {"0xa11c095c7262e0f1c001c397dfb288cc2c1c516b"},
-			// "to": {"0xa11c095c7262e0f1c001c397dfb288cc2c1c516b"},
-			"to": {"0x9ade886ede77a25501a404f5b38430819971f65b"},
-		},
-	}
+	addresses = append(addresses, erc20Address)
 
 	// filtered info
-	request := EventRequest{
+	return EventRequest{
 		ABI:       contractABI,
 		Addresses: addresses,
-		Events:    desc,
+		Events: EventDescription{
+			Name:  "Transfer",
+			Rules: rules,
+		},
 	}
+}
+
+func TestHistoryFinder_Transfer_Filtered(t *testing.T) {
+	backend, erc20Address := newTestChain(t)
+	eventFactory := NewEventFactoryWithBackend(backend, backend)
+
+	request := newRequest(t, erc20Address, map[string][]interface{}{
+		"from": {backend.Accounts[0].PublicKey.Hex()},
+		"to":   {backend.Accounts[2].PublicKey.Hex()},
+	})
 
 	historyFinder := eventFactory.NewEventHistoryFinder(request, nil, nil)
 	events, err := historyFinder.History()
 	assert.Equal(t, nil, err)
 
-	fmt.Println("Event length : ", len(events))
-	for _, e := range events {
-		t.Log(e)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "Transfer", events[0].Name)
+	assert.Equal(t, big.NewInt(20), events[0].Event["value"])
+}
+
+func TestHistoryFinder_Transfer_All(t *testing.T) {
+	backend, erc20Address := newTestChain(t)
+	eventFactory := NewEventFactoryWithBackend(backend.RPCClient(), backend.RPCClient())
+
+	events, err := eventFactory.NewEventHistoryFinder(newRequest(t, erc20Address, nil), nil, nil).History()
+	assert.Equal(t, nil, err)
+
+	// mint(0x0 -> owner), owner -> Accounts[1], owner -> Accounts[2]
+	assert.Equal(t, 3, len(events))
+}
+
+func TestSubscriber_Transfer(t *testing.T) {
+	backend, erc20Address := newTestChain(t)
+	eventFactory := NewEventFactoryWithBackend(backend.RPCClient(), backend.RPCClient())
+
+	request := newRequest(t, erc20Address, map[string][]interface{}{
+		"to": {backend.Accounts[1].PublicKey.Hex()},
+	})
+	subscriber := eventFactory.NewEventSubscriber(request)
+	outch, errch, err := subscriber.Subscribe()
+	assert.Equal(t, nil, err)
+
+	// 구독이 등록될 때까지 대기 후 전송
+	time.Sleep(100 * time.Millisecond)
+	transfer(t, backend, erc20Address, backend.Accounts[2].PublicKey, 5)
+	transfer(t, backend, erc20Address, backend.Accounts[1].PublicKey, 7)
+
+	select {
+	case event := <-outch:
+		assert.Equal(t, "Transfer", event.Name)
+		assert.Equal(t, big.NewInt(7), event.Event["value"])
+	case err := <-errch:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+}
+
+func TestSubscriber_Unsubscribe(t *testing.T) {
+	backend, erc20Address := newTestChain(t)
+	eventFactory := NewEventFactoryWithBackend(backend.RPCClient(), backend.RPCClient())
+
+	subscriber := eventFactory.NewEventSubscriber(newRequest(t, erc20Address, nil))
+	outch, _, err := subscriber.Subscribe()
+	assert.Equal(t, nil, err)
+	time.Sleep(100 * time.Millisecond)
+
+	// 받지 않은 이벤트가 있어도 해지되어야 함 (여러 번 호출해도 안전)
+	transfer(t, backend, erc20Address, backend.Accounts[1].PublicKey, 5)
+	subscriber.Unsubscribe()
+	subscriber.Unsubscribe()
+
+	transfer(t, backend, erc20Address, backend.Accounts[1].PublicKey, 5)
+	select {
+	case event := <-outch:
+		t.Fatalf("event received after unsubscribe: %v", event)
+	case <-time.After(200 * time.Millisecond):
 	}
 }
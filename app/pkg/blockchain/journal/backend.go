@@ -0,0 +1,57 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Backend : SendTransaction 전에 서명된 트랜잭션을 기록하는 client
+// 기록에 실패하면 전송하지 않고, 처음 전송을 노드가 JSON-RPC 오류로 거부하면 rejected 로 표시
+// (연결 오류는 노드에 도달했을 수 있으므로 pending 으로 두고 Check 에서 확인)
+// 거부되지 않으면 같은 nonce 로 먼저 보낸 pending 기록은 replaced 로 표시
+func (j *Journal) Backend() backend.Backend {
+	return &journalBackend{Backend: j.client, journal: j}
+}
+
+type journalBackend struct {
+	backend.Backend
+	journal *Journal
+}
+
+func (b *journalBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.journal.record(ctx, tx); err != nil {
+		return err
+	}
+	err := b.Backend.SendTransaction(ctx, tx)
+	rejected := false
+	if err != nil {
+		var rpcErr rpc.Error
+		if updateErr := b.journal.update(tx.Hash(), func(entry *Entry) {
+			if entry.Attempts == 1 && errors.As(err, &rpcErr) {
+				entry.Status = StatusRejected
+				rejected = true
+			}
+			entry.Error = err.Error()
+		}); updateErr != nil {
+			logger.Warn("Failed to update journal", "tx", tx.Hash(), "err", updateErr)
+		}
+	}
+	if !rejected {
+		if updateErr := b.journal.supersede(tx.Hash()); updateErr != nil {
+			logger.Warn("Failed to update journal", "tx", tx.Hash(), "err", updateErr)
+		}
+	}
+	return err
+}
+
+func (b *journalBackend) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	return backend.BatchCall(ctx, b.Backend, batch)
+}
+
+func (b *journalBackend) TxPoolContent(ctx context.Context) (*backend.TxPool, error) {
+	return backend.TxPoolContent(ctx, b.Backend)
+}
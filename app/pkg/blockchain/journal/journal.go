@@ -0,0 +1,362 @@
+// journal : 서명한 트랜잭션을 전송 전에 기록하고, 노드에서 사라진 미확정 트랜잭션을 다시 전송
+//
+// SendTransaction 이후 트랜잭션은 노드의 txpool 에만 있으므로 노드가 재시작되면 사라질 수 있음
+// Backend() 로 전송하면 서명된 원본(raw)과 보낸 계정, nonce, 요청자를 먼저 기록하고,
+// Run(또는 Check)이 pending 기록의 receipt 와 nonce 를 확인하여 mined, dropped 로 표시하거나 다시 전송
+package journal
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/logging"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var logger = logging.New("journal")
+
+const (
+	// DefaultRebroadcastInterval : 노드에 없는 pending 기록을 다시 전송하기 전 마지막 전송 후 대기 시간
+	DefaultRebroadcastInterval = 30 * time.Second
+	// DefaultMaxAttempts : 처음 전송을 포함한 전송 횟수 (그 뒤에도 노드에 없으면 dropped)
+	DefaultMaxAttempts = 10
+	// maxEntries : 메모리에 보관하는 기록 수 (넘으면 끝난 기록, pending 기록 순으로 오래된 것부터 pruneTo 개까지 삭제)
+	maxEntries = 10000
+	// pruneTo : 정리 후 남기는 기록 수 (정리 후 maxEntries-pruneTo 번 저장할 때까지는 다시 정렬하지 않음)
+	pruneTo = maxEntries * 9 / 10
+)
+
+// Config : journal 파일과 다시 전송 주기 (File 이 비어 있으면 메모리에만 기록)
+type Config struct {
+	File                   string
+	RebroadcastIntervalSec int
+	MaxAttempts            int
+}
+
+// Status : 기록 상태
+//
+//	pending  : 전송함 (receipt 대기)
+//	mined    : 성공 receipt 확인
+//	reverted : 실패 receipt 확인
+//	dropped  : 다른 트랜잭션이 nonce 를 사용했거나 다시 전송 횟수를 넘음
+//	rejected : 처음 전송을 노드가 JSON-RPC 오류로 거부함 (연결 오류는 pending)
+//	replaced : 같은 nonce 의 교체 트랜잭션(speed-up, cancel)을 전송함 (다시 전송하지 않음)
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusMined    Status = "mined"
+	StatusReverted Status = "reverted"
+	StatusDropped  Status = "dropped"
+	StatusRejected Status = "rejected"
+	StatusReplaced Status = "replaced"
+)
+
+// Entry : 서명한 트랜잭션 하나의 기록
+type Entry struct {
+	Hash  common.Hash     `json:"hash"`
+	From  common.Address  `json:"from"`
+	Nonce uint64          `json:"nonce"`
+	To    *common.Address `json:"to,omitempty"`
+	Value string          `json:"value"`
+	// RawTx : 서명된 원본 (REST 응답에서는 제외)
+	RawTx hexutil.Bytes `json:"rawTx,omitempty"`
+	// Requester : 요청한 사용자 (REST 인증 사용자 이름, 없으면 비어 있음), RequestID : REST 요청 correlation ID
+	Requester string `json:"requester,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+	Status    Status `json:"status"`
+	// ReplacedBy : 이 기록을 교체한 트랜잭션 (replaced 일 때)
+	ReplacedBy *common.Hash `json:"replacedBy,omitempty"`
+	// Attempts : 전송 횟수 (처음 전송 포함)
+	Attempts  int       `json:"attempts"`
+	Block     uint64    `json:"block,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// SentAt : 마지막 전송 시각
+	SentAt time.Time `json:"sentAt"`
+}
+
+// Transaction : 기록된 서명 트랜잭션
+func (e *Entry) Transaction() (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(e.RawTx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+type requesterKey struct{}
+
+// WithRequester : 이 context 로 전송하는 트랜잭션의 요청자
+func WithRequester(ctx context.Context, requester string) context.Context {
+	return context.WithValue(ctx, requesterKey{}, requester)
+}
+
+// Requester : context 의 요청자 (없으면 빈 문자열)
+func Requester(ctx context.Context) string {
+	requester, _ := ctx.Value(requesterKey{}).(string)
+	return requester
+}
+
+// Journal : 전송한 트랜잭션 기록 (SetStore 로 저장소를 지정하면 재시작 후에도 유지)
+type Journal struct {
+	client backend.Backend
+
+	// RebroadcastInterval : 마지막 전송 후 이 시간이 지나도 노드에 없으면 다시 전송
+	RebroadcastInterval time.Duration
+	// MaxAttempts : 전송 횟수가 이 값에 도달한 뒤에도 노드에 없으면 dropped
+	MaxAttempts int
+
+	mu      sync.Mutex
+	store   Store
+	entries map[common.Hash]*Entry
+}
+
+// New : client 로 전송하는 journal (메모리에만 기록)
+func New(client backend.Backend) *Journal {
+	return &Journal{
+		client:              client,
+		RebroadcastInterval: DefaultRebroadcastInterval,
+		MaxAttempts:         DefaultMaxAttempts,
+		entries:             map[common.Hash]*Entry{},
+	}
+}
+
+// SetStore : store 의 기록을 불러오고 (끝난 기록을 정리하여 다시 씀) 이후 기록을 store 에 저장
+func (j *Journal) SetStore(store Store) error {
+	entries, err := store.Load()
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, entry := range entries {
+		j.entries[entry.Hash] = entry
+	}
+	j.prune()
+	if err := store.Rewrite(j.sorted()); err != nil {
+		return err
+	}
+	j.store = store
+	return nil
+}
+
+// Read : store 의 기록을 조회만 하는 journal (store 를 다시 쓰지 않고 Backend, Check 는 사용할 수 없음)
+func Read(store Store) (*Journal, error) {
+	entries, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	j := New(nil)
+	for _, entry := range entries {
+		j.entries[entry.Hash] = entry
+	}
+	return j, nil
+}
+
+// Filter : List 조건 (비어 있으면 전체)
+type Filter struct {
+	Status Status
+	From   *common.Address
+	// Limit : 최근 기록부터 최대 개수 (0 이면 전체)
+	Limit int
+}
+
+// List : 조건에 맞는 기록을 오래된 것부터
+func (j *Journal) List(filter Filter) []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var entries []Entry
+	for _, entry := range j.sorted() {
+		if filter.Status != "" && entry.Status != filter.Status {
+			continue
+		}
+		if filter.From != nil && entry.From != *filter.From {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+	return entries
+}
+
+// Entry : hash 의 기록 (없으면 nil)
+func (j *Journal) Entry(hash common.Hash) *Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entry, exist := j.entries[hash]
+	if !exist {
+		return nil
+	}
+	copied := *entry
+	return &copied
+}
+
+// Transaction : from 이 nonce 로 마지막에 전송한 교체 트랜잭션 (txmanager.Manager 의 빈 nonce 채우기에 사용)
+// 거부되었거나 교체된 기록은 제외하고, 처음 기록한 시각이 가장 늦은 (같으면 가스 가격이 가장 높은) 트랜잭션
+func (j *Journal) Transaction(from common.Address, nonce uint64) *types.Transaction {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var latest *types.Transaction
+	var latestAt time.Time
+	for _, entry := range j.entries {
+		if entry.From != from || entry.Nonce != nonce || entry.Status == StatusRejected || entry.Status == StatusReplaced {
+			continue
+		}
+		tx, err := entry.Transaction()
+		if err != nil {
+			continue
+		}
+		if latest == nil || entry.CreatedAt.After(latestAt) ||
+			(entry.CreatedAt.Equal(latestAt) && tx.GasFeeCap().Cmp(latest.GasFeeCap()) > 0) {
+			latest, latestAt = tx, entry.CreatedAt
+		}
+	}
+	return latest
+}
+
+// record : 전송 전 기록 (이미 있는 hash 는 전송 횟수만 증가)
+func (j *Journal) record(ctx context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now()
+	entry, exist := j.entries[tx.Hash()]
+	if !exist {
+		entry = &Entry{
+			Hash:      tx.Hash(),
+			From:      from,
+			Nonce:     tx.Nonce(),
+			To:        tx.To(),
+			Value:     tx.Value().String(),
+			RawTx:     raw,
+			Requester: Requester(ctx),
+			RequestID: logging.RequestID(ctx),
+			CreatedAt: now,
+		}
+		j.entries[entry.Hash] = entry
+	}
+	entry.Status = StatusPending
+	entry.Attempts++
+	entry.Error = ""
+	entry.SentAt = now
+	entry.UpdatedAt = now
+	return j.save(entry)
+}
+
+// supersede : hash 보다 먼저 기록된 같은 계정, nonce 의 pending 기록을 replaced 로 표시 (다시 전송하지 않음)
+func (j *Journal) supersede(hash common.Hash) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	replacement, exist := j.entries[hash]
+	if !exist {
+		return nil
+	}
+	now := time.Now()
+	for _, entry := range j.entries {
+		if entry.From != replacement.From || entry.Nonce != replacement.Nonce || entry.Hash == hash ||
+			entry.Status != StatusPending || entry.CreatedAt.After(replacement.CreatedAt) {
+			continue
+		}
+		entry.Status = StatusReplaced
+		entry.ReplacedBy = &replacement.Hash
+		entry.UpdatedAt = now
+		if err := j.save(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaced : from 이 nonce 로 보냈다가 교체된 기록
+func (j *Journal) replaced(from common.Address, nonce uint64) []Entry {
+	var entries []Entry
+	for _, entry := range j.List(Filter{Status: StatusReplaced, From: &from}) {
+		if entry.Nonce == nonce {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// update : hash 기록을 fn 으로 바꾸고 저장
+func (j *Journal) update(hash common.Hash, fn func(entry *Entry)) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entry, exist := j.entries[hash]
+	if !exist {
+		return nil
+	}
+	fn(entry)
+	entry.UpdatedAt = time.Now()
+	return j.save(entry)
+}
+
+// save : j.mu 를 잡은 상태에서 호출
+func (j *Journal) save(entry *Entry) error {
+	j.prune()
+	if j.store == nil {
+		return nil
+	}
+	return j.store.Append(entry)
+}
+
+// prune : 기록이 maxEntries 를 넘으면 pruneTo 개만 남기고 메모리에서 삭제 (j.mu 를 잡은 상태에서 호출)
+func (j *Journal) prune() {
+	if len(j.entries) <= maxEntries {
+		return
+	}
+	entries := make([]*Entry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		finishedA, finishedB := entries[a].Status != StatusPending, entries[b].Status != StatusPending
+		if finishedA != finishedB {
+			return finishedA
+		}
+		return entries[a].UpdatedAt.Before(entries[b].UpdatedAt)
+	})
+	for _, entry := range entries[:len(entries)-pruneTo] {
+		delete(j.entries, entry.Hash)
+	}
+}
+
+// sorted : 기록 순서 (j.mu 를 잡은 상태에서 호출)
+func (j *Journal) sorted() []*Entry {
+	entries := make([]*Entry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(a, b int) bool {
+		if !entries[a].CreatedAt.Equal(entries[b].CreatedAt) {
+			return entries[a].CreatedAt.Before(entries[b].CreatedAt)
+		}
+		return entries[a].Nonce < entries[b].Nonce
+	})
+	return entries
+}
+
+// pending : 다시 확인할 기록
+func (j *Journal) pending() []Entry {
+	return j.List(Filter{Status: StatusPending})
+}
@@ -0,0 +1,317 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+// rpcError : 노드가 JSON-RPC 오류로 응답한 경우
+type rpcError struct{ error }
+
+func (rpcError) ErrorCode() int { return -32000 }
+
+// nodeBackend : 노드의 거부를 JSON-RPC 오류로 반환하고, disconnected 이면 전송하지 않고 연결 오류 반환
+type nodeBackend struct {
+	*simulated.Backend
+	disconnected bool
+}
+
+func (b *nodeBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if b.disconnected {
+		return errors.New("connection reset by peer")
+	}
+	if err := b.Backend.SendTransaction(ctx, tx); err != nil {
+		return rpcError{err}
+	}
+	return nil
+}
+
+// newPoolChain : 블록을 Commit 할 때만 만들고 txpool 로 트랜잭션을 보관하는 체인
+func newPoolChain(t *testing.T) (*simulated.Backend, *Journal) {
+	sim, err := simulated.New(2)
+	assert.Equal(t, nil, err)
+	t.Cleanup(func() { sim.Close() })
+	sim.SetTxPool(true)
+	sim.SetAutoCommit(false)
+	return sim, New(&nodeBackend{Backend: sim})
+}
+
+// signLegacy : signer 가 nonce 로 0x1000 에게 value 를 보내는 트랜잭션
+func signLegacy(t *testing.T, sim *simulated.Backend, signer wallet.Signer, nonce uint64, value int64) *types.Transaction {
+	ctx := context.Background()
+	price, err := sim.SuggestGasPrice(ctx)
+	assert.Equal(t, nil, err)
+	chainID, _ := sim.ChainID(ctx)
+	tx, err := signer.SignTx(types.NewTransaction(nonce, common.HexToAddress("0x1000"), big.NewInt(value), params.TxGas, price, nil), chainID)
+	assert.Equal(t, nil, err)
+	return tx
+}
+
+func TestJournal_Backend(t *testing.T) {
+	sim, journal := newPoolChain(t)
+	ctx := WithRequester(context.Background(), "alice")
+	signer := sim.Accounts[0]
+
+	tx := signLegacy(t, sim, signer, 0, 1)
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, tx))
+	entry := journal.Entry(tx.Hash())
+	assert.Equal(t, StatusPending, entry.Status)
+	assert.Equal(t, signer.Address(), entry.From)
+	assert.Equal(t, "alice", entry.Requester)
+	assert.Equal(t, 1, entry.Attempts)
+	recorded, err := entry.Transaction()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, tx.Hash(), recorded.Hash())
+	assert.Equal(t, tx.Hash(), journal.Transaction(signer.Address(), 0).Hash())
+
+	// 노드가 거부한 전송도 기록
+	rejected := signLegacy(t, sim, signer, 0, 2)
+	assert.NotEqual(t, nil, journal.Backend().SendTransaction(ctx, rejected))
+	entry = journal.Entry(rejected.Hash())
+	assert.Equal(t, StatusRejected, entry.Status)
+	assert.NotEqual(t, "", entry.Error)
+	assert.Equal(t, tx.Hash(), journal.Transaction(signer.Address(), 0).Hash())
+
+	assert.Equal(t, 2, len(journal.List(Filter{})))
+	assert.Equal(t, 1, len(journal.List(Filter{Status: StatusRejected})))
+	assert.Equal(t, rejected.Hash(), journal.List(Filter{Limit: 1})[0].Hash)
+	other := sim.Accounts[1].Address()
+	assert.Equal(t, 0, len(journal.List(Filter{From: &other})))
+
+	// 연결 오류는 노드에 도달했을 수 있으므로 pending
+	journal.client.(*nodeBackend).disconnected = true
+	unsent := signLegacy(t, sim, signer, 1, 1)
+	assert.NotEqual(t, nil, journal.Backend().SendTransaction(ctx, unsent))
+	entry = journal.Entry(unsent.Hash())
+	assert.Equal(t, StatusPending, entry.Status)
+	assert.Equal(t, "connection reset by peer", entry.Error)
+	assert.Equal(t, unsent.Hash(), journal.Transaction(signer.Address(), 1).Hash())
+}
+
+func TestJournal_Check(t *testing.T) {
+	sim, journal := newPoolChain(t)
+	journal.RebroadcastInterval = 0
+	journal.MaxAttempts = 2
+	ctx := context.Background()
+	client := journal.Backend()
+
+	mined := signLegacy(t, sim, sim.Accounts[0], 0, 1)
+	lost := signLegacy(t, sim, sim.Accounts[1], 0, 1)
+	assert.Equal(t, nil, client.SendTransaction(ctx, mined))
+	assert.Equal(t, nil, client.SendTransaction(ctx, lost))
+
+	// 노드에 있는 트랜잭션은 다시 전송하지 않음
+	result, err := journal.Check(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CheckResult{Pending: 2}, result)
+
+	// 노드에서 사라진 트랜잭션은 원본을 다시 전송
+	assert.True(t, sim.DropFromPool(lost.Hash()))
+	result, err = journal.Check(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CheckResult{Rebroadcast: 1, Pending: 2}, result)
+	assert.Equal(t, 2, journal.Entry(lost.Hash()).Attempts)
+
+	sim.Commit()
+	result, err = journal.Check(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CheckResult{Mined: 2}, result)
+	entry := journal.Entry(mined.Hash())
+	assert.Equal(t, StatusMined, entry.Status)
+	assert.Equal(t, uint64(1), entry.Block)
+	assert.Equal(t, StatusMined, journal.Entry(lost.Hash()).Status)
+}
+
+func TestJournal_CheckDropped(t *testing.T) {
+	sim, journal := newPoolChain(t)
+	journal.RebroadcastInterval = 0
+	journal.MaxAttempts = 1
+	ctx := context.Background()
+
+	// 같은 nonce 를 다른 트랜잭션이 사용
+	replaced := signLegacy(t, sim, sim.Accounts[0], 0, 1)
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, replaced))
+	assert.True(t, sim.DropFromPool(replaced.Hash()))
+	assert.Equal(t, nil, sim.SendTransaction(ctx, signLegacy(t, sim, sim.Accounts[0], 0, 2)))
+	sim.Commit()
+
+	// 다시 전송 횟수를 넘도록 노드에 없는 트랜잭션
+	lost := signLegacy(t, sim, sim.Accounts[1], 0, 1)
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, lost))
+	assert.True(t, sim.DropFromPool(lost.Hash()))
+
+	result, err := journal.Check(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CheckResult{Dropped: 2}, result)
+	assert.Equal(t, StatusDropped, journal.Entry(replaced.Hash()).Status)
+	assert.Equal(t, StatusDropped, journal.Entry(lost.Hash()).Status)
+}
+
+// receiptFailing : failing 의 receipt 조회만 연결 오류 반환
+type receiptFailing struct {
+	*nodeBackend
+	failing common.Hash
+}
+
+func (b *receiptFailing) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if hash == b.failing {
+		return nil, errors.New("connection reset by peer")
+	}
+	return b.nodeBackend.TransactionReceipt(ctx, hash)
+}
+
+func TestJournal_CheckContinues(t *testing.T) {
+	sim, err := simulated.New(2)
+	assert.Equal(t, nil, err)
+	t.Cleanup(func() { sim.Close() })
+	sim.SetTxPool(true)
+	sim.SetAutoCommit(false)
+	ctx := context.Background()
+
+	failing := signLegacy(t, sim, sim.Accounts[0], 0, 1)
+	mined := signLegacy(t, sim, sim.Accounts[1], 0, 1)
+	journal := New(&receiptFailing{nodeBackend: &nodeBackend{Backend: sim}, failing: failing.Hash()})
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, failing))
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, mined))
+	sim.Commit()
+
+	// 한 기록을 확인하지 못해도 나머지 기록은 확인
+	result, err := journal.Check(ctx)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, CheckResult{Mined: 1}, result)
+	assert.Equal(t, StatusPending, journal.Entry(failing.Hash()).Status)
+	assert.Equal(t, StatusMined, journal.Entry(mined.Hash()).Status)
+}
+
+func TestJournal_Prune(t *testing.T) {
+	journal := New(nil)
+	now := time.Now()
+	for i := 0; i <= maxEntries; i++ {
+		hash := common.BigToHash(big.NewInt(int64(i)))
+		journal.entries[hash] = &Entry{Hash: hash, Status: StatusMined, UpdatedAt: now.Add(time.Duration(i) * time.Second)}
+	}
+	pending := common.BigToHash(big.NewInt(0))
+	journal.entries[pending].Status = StatusPending
+
+	// 넘으면 pruneTo 개만 남기고, 끝난 기록 중 오래된 것부터 삭제
+	journal.mu.Lock()
+	assert.Equal(t, nil, journal.save(journal.entries[pending]))
+	journal.mu.Unlock()
+	assert.Equal(t, pruneTo, len(journal.entries))
+	assert.NotEqual(t, (*Entry)(nil), journal.Entry(pending))
+	assert.Equal(t, (*Entry)(nil), journal.Entry(common.BigToHash(big.NewInt(1))))
+	assert.NotEqual(t, (*Entry)(nil), journal.Entry(common.BigToHash(big.NewInt(maxEntries))))
+}
+
+// signPriced : signer 가 nonce 로 0x1000 에게 1 을 보내는 트랜잭션 (제안 가격의 multiplier 배)
+func signPriced(t *testing.T, sim *simulated.Backend, signer wallet.Signer, nonce uint64, multiplier int64) *types.Transaction {
+	ctx := context.Background()
+	price, err := sim.SuggestGasPrice(ctx)
+	assert.Equal(t, nil, err)
+	chainID, _ := sim.ChainID(ctx)
+	price.Mul(price, big.NewInt(multiplier))
+	tx, err := signer.SignTx(types.NewTransaction(nonce, common.HexToAddress("0x1000"), big.NewInt(1), params.TxGas, price, nil), chainID)
+	assert.Equal(t, nil, err)
+	return tx
+}
+
+func TestJournal_Replaced(t *testing.T) {
+	sim, journal := newPoolChain(t)
+	journal.RebroadcastInterval = 0
+	ctx := context.Background()
+	signer := sim.Accounts[0]
+
+	// 교체한 원본은 replaced, 같은 nonce 의 기록은 교체 트랜잭션
+	original := signPriced(t, sim, signer, 0, 1)
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, original))
+	speedUp := signPriced(t, sim, signer, 0, 2)
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, speedUp))
+	entry := journal.Entry(original.Hash())
+	assert.Equal(t, StatusReplaced, entry.Status)
+	assert.Equal(t, speedUp.Hash(), *entry.ReplacedBy)
+	assert.Equal(t, speedUp.Hash(), journal.Transaction(signer.Address(), 0).Hash())
+
+	// 교체 트랜잭션이 노드에서 사라지면 원본이 아닌 교체 트랜잭션만 다시 전송
+	assert.True(t, sim.DropFromPool(speedUp.Hash()))
+	result, err := journal.Check(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CheckResult{Rebroadcast: 1, Pending: 1}, result)
+	assert.Equal(t, 1, journal.Entry(original.Hash()).Attempts)
+
+	// 다시 전송에 실패하면 마지막 전송 시각은 그대로
+	assert.True(t, sim.DropFromPool(speedUp.Hash()))
+	assert.Equal(t, nil, sim.SendTransaction(ctx, signPriced(t, sim, signer, 0, 3)))
+	sentAt := journal.Entry(speedUp.Hash()).SentAt
+	_, err = journal.Check(ctx)
+	assert.Equal(t, nil, err)
+	entry = journal.Entry(speedUp.Hash())
+	assert.Equal(t, 3, entry.Attempts)
+	assert.NotEqual(t, "", entry.Error)
+	assert.True(t, entry.SentAt.Equal(sentAt))
+	assert.Equal(t, speedUp.Hash(), journal.Transaction(signer.Address(), 0).Hash())
+}
+
+func TestJournal_ReplacedMined(t *testing.T) {
+	sim, journal := newPoolChain(t)
+	ctx := context.Background()
+	signer := sim.Accounts[0]
+
+	// 교체 트랜잭션 대신 교체된 원본이 채굴됨
+	original := signPriced(t, sim, signer, 0, 1)
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, original))
+	assert.True(t, sim.DropFromPool(original.Hash()))
+	speedUp := signPriced(t, sim, signer, 0, 2)
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, speedUp))
+	assert.True(t, sim.DropFromPool(speedUp.Hash()))
+	assert.Equal(t, nil, sim.SendTransaction(ctx, original))
+	sim.Commit()
+
+	result, err := journal.Check(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CheckResult{Mined: 1, Dropped: 1}, result)
+	assert.Equal(t, StatusMined, journal.Entry(original.Hash()).Status)
+	entry := journal.Entry(speedUp.Hash())
+	assert.Equal(t, StatusDropped, entry.Status)
+	assert.Equal(t, "replaced transaction "+original.Hash().Hex()+" was mined", entry.Error)
+}
+
+func TestJournal_FileStore(t *testing.T) {
+	sim, journal := newPoolChain(t)
+	ctx := WithRequester(context.Background(), "bob")
+	file := filepath.Join(t.TempDir(), "journal.jsonl")
+	assert.Equal(t, nil, journal.SetStore(NewFileStore(file)))
+
+	tx := signLegacy(t, sim, sim.Accounts[0], 0, 1)
+	assert.Equal(t, nil, journal.Backend().SendTransaction(ctx, tx))
+	sim.Commit()
+	_, err := journal.Check(ctx)
+	assert.Equal(t, nil, err)
+
+	// 갱신마다 줄을 추가하고 다시 읽으면 마지막 기록
+	reloaded := New(sim)
+	assert.Equal(t, nil, reloaded.SetStore(NewFileStore(file)))
+	entry := reloaded.Entry(tx.Hash())
+	assert.Equal(t, StatusMined, entry.Status)
+	assert.Equal(t, "bob", entry.Requester)
+	assert.Equal(t, tx.Hash(), reloaded.Transaction(sim.Accounts[0].Address(), 0).Hash())
+
+	// SetStore 에서 최신 기록만 남겨 다시 씀
+	entries, err := NewFileStore(file).Load()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(entries))
+
+	read, err := Read(NewFileStore(file))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(read.List(Filter{Status: StatusMined})))
+}
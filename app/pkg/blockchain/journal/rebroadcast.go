@@ -0,0 +1,194 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"tiny-blockchain-app/app/pkg/logging"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// CheckResult : Check 한 번의 처리 결과 (기록 수)
+type CheckResult struct {
+	Mined       int `json:"mined"`
+	Dropped     int `json:"dropped"`
+	Rebroadcast int `json:"rebroadcast"`
+	Pending     int `json:"pending"`
+}
+
+// Run : ctx 가 끝날 때까지 interval 마다 Check
+func (j *Journal) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := j.Check(ctx); err != nil && ctx.Err() == nil {
+			logger.Warn("Failed to check journal", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check : pending 기록마다 receipt 가 있으면 mined(reverted), 다른 트랜잭션이 nonce 를 사용했으면 dropped
+// (교체된 기록이 채굴되었으면 그 기록을 mined), 마지막 전송 후 RebroadcastInterval 이 지났는데 노드에 없으면 원본을 다시 전송
+// replaced 기록은 교체 트랜잭션이 대신 확인하므로 다시 전송하지 않음
+// 기록 하나를 확인하지 못해도 로그를 남기고 나머지 기록을 확인 (실패한 기록 수와 마지막 오류 반환)
+func (j *Journal) Check(ctx context.Context) (CheckResult, error) {
+	var result CheckResult
+	log := logging.FromContext(ctx, logger)
+	nonces := map[common.Address]uint64{}
+	failed := 0
+	var lastErr error
+	for _, entry := range j.pending() {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if err := j.check(ctx, entry, nonces, &result); err != nil {
+			log.Warn("Failed to check journal entry", "tx", entry.Hash, "from", entry.From, "nonce", entry.Nonce, "err", err)
+			failed++
+			lastErr = err
+		}
+	}
+	if lastErr != nil {
+		return result, fmt.Errorf("%d entries failed: %w", failed, lastErr)
+	}
+	return result, nil
+}
+
+// check : pending 기록 하나를 확인하고 result 에 반영 (nonces 는 계정마다 확정된 nonce 캐시)
+func (j *Journal) check(ctx context.Context, entry Entry, nonces map[common.Address]uint64, result *CheckResult) error {
+	log := logging.FromContext(ctx, logger)
+
+	// receipt 보다 nonce 를 먼저 조회해야 그 사이 mined 된 트랜잭션을 dropped 로 잘못 표시하지 않음
+	confirmed, exist := nonces[entry.From]
+	if !exist {
+		var err error
+		if confirmed, err = j.client.NonceAt(ctx, entry.From, nil); err != nil {
+			return err
+		}
+		nonces[entry.From] = confirmed
+	}
+
+	receipt, err := j.client.TransactionReceipt(ctx, entry.Hash)
+	if err == nil {
+		status := StatusMined
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			status = StatusReverted
+		}
+		if err := j.update(entry.Hash, func(e *Entry) {
+			e.Status = status
+			e.Block = receipt.BlockNumber.Uint64()
+			e.Error = ""
+		}); err != nil {
+			return err
+		}
+		result.Mined++
+		return nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return err
+	}
+
+	if confirmed > entry.Nonce {
+		minedBy, err := j.checkReplaced(ctx, entry)
+		if err != nil {
+			return err
+		}
+		reason := "nonce was used by another transaction"
+		if minedBy != nil {
+			reason = "replaced transaction " + minedBy.Hex() + " was mined"
+			result.Mined++
+		}
+		if err := j.update(entry.Hash, func(e *Entry) {
+			e.Status = StatusDropped
+			e.Error = reason
+		}); err != nil {
+			return err
+		}
+		log.Warn("Transaction dropped", "tx", entry.Hash, "from", entry.From, "nonce", entry.Nonce, "reason", reason)
+		result.Dropped++
+		return nil
+	}
+
+	result.Pending++
+	if time.Since(entry.SentAt) < j.RebroadcastInterval {
+		return nil
+	}
+	if _, _, err := j.client.TransactionByHash(ctx, entry.Hash); err == nil {
+		return nil
+	} else if !errors.Is(err, ethereum.NotFound) {
+		return err
+	}
+	if entry.Attempts >= j.MaxAttempts {
+		if err := j.update(entry.Hash, func(e *Entry) {
+			e.Status = StatusDropped
+			e.Error = "not in the node after rebroadcasting"
+		}); err != nil {
+			return err
+		}
+		log.Warn("Gave up rebroadcasting transaction", "tx", entry.Hash, "attempts", entry.Attempts)
+		result.Pending--
+		result.Dropped++
+		return nil
+	}
+	if err := j.rebroadcast(ctx, &entry); err != nil {
+		log.Warn("Failed to rebroadcast transaction", "tx", entry.Hash, "err", err)
+		return nil
+	}
+	log.Info("Rebroadcast transaction", "tx", entry.Hash, "from", entry.From, "nonce", entry.Nonce, "attempts", entry.Attempts+1)
+	result.Rebroadcast++
+	return nil
+}
+
+// checkReplaced : entry 가 교체한 기록 중 채굴된 기록을 mined(reverted) 로 표시하고 그 hash 반환 (없으면 nil)
+func (j *Journal) checkReplaced(ctx context.Context, entry Entry) (*common.Hash, error) {
+	for _, replaced := range j.replaced(entry.From, entry.Nonce) {
+		receipt, err := j.client.TransactionReceipt(ctx, replaced.Hash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		status := StatusMined
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			status = StatusReverted
+		}
+		if err := j.update(replaced.Hash, func(e *Entry) {
+			e.Status = status
+			e.Block = receipt.BlockNumber.Uint64()
+		}); err != nil {
+			return nil, err
+		}
+		return &replaced.Hash, nil
+	}
+	return nil, nil
+}
+
+// rebroadcast : 기록된 원본을 다시 전송 (실패해도 전송 횟수는 증가하지만 마지막 전송 시각은 성공했을 때만 갱신)
+func (j *Journal) rebroadcast(ctx context.Context, entry *Entry) error {
+	tx, err := entry.Transaction()
+	if err != nil {
+		return err
+	}
+	sendErr := j.client.SendTransaction(ctx, tx)
+	if err := j.update(entry.Hash, func(e *Entry) {
+		e.Attempts++
+		e.Error = ""
+		if sendErr != nil {
+			e.Error = sendErr.Error()
+			return
+		}
+		e.SentAt = time.Now()
+	}); err != nil {
+		return err
+	}
+	return sendErr
+}
@@ -0,0 +1,109 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store : 기록 저장소
+type Store interface {
+	// Load : 저장된 기록 (같은 hash 는 마지막 기록), 저장된 적이 없으면 빈 목록
+	Load() ([]*Entry, error)
+	// Append : 기록 추가 또는 갱신
+	Append(entry *Entry) error
+	// Rewrite : entries 만 남기고 다시 씀
+	Rewrite(entries []*Entry) error
+}
+
+// FileStore : 한 줄에 기록 하나인 JSON Lines 파일 (갱신도 줄을 추가하고, SetStore 에서 최신 기록만 남겨 다시 씀)
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load : 쓰는 중에 종료되어 잘린 줄은 건너뜀
+func (s *FileStore) Load() ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	index := map[string]int{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			logger.Warn("Skipped malformed journal line", "file", s.path, "err", err)
+			continue
+		}
+		if i, exist := index[entry.Hash.Hex()]; exist {
+			entries[i] = entry
+			continue
+		}
+		index[entry.Hash.Hex()] = len(entries)
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+func (s *FileStore) Append(entry *Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Rewrite : 임시 파일에 쓴 뒤 교체하므로 쓰는 중에 종료되어도 기존 파일은 유지
+func (s *FileStore) Rewrite(entries []*Entry) error {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(line, '\n'))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
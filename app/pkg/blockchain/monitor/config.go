@@ -0,0 +1,19 @@
+package monitor
+
+// Config : 모니터링할 노드 목록과 판정 기준
+type Config struct {
+	Nodes []NodeConfig
+	// IntervalSec : 노드 조회 주기
+	IntervalSec uint64
+	// TimeoutSec : 노드 하나의 조회 제한 시간 (0 이면 IntervalSec), 넘으면 down
+	TimeoutSec uint64
+	// StallTimeoutSec : txpool에 pending 트랜잭션이 있는데 블록 높이가 이 시간 동안 그대로면 stalled
+	StallTimeoutSec uint64
+	// MaxBlockLag : 리더보다 이 블록 수 이상 뒤처지면 lagging
+	MaxBlockLag uint64
+}
+
+type NodeConfig struct {
+	Name     string
+	Endpoint string
+}
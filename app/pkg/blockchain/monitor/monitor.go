@@ -0,0 +1,282 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/quorum"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxStalePolls : 마지막 조회가 조회 주기의 이 배수보다 오래되면 상태를 믿을 수 없으므로 비정상
+const maxStalePolls = 3
+
+// NodeStatus : 한 노드의 조회 결과와 판정
+type NodeStatus struct {
+	Name        string    `json:"name"`
+	Endpoint    string    `json:"endpoint"`
+	Up          bool      `json:"up"`
+	Error       string    `json:"error,omitempty"`
+	BlockNumber uint64    `json:"blockNumber"`
+	PeerCount   uint64    `json:"peerCount"`
+	Role        string    `json:"role"`
+	Leader      string    `json:"leader"`
+	Pending     uint64    `json:"pending"`
+	Queued      uint64    `json:"queued"`
+	Syncing     bool      `json:"syncing"`
+	Lag         uint64    `json:"lag"`
+	Lagging     bool      `json:"lagging"`
+	Stalled     bool      `json:"stalled"`
+	Partitioned bool      `json:"partitioned"`
+	LastBlockAt time.Time `json:"lastBlockAt"`
+}
+
+// Healthy : 응답하고, 리더와 연결되어 있으며, 블록 생성이 멈추거나 뒤처지지 않은 상태
+func (n NodeStatus) Healthy() bool {
+	return n.Up && !n.Stalled && !n.Partitioned && !n.Lagging
+}
+
+// Status : 클러스터 전체 상태
+type Status struct {
+	Healthy     bool         `json:"healthy"`
+	Leader      string       `json:"leader"`
+	BlockNumber uint64       `json:"blockNumber"`
+	Nodes       []NodeStatus `json:"nodes"`
+	CheckedAt   time.Time    `json:"checkedAt"`
+	// Stale : 조회가 멈춰 CheckedAt 이 오래된 결과 (Healthy 는 false)
+	Stale bool `json:"stale"`
+}
+
+// Unhealthy : 비정상 노드 이름 목록
+func (s Status) Unhealthy() []string {
+	names := []string{}
+	for _, node := range s.Nodes {
+		if !node.Healthy() {
+			names = append(names, node.Name)
+		}
+	}
+	return names
+}
+
+type txpoolStatus struct {
+	Pending hexutil.Uint64 `json:"pending"`
+	Queued  hexutil.Uint64 `json:"queued"`
+}
+
+type node struct {
+	config NodeConfig
+	rpc    *rpc.Client
+	eth    *ethclient.Client
+	raft   *quorum.RaftClient
+
+	// 블록 높이 변화 추적 (stall 판정)
+	lastBlock   uint64
+	lastBlockAt time.Time
+}
+
+// Monitor : 설정된 노드들을 주기적으로 조회하여 상태 판정
+type Monitor struct {
+	conf  Config
+	nodes []*node
+	now   func() time.Time
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// New : 설정된 모든 노드에 연결
+func New(conf Config) (*Monitor, error) {
+	if len(conf.Nodes) == 0 {
+		return nil, errors.New("no nodes to monitor")
+	}
+
+	m := &Monitor{conf: conf, now: time.Now}
+	for _, nodeConfig := range conf.Nodes {
+		cli, err := rpc.Dial(nodeConfig.Endpoint)
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+		m.nodes = append(m.nodes, &node{
+			config: nodeConfig,
+			rpc:    cli,
+			eth:    ethclient.NewClient(cli),
+			raft:   quorum.NewRaftClient(cli),
+		})
+	}
+	return m, nil
+}
+
+// Close : 모든 노드 연결 종료
+func (m *Monitor) Close() {
+	for _, node := range m.nodes {
+		node.rpc.Close()
+	}
+}
+
+// Start : ctx가 종료될 때까지 IntervalSec 주기로 Poll
+func (m *Monitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.interval())
+	defer ticker.Stop()
+
+	m.Poll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Poll(ctx)
+		}
+	}
+}
+
+func (m *Monitor) interval() time.Duration {
+	if m.conf.IntervalSec == 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(m.conf.IntervalSec) * time.Second
+}
+
+func (m *Monitor) timeout() time.Duration {
+	if m.conf.TimeoutSec == 0 {
+		return m.interval()
+	}
+	return time.Duration(m.conf.TimeoutSec) * time.Second
+}
+
+// Status : 마지막 Poll 결과, 조회 주기의 maxStalePolls 배수보다 오래되었으면 Stale 이고 비정상
+func (m *Monitor) Status() Status {
+	m.mu.RLock()
+	status := m.status
+	m.mu.RUnlock()
+	if !status.CheckedAt.IsZero() && m.now().Sub(status.CheckedAt) > maxStalePolls*m.interval() {
+		status.Stale = true
+		status.Healthy = false
+	}
+	return status
+}
+
+// Poll : 모든 노드를 한 번 조회하고 리더 기준으로 lag, stall, partition 판정
+func (m *Monitor) Poll(ctx context.Context) Status {
+	now := m.now()
+	statuses := make([]NodeStatus, len(m.nodes))
+
+	var wg sync.WaitGroup
+	for i := range m.nodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// 응답하지 않는 노드 하나가 전체 조회를 멈추지 않도록 노드마다 제한 시간
+			nodeCtx, cancel := context.WithTimeout(ctx, m.timeout())
+			defer cancel()
+			statuses[i] = m.pollNode(nodeCtx, m.nodes[i], now)
+		}(i)
+	}
+	wg.Wait()
+
+	status := Status{Nodes: statuses, CheckedAt: now}
+
+	// 리더(minter)의 높이를 기준으로 lag 계산, 리더가 응답하지 않으면 가장 높은 블록 기준
+	var leaderFound bool
+	for _, node := range statuses {
+		if !node.Up {
+			continue
+		}
+		if node.Role == quorum.RoleMinter {
+			status.BlockNumber = node.BlockNumber
+			leaderFound = true
+			break
+		}
+		if node.BlockNumber > status.BlockNumber {
+			status.BlockNumber = node.BlockNumber
+		}
+	}
+	status.Leader = majorityLeader(statuses)
+
+	status.Healthy = leaderFound
+	for i := range statuses {
+		node := &statuses[i]
+		if node.Up && status.BlockNumber > node.BlockNumber {
+			node.Lag = status.BlockNumber - node.BlockNumber
+			node.Lagging = node.Lag > m.conf.MaxBlockLag
+		}
+		// 다른 노드와 연결이 없거나, 다른 노드들과 다른 리더를 보고 있으면 분리된 것으로 판정
+		if node.Up && len(statuses) > 1 {
+			node.Partitioned = node.PeerCount == 0 || node.Leader == "" || node.Leader != status.Leader
+		}
+		if !node.Healthy() {
+			status.Healthy = false
+		}
+	}
+
+	m.mu.Lock()
+	m.status = status
+	m.mu.Unlock()
+	return status
+}
+
+func (m *Monitor) pollNode(ctx context.Context, node *node, now time.Time) NodeStatus {
+	status := NodeStatus{Name: node.config.Name, Endpoint: node.config.Endpoint}
+
+	blockNumber, err := node.eth.BlockNumber(ctx)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Up = true
+	status.BlockNumber = blockNumber
+
+	// 일부 조회가 실패해도 나머지 정보는 채우고 첫 오류만 기록
+	record := func(err error) {
+		if err != nil && status.Error == "" {
+			status.Error = err.Error()
+		}
+	}
+
+	status.PeerCount, err = node.eth.PeerCount(ctx)
+	record(err)
+
+	progress, err := node.eth.SyncProgress(ctx)
+	record(err)
+	status.Syncing = progress != nil
+
+	var txpool txpoolStatus
+	record(node.rpc.CallContext(ctx, &txpool, "txpool_status"))
+	status.Pending, status.Queued = uint64(txpool.Pending), uint64(txpool.Queued)
+
+	status.Role, err = node.raft.Role(ctx)
+	record(err)
+	status.Leader, err = node.raft.Leader(ctx)
+	record(err)
+
+	// Raft는 트랜잭션이 없으면 블록을 만들지 않으므로, pending 트랜잭션이 있는데 높이가 멈춘 경우만 stall
+	if blockNumber != node.lastBlock || node.lastBlockAt.IsZero() {
+		node.lastBlock = blockNumber
+		node.lastBlockAt = now
+	}
+	status.LastBlockAt = node.lastBlockAt
+	stallTimeout := time.Duration(m.conf.StallTimeoutSec) * time.Second
+	status.Stalled = status.Pending > 0 && stallTimeout > 0 && now.Sub(node.lastBlockAt) > stallTimeout
+
+	return status
+}
+
+// majorityLeader : 응답한 노드들이 가장 많이 보고한 리더
+func majorityLeader(statuses []NodeStatus) string {
+	votes := map[string]int{}
+	var leader string
+	for _, node := range statuses {
+		if !node.Up || node.Leader == "" {
+			continue
+		}
+		votes[node.Leader]++
+		if votes[node.Leader] > votes[leader] {
+			leader = node.Leader
+		}
+	}
+	return leader
+}
@@ -0,0 +1,178 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/quorum"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubNode : eth, net, txpool, raft 네임스페이스를 흉내내는 노드
+type stubNode struct {
+	blockNumber uint64
+	peerCount   uint64
+	pending     uint64
+	role        string
+	leader      string
+	// hang : 요청이 취소될 때까지 eth_blockNumber 응답하지 않음
+	hang bool
+}
+
+type stubEth struct{ node *stubNode }
+
+func (s *stubEth) BlockNumber(ctx context.Context) hexutil.Uint64 {
+	if s.node.hang {
+		<-ctx.Done()
+	}
+	return hexutil.Uint64(s.node.blockNumber)
+}
+
+func (s *stubEth) Syncing() bool { return false }
+
+type stubNet struct{ node *stubNode }
+
+func (s *stubNet) PeerCount() hexutil.Uint64 { return hexutil.Uint64(s.node.peerCount) }
+
+type stubTxpool struct{ node *stubNode }
+
+func (s *stubTxpool) Status() map[string]hexutil.Uint64 {
+	return map[string]hexutil.Uint64{"pending": hexutil.Uint64(s.node.pending), "queued": 0}
+}
+
+type stubRaft struct{ node *stubNode }
+
+func (s *stubRaft) Role() string   { return s.node.role }
+func (s *stubRaft) Leader() string { return s.node.leader }
+
+func newStubEndpoint(t *testing.T, node *stubNode) string {
+	server := rpc.NewServer()
+	assert.Equal(t, nil, server.RegisterName("eth", &stubEth{node}))
+	assert.Equal(t, nil, server.RegisterName("net", &stubNet{node}))
+	assert.Equal(t, nil, server.RegisterName("txpool", &stubTxpool{node}))
+	assert.Equal(t, nil, server.RegisterName("raft", &stubRaft{node}))
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+var leaderID = strings.Repeat("a", 128)
+
+func newTestMonitor(t *testing.T, nodes ...*stubNode) *Monitor {
+	conf := Config{StallTimeoutSec: 30, MaxBlockLag: 5}
+	for i, node := range nodes {
+		conf.Nodes = append(conf.Nodes, NodeConfig{
+			Name:     fmt.Sprintf("node-%d", i+1),
+			Endpoint: newStubEndpoint(t, node),
+		})
+	}
+	m, err := New(conf)
+	assert.Equal(t, nil, err)
+	t.Cleanup(m.Close)
+	return m
+}
+
+func TestPoll_Healthy(t *testing.T) {
+	m := newTestMonitor(t,
+		&stubNode{blockNumber: 100, peerCount: 1, role: quorum.RoleMinter, leader: leaderID},
+		&stubNode{blockNumber: 98, peerCount: 1, role: quorum.RoleVerifier, leader: leaderID},
+	)
+
+	status := m.Poll(context.Background())
+	assert.True(t, status.Healthy)
+	assert.Equal(t, leaderID, status.Leader)
+	assert.Equal(t, uint64(100), status.BlockNumber)
+	assert.Equal(t, uint64(2), status.Nodes[1].Lag)
+	assert.Equal(t, 0, len(status.Unhealthy()))
+	assert.Equal(t, status, m.Status())
+}
+
+func TestPoll_Unhealthy(t *testing.T) {
+	lagging := &stubNode{blockNumber: 90, peerCount: 2, role: quorum.RoleVerifier, leader: leaderID}
+	partitioned := &stubNode{blockNumber: 100, peerCount: 0, role: quorum.RoleVerifier, leader: ""}
+	m := newTestMonitor(t,
+		&stubNode{blockNumber: 100, peerCount: 2, role: quorum.RoleMinter, leader: leaderID},
+		lagging,
+		partitioned,
+	)
+
+	status := m.Poll(context.Background())
+	assert.False(t, status.Healthy)
+	assert.True(t, status.Nodes[1].Lagging)
+	assert.True(t, status.Nodes[2].Partitioned)
+	assert.Equal(t, []string{"node-2", "node-3"}, status.Unhealthy())
+}
+
+func TestPoll_Stalled(t *testing.T) {
+	leader := &stubNode{blockNumber: 100, peerCount: 0, role: quorum.RoleMinter, leader: leaderID}
+	m := newTestMonitor(t, leader)
+
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	assert.True(t, m.Poll(context.Background()).Healthy)
+
+	// pending 트랜잭션이 없으면 블록이 멈춰 있어도 정상 (Raft는 빈 블록을 만들지 않음)
+	now = now.Add(time.Minute)
+	assert.True(t, m.Poll(context.Background()).Healthy)
+
+	leader.pending = 3
+	status := m.Poll(context.Background())
+	assert.False(t, status.Healthy)
+	assert.True(t, status.Nodes[0].Stalled)
+
+	leader.blockNumber = 101
+	assert.True(t, m.Poll(context.Background()).Healthy)
+}
+
+func TestPoll_Down(t *testing.T) {
+	m, err := New(Config{Nodes: []NodeConfig{{Name: "node-1", Endpoint: "http://127.0.0.1:1"}}})
+	assert.Equal(t, nil, err)
+	defer m.Close()
+
+	status := m.Poll(context.Background())
+	assert.False(t, status.Healthy)
+	assert.False(t, status.Nodes[0].Up)
+	assert.NotEqual(t, "", status.Nodes[0].Error)
+}
+
+func TestPoll_Timeout(t *testing.T) {
+	m := newTestMonitor(t,
+		&stubNode{blockNumber: 100, peerCount: 1, role: quorum.RoleMinter, leader: leaderID},
+		&stubNode{hang: true},
+	)
+	m.conf.TimeoutSec = 1
+
+	// 응답하지 않는 노드는 제한 시간 후 down, 나머지 노드는 조회됨
+	started := time.Now()
+	status := m.Poll(context.Background())
+	assert.True(t, time.Since(started) < 3*time.Second)
+	assert.True(t, status.Nodes[0].Up)
+	assert.False(t, status.Nodes[1].Up)
+	assert.Equal(t, []string{"node-2"}, status.Unhealthy())
+}
+
+func TestStatus_Stale(t *testing.T) {
+	m := newTestMonitor(t, &stubNode{blockNumber: 100, peerCount: 0, role: quorum.RoleMinter, leader: leaderID})
+	m.conf.IntervalSec = 5
+
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	assert.True(t, m.Poll(context.Background()).Healthy)
+	assert.False(t, m.Status().Stale)
+
+	// 조회가 멈추면 마지막 결과가 정상이어도 비정상
+	now = now.Add(time.Minute)
+	status := m.Status()
+	assert.True(t, status.Stale)
+	assert.False(t, status.Healthy)
+}
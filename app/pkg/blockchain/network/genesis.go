@@ -0,0 +1,128 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+)
+
+// genesis.json 필드 순서는 docker/quorum/nodes/template 과 동일하게 유지
+type genesis struct {
+	Nonce      string                    `json:"nonce"`
+	Timestamp  string                    `json:"timestamp"`
+	ExtraData  string                    `json:"extraData"`
+	GasLimit   string                    `json:"gasLimit"`
+	GasUsed    string                    `json:"gasUsed"`
+	Number     string                    `json:"number"`
+	Difficulty string                    `json:"difficulty"`
+	Coinbase   string                    `json:"coinbase"`
+	MixHash    string                    `json:"mixHash"`
+	ParentHash string                    `json:"parentHash"`
+	Config     genesisConfig             `json:"config"`
+	Alloc      map[string]genesisAccount `json:"alloc"`
+}
+
+type genesisConfig struct {
+	ChainID             int64            `json:"chainId"`
+	HomesteadBlock      int              `json:"homesteadBlock"`
+	EIP150Block         int              `json:"eip150Block"`
+	EIP150Hash          string           `json:"eip150Hash"`
+	EIP155Block         int              `json:"eip155Block"`
+	EIP158Block         int              `json:"eip158Block"`
+	ByzantiumBlock      int              `json:"byzantiumBlock"`
+	ConstantinopleBlock int              `json:"constantinopleBlock"`
+	IsQuorum            bool             `json:"isQuorum"`
+	MaxCodeSizeConfig   []maxCodeSizeCfg `json:"maxCodeSizeConfig"`
+	TxnSizeLimit        int              `json:"txnSizeLimit"`
+}
+
+type maxCodeSizeCfg struct {
+	Block int `json:"block"`
+	Size  int `json:"size"`
+}
+
+type genesisAccount struct {
+	Balance string `json:"balance"`
+}
+
+func (n *Network) genesis() ([]byte, error) {
+	alloc := map[string]genesisAccount{}
+	for _, address := range n.sortedAlloc() {
+		// 노드 계정이 Alloc에도 지정되었다면 Alloc의 잔액을 우선
+		balance := n.Spec.Alloc[address]
+		if balance == nil {
+			balance = n.Spec.AccountBalance
+		}
+		alloc[strings.ToLower(address.Hex())] = genesisAccount{Balance: balance.String()}
+	}
+	zeroHash := "0x" + strings.Repeat("0", 64)
+	return json.MarshalIndent(genesis{
+		Nonce:      "0x0",
+		Timestamp:  "0x58ee40ba",
+		ExtraData:  zeroHash,
+		GasLimit:   "0xFFFFFF",
+		GasUsed:    "0x0",
+		Number:     "0x0",
+		Difficulty: "0x1",
+		Coinbase:   "0x" + strings.Repeat("0", 40),
+		MixHash:    "0x63746963616c2062797a616e74696e65206661756c7420746f6c6572616e6365",
+		ParentHash: zeroHash,
+		Config: genesisConfig{
+			ChainID:           n.Spec.ChainID,
+			EIP150Hash:        zeroHash,
+			IsQuorum:          true,
+			MaxCodeSizeConfig: []maxCodeSizeCfg{{Block: 0, Size: 64}},
+			TxnSizeLimit:      64,
+		},
+		Alloc: alloc,
+	}, "", "  ")
+}
+
+var composeTemplate = template.Must(template.New("compose").Funcs(template.FuncMap{"hostPort": hostPort}).Parse(`version: "3.6"
+
+services:
+{{- range .Nodes }}
+  node-{{ .Num }}:
+    image: {{ $.Spec.Image }}
+    container_name: node-{{ .Num }}
+    environment:
+      - NODE_NUM={{ .Num }}
+      - HTTP_PORT={{ $.Spec.HTTPPort }}
+      - WS_PORT={{ $.Spec.WSPort }}
+      - RAFT_PORT={{ $.Spec.RaftPort }}
+      - PORT={{ $.Spec.P2PPort }}
+      - VERBOSITY=3
+    ports:
+      - "{{ hostPort $.Spec.HTTPPort .Num }}:{{ $.Spec.HTTPPort }}"
+      - "{{ hostPort $.Spec.WSPort .Num }}:{{ $.Spec.WSPort }}"
+      - "{{ hostPort $.Spec.RaftPort .Num }}:{{ $.Spec.RaftPort }}"
+      - "{{ hostPort $.Spec.P2PPort .Num }}:{{ $.Spec.P2PPort }}"
+    volumes:
+      - ./Node-{{ .Num }}/data:/root/Node-{{ .Num }}/data
+    networks:
+      quorum:
+        ipv4_address: {{ .IP }}
+{{- end }}
+
+networks:
+  quorum:
+    driver: bridge
+    ipam:
+      config:
+        - subnet: {{ .Spec.Subnet }}
+`))
+
+func (n *Network) compose() ([]byte, error) {
+	var buf bytes.Buffer
+	err := composeTemplate.Execute(&buf, n)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// hostPort : run-node.sh 와 같이 호스트 포트는 컨테이너 포트 + 노드 번호
+func hostPort(port, num int) int {
+	return port + num
+}
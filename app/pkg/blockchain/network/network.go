@@ -0,0 +1,290 @@
+package network
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// Spec : 생성할 Raft 네트워크 구성
+type Spec struct {
+	Nodes   int
+	ChainID int64
+
+	// 컨테이너 내부 포트 (모든 노드 동일), 호스트 포트는 포트 + 노드 번호로 매핑
+	HTTPPort int
+	WSPort   int
+	P2PPort  int
+	RaftPort int
+
+	// 노드 컨테이너에 고정 IP를 할당할 docker 네트워크 (노드 i → 서브넷의 10+i 번째 주소)
+	Subnet string
+	Image  string
+
+	// Alloc : genesis에서 잔액을 지급할 계정
+	Alloc map[common.Address]*big.Int
+	// NodeAccounts : 노드마다 keystore 계정을 생성하고 AccountBalance 만큼 지급
+	NodeAccounts   bool
+	AccountBalance *big.Int
+	// LightKDF : keystore 암호화의 scrypt 연산량을 줄임 (테스트 용도)
+	LightKDF bool
+
+	// Seed : 지정하면 노드 키와 계정 키를 seed로부터 결정적으로 생성하여 같은 구성을 재현
+	Seed string
+}
+
+// DefaultSpec : docker/quorum 스크립트와 같은 포트의 5 노드 네트워크
+func DefaultSpec() Spec {
+	balance, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+	return Spec{
+		Nodes:          5,
+		ChainID:        10,
+		HTTPPort:       22000,
+		WSPort:         32000,
+		P2PPort:        30300,
+		RaftPort:       63000,
+		Subnet:         "172.16.239.0/24",
+		Image:          "test-node:latest",
+		Alloc:          map[common.Address]*big.Int{},
+		NodeAccounts:   true,
+		AccountBalance: balance,
+	}
+}
+
+// Node : 생성된 노드 정보
+type Node struct {
+	Num     int
+	IP      string
+	NodeKey *ecdsa.PrivateKey
+	Enode   string
+	Account *NodeAccount
+}
+
+// NodeAccount : 노드에 함께 배포되는 keystore 계정
+type NodeAccount struct {
+	Address    common.Address
+	PrivateKey *ecdsa.PrivateKey
+	Keystore   []byte
+}
+
+// Network : 생성 결과 (파일로 쓰기 전 메모리 상태)
+type Network struct {
+	Spec    Spec
+	Nodes   []*Node
+	Genesis []byte
+	Compose []byte
+}
+
+// Generate : spec에 따라 노드 키, enode, genesis, docker-compose 생성
+func Generate(spec Spec) (*Network, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	ips, err := nodeIPs(spec.Subnet, spec.Nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+	if spec.LightKDF {
+		scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+
+	network := &Network{Spec: spec}
+	for i := 1; i <= spec.Nodes; i++ {
+		nodeKey, err := spec.generateKey("node", i)
+		if err != nil {
+			return nil, err
+		}
+
+		node := &Node{
+			Num:     i,
+			IP:      ips[i-1],
+			NodeKey: nodeKey,
+			Enode:   enodeURL(nodeKey, ips[i-1], spec.P2PPort, spec.RaftPort),
+		}
+
+		if spec.NodeAccounts {
+			accountKey, err := spec.generateKey("account", i)
+			if err != nil {
+				return nil, err
+			}
+			address := crypto.PubkeyToAddress(accountKey.PublicKey)
+			keyJSON, err := keystore.EncryptKey(&keystore.Key{
+				Id:         uuid.New(),
+				Address:    address,
+				PrivateKey: accountKey,
+			}, "", scryptN, scryptP)
+			if err != nil {
+				return nil, err
+			}
+			node.Account = &NodeAccount{Address: address, PrivateKey: accountKey, Keystore: keyJSON}
+		}
+		network.Nodes = append(network.Nodes, node)
+	}
+
+	network.Genesis, err = network.genesis()
+	if err != nil {
+		return nil, err
+	}
+	network.Compose, err = network.compose()
+	if err != nil {
+		return nil, err
+	}
+	return network, nil
+}
+
+// Enodes : raft 클러스터 초기 구성 순서의 enode 목록 (static-nodes, permissioned-nodes 공통)
+func (n *Network) Enodes() []string {
+	enodes := make([]string, len(n.Nodes))
+	for i, node := range n.Nodes {
+		enodes[i] = node.Enode
+	}
+	return enodes
+}
+
+// Write : dir 아래에 Node-N/data 디렉토리와 docker-compose.yml 생성
+// 구조는 docker/quorum/nodes/template 과 같으며 quorum-start.sh로 그대로 실행 가능
+func (n *Network) Write(dir string) error {
+	enodes, err := json.MarshalIndent(n.Enodes(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	for _, node := range n.Nodes {
+		dataDir := filepath.Join(dir, fmt.Sprintf("Node-%d", node.Num), "data")
+		files := map[string][]byte{
+			"genesis.json":            n.Genesis,
+			"static-nodes.json":       enodes,
+			"permissioned-nodes.json": enodes,
+			"nodekey":                 []byte(hex.EncodeToString(crypto.FromECDSA(node.NodeKey))),
+			"nodekey.pub":             []byte(hex.EncodeToString(crypto.FromECDSAPub(&node.NodeKey.PublicKey)[1:])),
+			"address":                 []byte(hex.EncodeToString(crypto.PubkeyToAddress(node.NodeKey.PublicKey).Bytes())),
+		}
+		if node.Account != nil {
+			files["keystore/accountAddress"] = []byte(node.Account.Address.Hex())
+			files["keystore/accountKeystore"] = node.Account.Keystore
+			files["keystore/accountPassword"] = []byte{}
+			files["keystore/accountPrivateKey"] = []byte("0x" + hex.EncodeToString(crypto.FromECDSA(node.Account.PrivateKey)))
+		}
+
+		for name, content := range files {
+			path := filepath.Join(dataDir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, content, 0600); err != nil {
+				return err
+			}
+		}
+	}
+
+	return os.WriteFile(filepath.Join(dir, "docker-compose.yml"), n.Compose, 0644)
+}
+
+func (s Spec) validate() error {
+	if s.Nodes < 1 {
+		return errors.New("at least one node is required")
+	}
+	if s.ChainID < 1 {
+		return errors.New("chain id must be positive")
+	}
+	for name, port := range map[string]int{"http": s.HTTPPort, "ws": s.WSPort, "p2p": s.P2PPort, "raft": s.RaftPort} {
+		if port < 1 || port+s.Nodes > 65535 {
+			return fmt.Errorf("invalid %s port %d", name, port)
+		}
+	}
+	if s.NodeAccounts && (s.AccountBalance == nil || s.AccountBalance.Sign() < 0) {
+		return errors.New("node account balance is required")
+	}
+	for address, balance := range s.Alloc {
+		if balance == nil || balance.Sign() < 0 {
+			return fmt.Errorf("invalid balance for %s", address.Hex())
+		}
+	}
+	return nil
+}
+
+// generateKey : Seed가 있으면 keccak256(seed, kind, index)로 결정적 생성, 없으면 무작위 생성
+func (s Spec) generateKey(kind string, index int) (*ecdsa.PrivateKey, error) {
+	if s.Seed == "" {
+		return crypto.GenerateKey()
+	}
+
+	indexBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(indexBytes, uint64(index))
+	hash := crypto.Keccak256([]byte(s.Seed), []byte(kind), indexBytes)
+	for {
+		key, err := crypto.ToECDSA(hash)
+		if err == nil {
+			return key, nil
+		}
+		// 곡선 범위를 벗어난 값(확률적으로 거의 없음)은 다시 해시
+		hash = crypto.Keccak256(hash)
+	}
+}
+
+func enodeURL(nodeKey *ecdsa.PrivateKey, ip string, p2pPort, raftPort int) string {
+	publicKey := crypto.FromECDSAPub(&nodeKey.PublicKey)[1:]
+	return fmt.Sprintf("enode://%x@%s:%d?discport=0&raftport=%d", publicKey, ip, p2pPort, raftPort)
+}
+
+// nodeIPs : 서브넷에서 노드별 고정 IP 할당 (게이트웨이 등과 겹치지 않도록 .11 부터 사용)
+func nodeIPs(subnet string, count int) ([]string, error) {
+	ip, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return nil, err
+	}
+	base := ip.Mask(ipNet.Mask).To4()
+	if base == nil {
+		return nil, errors.New("only IPv4 subnets are supported")
+	}
+
+	ips := make([]string, count)
+	for i := 0; i < count; i++ {
+		offset := binary.BigEndian.Uint32(base) + uint32(11+i)
+		next := make(net.IP, 4)
+		binary.BigEndian.PutUint32(next, offset)
+		if !ipNet.Contains(next) {
+			return nil, fmt.Errorf("subnet %s is too small for %d nodes", subnet, count)
+		}
+		ips[i] = next.String()
+	}
+	return ips, nil
+}
+
+// sortedAlloc : genesis alloc을 주소 순으로 정렬 (결정적 출력)
+func (n *Network) sortedAlloc() []common.Address {
+	alloc := map[common.Address]bool{}
+	for address := range n.Spec.Alloc {
+		alloc[address] = true
+	}
+	for _, node := range n.Nodes {
+		if node.Account != nil {
+			alloc[node.Account.Address] = true
+		}
+	}
+
+	addresses := make([]common.Address, 0, len(alloc))
+	for address := range alloc {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	return addresses
+}
@@ -0,0 +1,128 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSpec() Spec {
+	spec := DefaultSpec()
+	spec.Nodes = 3
+	spec.Seed = "tiny-blockchain-app"
+	spec.LightKDF = true
+	return spec
+}
+
+func TestGenerate(t *testing.T) {
+	prefunded := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	spec := testSpec()
+	spec.Alloc = map[common.Address]*big.Int{prefunded: big.NewInt(1000)}
+
+	network, err := Generate(spec)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(network.Nodes))
+
+	for i, node := range network.Nodes {
+		assert.Equal(t, i+1, node.Num)
+		assert.True(t, strings.HasPrefix(node.Enode, "enode://"))
+		assert.True(t, strings.HasSuffix(node.Enode, "@"+node.IP+":30300?discport=0&raftport=63000"))
+	}
+	assert.Equal(t, "172.16.239.11", network.Nodes[0].IP)
+
+	var genesis genesis
+	assert.Equal(t, nil, json.Unmarshal(network.Genesis, &genesis))
+	assert.Equal(t, int64(10), genesis.Config.ChainID)
+	assert.True(t, genesis.Config.IsQuorum)
+	assert.Equal(t, 4, len(genesis.Alloc))
+	assert.Equal(t, "1000", genesis.Alloc[strings.ToLower(prefunded.Hex())].Balance)
+	for _, node := range network.Nodes {
+		account := genesis.Alloc[strings.ToLower(node.Account.Address.Hex())]
+		assert.Equal(t, spec.AccountBalance.String(), account.Balance)
+	}
+
+	compose := string(network.Compose)
+	assert.True(t, strings.Contains(compose, "- \"22003:22000\""))
+	assert.True(t, strings.Contains(compose, "- \"63002:63000\""))
+	assert.True(t, strings.Contains(compose, "ipv4_address: 172.16.239.13"))
+	assert.True(t, strings.Contains(compose, "- subnet: 172.16.239.0/24"))
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	first, err := Generate(testSpec())
+	assert.Equal(t, nil, err)
+	second, err := Generate(testSpec())
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, first.Enodes(), second.Enodes())
+	assert.Equal(t, first.Genesis, second.Genesis)
+	assert.Equal(t, first.Nodes[0].Account.Address, second.Nodes[0].Account.Address)
+
+	other := testSpec()
+	other.Seed = "other"
+	third, err := Generate(other)
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, first.Enodes(), third.Enodes())
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	spec := testSpec()
+	spec.Nodes = 0
+	_, err := Generate(spec)
+	assert.NotEqual(t, nil, err)
+
+	spec = testSpec()
+	spec.Subnet = "10.0.0.0/29"
+	_, err = Generate(spec)
+	assert.NotEqual(t, nil, err)
+
+	spec = testSpec()
+	spec.RaftPort = 65535
+	_, err = Generate(spec)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestWrite(t *testing.T) {
+	network, err := Generate(testSpec())
+	assert.Equal(t, nil, err)
+
+	dir := t.TempDir()
+	assert.Equal(t, nil, network.Write(dir))
+
+	for _, node := range network.Nodes {
+		dataDir := filepath.Join(dir, fmt.Sprintf("Node-%d", node.Num), "data")
+
+		var staticNodes []string
+		content, err := os.ReadFile(filepath.Join(dataDir, "static-nodes.json"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, json.Unmarshal(content, &staticNodes))
+		assert.Equal(t, network.Enodes(), staticNodes)
+
+		permissioned, err := os.ReadFile(filepath.Join(dataDir, "permissioned-nodes.json"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, content, permissioned)
+
+		// enode의 공개키는 nodekey.pub 과 일치해야 함
+		pub, err := os.ReadFile(filepath.Join(dataDir, "nodekey.pub"))
+		assert.Equal(t, nil, err)
+		assert.True(t, strings.HasPrefix(node.Enode, "enode://"+string(pub)+"@"))
+
+		// 생성된 keystore는 빈 비밀번호로 복호화 가능
+		keyJSON, err := os.ReadFile(filepath.Join(dataDir, "keystore", "accountKeystore"))
+		assert.Equal(t, nil, err)
+		key, err := keystore.DecryptKey(keyJSON, "")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, node.Account.Address, key.Address)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "docker-compose.yml"))
+	assert.Equal(t, nil, err)
+}
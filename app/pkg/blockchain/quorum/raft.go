@@ -0,0 +1,111 @@
+package quorum
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Raft 노드 역할 (raft_role, raft_cluster 응답)
+const (
+	RoleMinter   = "minter"
+	RoleVerifier = "verifier"
+	RoleLearner  = "learner"
+)
+
+// ErrInvalidEnode : raft_addPeer/raft_addLearner 는 raftport가 포함된 enode URL 필요
+var ErrInvalidEnode = errors.New("enode url with raftport is required")
+
+// ClusterMember : raft_cluster 응답의 노드 정보
+type ClusterMember struct {
+	RaftID     uint16 `json:"raftId"`
+	NodeID     string `json:"nodeId"`
+	IP         string `json:"ip"`
+	P2PPort    uint16 `json:"p2pPort"`
+	RaftPort   uint16 `json:"raftPort"`
+	Hostname   string `json:"hostname"`
+	Role       string `json:"role"`
+	NodeActive bool   `json:"nodeActive"`
+}
+
+// RaftClient : Quorum 노드의 raft RPC 네임스페이스 클라이언트
+type RaftClient struct {
+	cli *rpc.Client
+}
+
+// Dial : endpoint(http, ws, ipc)의 노드에 연결
+func Dial(endpoint string) (*RaftClient, error) {
+	if endpoint == "" {
+		return nil, errors.New("no endpoint info")
+	}
+	cli, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return NewRaftClient(cli), nil
+}
+
+// NewRaftClient : 이미 연결된 rpc.Client 사용
+func NewRaftClient(cli *rpc.Client) *RaftClient {
+	return &RaftClient{cli: cli}
+}
+
+// Close : 연결 종료
+func (r *RaftClient) Close() {
+	r.cli.Close()
+}
+
+// Cluster : raft_cluster, 클러스터에 참여 중인 모든 노드
+func (r *RaftClient) Cluster(ctx context.Context) ([]ClusterMember, error) {
+	var members []ClusterMember
+	err := r.cli.CallContext(ctx, &members, "raft_cluster")
+	return members, err
+}
+
+// Role : raft_role, 연결된 노드의 역할 (minter, verifier, learner)
+func (r *RaftClient) Role(ctx context.Context) (string, error) {
+	var role string
+	err := r.cli.CallContext(ctx, &role, "raft_role")
+	return role, err
+}
+
+// Leader : raft_leader, 현재 리더 노드의 enode ID
+func (r *RaftClient) Leader(ctx context.Context) (string, error) {
+	var leader string
+	err := r.cli.CallContext(ctx, &leader, "raft_leader")
+	return leader, err
+}
+
+// AddPeer : raft_addPeer, 새 노드를 투표권이 있는 peer로 추가하고 할당된 raft ID 반환
+// 추가된 노드는 --raftjoinexisting <raftID> 로 시작해야 함
+func (r *RaftClient) AddPeer(ctx context.Context, enode string) (uint16, error) {
+	return r.addNode(ctx, "raft_addPeer", enode)
+}
+
+// AddLearner : raft_addLearner, 새 노드를 투표권이 없는 learner로 추가
+func (r *RaftClient) AddLearner(ctx context.Context, enode string) (uint16, error) {
+	return r.addNode(ctx, "raft_addLearner", enode)
+}
+
+// PromoteToPeer : raft_promoteToPeer, learner를 peer로 승격
+func (r *RaftClient) PromoteToPeer(ctx context.Context, raftID uint16) (bool, error) {
+	var promoted bool
+	err := r.cli.CallContext(ctx, &promoted, "raft_promoteToPeer", raftID)
+	return promoted, err
+}
+
+// RemovePeer : raft_removePeer, 클러스터에서 노드 제거
+func (r *RaftClient) RemovePeer(ctx context.Context, raftID uint16) error {
+	return r.cli.CallContext(ctx, nil, "raft_removePeer", raftID)
+}
+
+func (r *RaftClient) addNode(ctx context.Context, method string, enode string) (uint16, error) {
+	if !strings.HasPrefix(enode, "enode://") || !strings.Contains(enode, "raftport=") {
+		return 0, ErrInvalidEnode
+	}
+	var raftID uint16
+	err := r.cli.CallContext(ctx, &raftID, method, enode)
+	return raftID, err
+}
@@ -0,0 +1,86 @@
+package quorum
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	leaderID = strings.Repeat("a", 128)
+	peerID   = strings.Repeat("b", 128)
+	newID    = strings.Repeat("c", 128)
+)
+
+func newStubRaftClient(t *testing.T) *RaftClient {
+	server := rpc.NewServer()
+	err := server.RegisterName("raft", NewStubRaftService(
+		ClusterMember{RaftID: 1, NodeID: leaderID, IP: "172.16.239.11", P2PPort: 30300, RaftPort: 63000, Role: RoleMinter, NodeActive: true},
+		ClusterMember{RaftID: 2, NodeID: peerID, IP: "172.16.239.12", P2PPort: 30300, RaftPort: 63000, Role: RoleVerifier, NodeActive: true},
+	))
+	assert.Equal(t, nil, err)
+
+	client := NewRaftClient(rpc.DialInProc(server))
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return client
+}
+
+func TestRaftClient(t *testing.T) {
+	ctx := context.Background()
+	client := newStubRaftClient(t)
+
+	members, err := client.Cluster(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(members))
+	assert.Equal(t, uint16(63000), members[0].RaftPort)
+
+	role, err := client.Role(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, RoleMinter, role)
+
+	leader, err := client.Leader(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, leaderID, leader)
+}
+
+func TestRaftClient_Membership(t *testing.T) {
+	ctx := context.Background()
+	client := newStubRaftClient(t)
+	enode := "enode://" + newID + "@172.16.239.13:30300?discport=0&raftport=63000"
+
+	raftID, err := client.AddLearner(ctx, enode)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint16(3), raftID)
+
+	_, err = client.AddPeer(ctx, enode)
+	assert.NotEqual(t, nil, err)
+
+	promoted, err := client.PromoteToPeer(ctx, raftID)
+	assert.Equal(t, nil, err)
+	assert.True(t, promoted)
+
+	members, err := client.Cluster(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(members))
+	assert.Equal(t, RoleVerifier, members[2].Role)
+
+	assert.Equal(t, nil, client.RemovePeer(ctx, raftID))
+	assert.NotEqual(t, nil, client.RemovePeer(ctx, raftID))
+
+	members, err = client.Cluster(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(members))
+}
+
+func TestRaftClient_InvalidEnode(t *testing.T) {
+	client := newStubRaftClient(t)
+
+	_, err := client.AddPeer(context.Background(), "enode://"+newID+"@172.16.239.13:30300")
+	assert.Equal(t, ErrInvalidEnode, err)
+}
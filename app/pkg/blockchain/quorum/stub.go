@@ -0,0 +1,149 @@
+package quorum
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StubRaftService : raft 네임스페이스를 흉내내는 메모리 구현
+// 노드 없이 RaftClient와 이를 사용하는 CLI/REST를 테스트하기 위한 용도
+//
+//	server := rpc.NewServer()
+//	server.RegisterName("raft", quorum.NewStubRaftService(members...))
+type StubRaftService struct {
+	mu      sync.Mutex
+	self    uint16
+	nextID  uint16
+	members []ClusterMember
+}
+
+// NewStubRaftService : members[0]을 연결된 노드이자 리더로 사용
+func NewStubRaftService(members ...ClusterMember) *StubRaftService {
+	service := &StubRaftService{members: members}
+	for _, member := range members {
+		if member.RaftID >= service.nextID {
+			service.nextID = member.RaftID
+		}
+	}
+	service.nextID++
+	if len(members) > 0 {
+		service.self = members[0].RaftID
+	}
+	return service
+}
+
+func (s *StubRaftService) Cluster() []ClusterMember {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ClusterMember{}, s.members...)
+}
+
+func (s *StubRaftService) Role() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if index := s.find(s.self); index >= 0 {
+		return s.members[index].Role
+	}
+	return ""
+}
+
+func (s *StubRaftService) Leader() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, member := range s.members {
+		if member.Role == RoleMinter {
+			return member.NodeID, nil
+		}
+	}
+	return "", errors.New("no leader")
+}
+
+func (s *StubRaftService) AddPeer(enode string) (uint16, error) {
+	return s.add(enode, RoleVerifier)
+}
+
+func (s *StubRaftService) AddLearner(enode string) (uint16, error) {
+	return s.add(enode, RoleLearner)
+}
+
+func (s *StubRaftService) PromoteToPeer(raftID uint16) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.find(raftID)
+	if index < 0 {
+		return false, fmt.Errorf("node with raftId %d not found", raftID)
+	}
+	if s.members[index].Role != RoleLearner {
+		return false, fmt.Errorf("%s is not a learner", s.members[index].NodeID)
+	}
+	s.members[index].Role = RoleVerifier
+	return true, nil
+}
+
+func (s *StubRaftService) RemovePeer(raftID uint16) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.find(raftID)
+	if index < 0 {
+		return fmt.Errorf("node with raftId %d not found", raftID)
+	}
+	s.members = append(s.members[:index], s.members[index+1:]...)
+	return nil
+}
+
+func (s *StubRaftService) add(enode string, role string) (uint16, error) {
+	member, err := parseEnode(enode)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.members {
+		if existing.NodeID == member.NodeID {
+			return 0, errors.New("node is already part of the cluster")
+		}
+	}
+	member.RaftID = s.nextID
+	member.Role = role
+	member.NodeActive = true
+	s.nextID++
+	s.members = append(s.members, member)
+	return member.RaftID, nil
+}
+
+func (s *StubRaftService) find(raftID uint16) int {
+	for i, member := range s.members {
+		if member.RaftID == raftID {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseEnode : enode://<id>@<ip>:<port>?discport=0&raftport=<raftport>
+func parseEnode(enode string) (ClusterMember, error) {
+	parsed, err := url.Parse(enode)
+	if err != nil || parsed.Scheme != "enode" || parsed.User == nil {
+		return ClusterMember{}, ErrInvalidEnode
+	}
+	p2pPort, err := strconv.ParseUint(parsed.Port(), 10, 16)
+	if err != nil {
+		return ClusterMember{}, ErrInvalidEnode
+	}
+	raftPort, err := strconv.ParseUint(parsed.Query().Get("raftport"), 10, 16)
+	if err != nil {
+		return ClusterMember{}, ErrInvalidEnode
+	}
+	return ClusterMember{
+		NodeID:   strings.ToLower(parsed.User.Username()),
+		IP:       parsed.Hostname(),
+		Hostname: parsed.Hostname(),
+		P2PPort:  uint16(p2pPort),
+		RaftPort: uint16(raftPort),
+	}, nil
+}
@@ -0,0 +1,83 @@
+package simulated
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ethService : 이벤트 조회/구독과 컨트랙트 조회에 필요한 eth 네임스페이스
+// (eth_chainId, eth_blockNumber, eth_call, eth_getLogs, eth_subscribe("logs"))
+type ethService struct {
+	backend *Backend
+}
+
+func (s *ethService) ChainId(ctx context.Context) (*hexutil.Big, error) {
+	chainID, err := s.backend.ChainID(ctx)
+	return (*hexutil.Big)(chainID), err
+}
+
+func (s *ethService) BlockNumber(ctx context.Context) (hexutil.Uint64, error) {
+	number, err := s.backend.BlockNumber(ctx)
+	return hexutil.Uint64(number), err
+}
+
+// callArgs : eth_call 요청 (from, to, gas, data 만 사용)
+type callArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Gas   hexutil.Uint64  `json:"gas"`
+	Data  hexutil.Bytes   `json:"data"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// Call : 블록 지정은 무시하고 최신 블록 기준으로 실행
+func (s *ethService) Call(ctx context.Context, args callArgs, block *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	data := args.Input
+	if data == nil {
+		data = args.Data
+	}
+	return s.backend.CallContract(ctx, ethereum.CallMsg{From: args.From, To: args.To, Gas: uint64(args.Gas), Data: data}, nil)
+}
+
+func (s *ethService) GetLogs(ctx context.Context, crit filters.FilterCriteria) ([]types.Log, error) {
+	logs, err := s.backend.FilterLogs(ctx, ethereum.FilterQuery(crit))
+	if logs == nil {
+		logs = []types.Log{}
+	}
+	return logs, err
+}
+
+func (s *ethService) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	logs := make(chan types.Log)
+	sub, err := s.backend.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery(crit), logs)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case vLog := <-logs:
+				notifier.Notify(rpcSub.ID, &vLog)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
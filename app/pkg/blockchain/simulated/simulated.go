@@ -0,0 +1,287 @@
+// simulated : 노드 없이 컨트랙트, 이벤트 패키지를 테스트하기 위한 인메모리 체인
+//
+// go-ethereum SimulatedBackend 위에 ChainID, BlockNumber 와 자동 블록 생성을 더해
+// backend.Backend 로 그대로 사용할 수 있으며,
+// RPCClient 는 같은 체인을 in-process JSON-RPC(구독 지원)로 노출하여 ethclient 경로까지 검증
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/wallet"
+	smartcontract "tiny-blockchain-app/smartcontract/golang"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// GasLimit : 블록 gas 한도 (contract 패키지의 트랜잭션 gas 한도 12,500,000 보다 커야 함)
+const GasLimit = 30000000
+
+// DefaultBalance : 생성 시 계정마다 지급하는 잔액 (1,000,000 ETH)
+var DefaultBalance = new(big.Int).Mul(big.NewInt(1000000), big.NewInt(params.Ether))
+
+var _ backend.Backend = (*Backend)(nil)
+
+// Backend : 트랜잭션을 받으면 바로 블록을 만드는 (raft 와 같은) 시뮬레이션 체인
+type Backend struct {
+	*backends.SimulatedBackend
+
+	// Accounts : genesis 에서 DefaultBalance 를 지급받은 계정, Accounts[0] 이 기본 배포 계정
+	Accounts []*wallet.KeyPair
+
+	mu         sync.Mutex
+	autoCommit bool
+	// pool : SetTxPool(true) 이면 블록에 포함되기 전까지 트랜잭션을 보관
+	pool      txPool
+	rpcServer *rpc.Server
+}
+
+// New : accounts 개의 계정에 잔액을 지급한 체인 생성
+func New(accounts int) (*Backend, error) {
+	if accounts < 1 {
+		return nil, errors.New("at least one account is required")
+	}
+
+	alloc := core.GenesisAlloc{}
+	keyPairs := make([]*wallet.KeyPair, accounts)
+	for i := range keyPairs {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		keyPairs[i] = newKeyPair(key)
+		alloc[keyPairs[i].PublicKey] = core.GenesisAccount{Balance: DefaultBalance}
+	}
+
+	return &Backend{
+		SimulatedBackend: backends.NewSimulatedBackend(alloc, GasLimit),
+		Accounts:         keyPairs,
+		autoCommit:       true,
+	}, nil
+}
+
+func newKeyPair(key *ecdsa.PrivateKey) *wallet.KeyPair {
+	return &wallet.KeyPair{PublicKey: crypto.PubkeyToAddress(key.PublicKey), PrivateKey: key}
+}
+
+// SetAutoCommit : false 이면 Commit 을 호출할 때까지 트랜잭션이 pending 으로 남음
+func (b *Backend) SetAutoCommit(autoCommit bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.autoCommit = autoCommit
+}
+
+// ChainID : SimulatedBackend genesis(params.AllEthashProtocolChanges)의 chain id
+func (b *Backend) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(params.AllEthashProtocolChanges.ChainID), nil
+}
+
+// BlockNumber : 최신 블록 번호
+func (b *Backend) BlockNumber(ctx context.Context) (uint64, error) {
+	return b.Blockchain().CurrentBlock().NumberU64(), nil
+}
+
+// SendTransaction : 자동 블록 생성이 켜져 있으면 전송 후 바로 블록 생성
+func (b *Backend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pool != nil {
+		if err := b.addToPool(ctx, tx); err != nil {
+			return err
+		}
+		if b.autoCommit {
+			b.promote(ctx)
+			b.SimulatedBackend.Commit()
+		}
+		return nil
+	}
+	if err := b.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	if b.autoCommit {
+		b.SimulatedBackend.Commit()
+	}
+	return nil
+}
+
+// Commit : pending 트랜잭션으로 블록 생성
+func (b *Backend) Commit() common.Hash {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.promote(context.Background())
+	return b.SimulatedBackend.Commit()
+}
+
+// TransactionByHash : txpool 에 있는 트랜잭션은 pending
+func (b *Backend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	b.mu.Lock()
+	tx := b.poolTransaction(hash)
+	b.mu.Unlock()
+	if tx != nil {
+		return tx, true, nil
+	}
+	return b.SimulatedBackend.TransactionByHash(ctx, hash)
+}
+
+// PendingNonceAt : txpool 에서 nonce 가 이어지는 트랜잭션까지 포함한 다음 nonce
+func (b *Backend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	nonce, err := b.SimulatedBackend.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+	return b.poolNonce(account, nonce), nil
+}
+
+// NewAccount : 새 계정을 만들고 Accounts[0] 에서 amount 만큼 전송
+func (b *Backend) NewAccount(amount *big.Int) (*wallet.KeyPair, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	keyPair := newKeyPair(key)
+	if amount != nil && amount.Sign() > 0 {
+		if _, err := b.Fund(keyPair.PublicKey, amount); err != nil {
+			return nil, err
+		}
+	}
+	return keyPair, nil
+}
+
+// Fund : Accounts[0] 에서 to 로 amount(wei) 전송
+func (b *Backend) Fund(to common.Address, amount *big.Int) (*types.Receipt, error) {
+	ctx := context.Background()
+	from := b.Accounts[0]
+
+	nonce, err := b.PendingNonceAt(ctx, from.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := b.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainID, _ := b.ChainID(ctx)
+
+	tx, err := from.SignTx(types.NewTransaction(nonce, to, amount, params.TxGas, gasPrice, nil), chainID)
+	if err != nil {
+		return nil, err
+	}
+	return b.send(tx)
+}
+
+// DeployERC20Burnable : owner 계정으로 ERC20Burnable 배포
+func (b *Backend) DeployERC20Burnable(owner wallet.Signer, name, symbol string, decimals uint8) (common.Address, *smartcontract.ERC20Burnable, error) {
+	auth := b.TransactOpts(owner)
+	address, tx, instance, err := smartcontract.DeployERC20Burnable(auth, b, name, symbol, decimals)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if _, err := b.Wait(tx); err != nil {
+		return common.Address{}, nil, err
+	}
+	return address, instance, nil
+}
+
+// Deploy : abigen 바인딩이 없는 컨트랙트(Swap 등)를 ABI 와 bytecode 로 배포
+func (b *Backend) Deploy(owner wallet.Signer, abiJSON string, bytecode string, args ...interface{}) (common.Address, *bind.BoundContract, error) {
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	auth := b.TransactOpts(owner)
+	address, tx, contract, err := bind.DeployContract(auth, contractABI, common.FromHex(bytecode), b, args...)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if _, err := b.Wait(tx); err != nil {
+		return common.Address{}, nil, err
+	}
+	return address, contract, nil
+}
+
+// MintERC20Burnable : 배포 계정으로 to 에게 amount 만큼 mint
+func (b *Backend) MintERC20Burnable(owner wallet.Signer, token common.Address, to common.Address, amount *big.Int) error {
+	instance, err := smartcontract.NewERC20Burnable(token, b)
+	if err != nil {
+		return err
+	}
+	auth := b.TransactOpts(owner)
+	tx, err := instance.Mint(auth, to, amount)
+	if err != nil {
+		return err
+	}
+	_, err = b.Wait(tx)
+	return err
+}
+
+// Handler : 같은 체인의 eth, txpool 네임스페이스 JSON-RPC 서버 (httptest 로 http endpoint 를 만들 때 사용)
+func (b *Backend) Handler() *rpc.Server {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rpcServer == nil {
+		b.rpcServer = rpc.NewServer()
+		b.rpcServer.RegisterName("eth", &ethService{backend: b})
+		b.rpcServer.RegisterName("txpool", &txPoolService{backend: b})
+	}
+	return b.rpcServer
+}
+
+// RPCClient : 같은 체인을 in-process JSON-RPC 로 연결한 client (eth_subscribe 로그 구독, batch 요청, txpool_content 지원)
+func (b *Backend) RPCClient() *backend.Client {
+	return backend.NewClient(rpc.DialInProc(b.Handler()))
+}
+
+// Close : in-process RPC 연결을 끊고 체인 종료
+func (b *Backend) Close() error {
+	b.mu.Lock()
+	if b.rpcServer != nil {
+		b.rpcServer.Stop()
+		b.rpcServer = nil
+	}
+	b.mu.Unlock()
+	return b.SimulatedBackend.Close()
+}
+
+// TransactOpts : signer 로 서명하는 트랜잭션 옵션 (abigen 바인딩 호출용)
+func (b *Backend) TransactOpts(signer wallet.Signer) *bind.TransactOpts {
+	chainID, _ := b.ChainID(context.Background())
+	return wallet.NewTransactOpts(signer, chainID)
+}
+
+func (b *Backend) send(tx *types.Transaction) (*types.Receipt, error) {
+	if err := b.SendTransaction(context.Background(), tx); err != nil {
+		return nil, err
+	}
+	return b.Wait(tx)
+}
+
+// Wait : 자동 블록 생성이 꺼져 있으면 블록을 만든 뒤 receipt 확인 (revert 되면 error)
+func (b *Backend) Wait(tx *types.Transaction) (*types.Receipt, error) {
+	if _, pending, _ := b.TransactionByHash(context.Background(), tx.Hash()); pending {
+		b.Commit()
+	}
+	receipt, err := b.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, errors.New("transaction reverted: " + tx.Hash().Hex())
+	}
+	return receipt, nil
+}
@@ -0,0 +1,163 @@
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	smartcontract "tiny-blockchain-app/smartcontract/golang"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccount(t *testing.T) {
+	backend, err := New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	amount := big.NewInt(params.Ether)
+	account, err := backend.NewAccount(amount)
+	assert.Equal(t, nil, err)
+
+	balance, err := backend.BalanceAt(context.Background(), account.PublicKey, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, amount, balance)
+
+	// 자동 블록 생성으로 전송마다 블록이 하나씩 만들어짐
+	number, err := backend.BlockNumber(context.Background())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(1), number)
+}
+
+func TestDeploy(t *testing.T) {
+	backend, err := New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	owner := backend.Accounts[0]
+	address, _, err := backend.Deploy(*owner, smartcontract.ERC20BurnableMetaData.ABI, smartcontract.ERC20BurnableMetaData.Bin, "Token", "TKN", uint8(18))
+	assert.Equal(t, nil, err)
+
+	instance, err := smartcontract.NewERC20Burnable(address, backend)
+	assert.Equal(t, nil, err)
+	symbol, err := instance.Symbol(&bind.CallOpts{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "TKN", symbol)
+
+	assert.Equal(t, nil, backend.MintERC20Burnable(*owner, address, owner.PublicKey, big.NewInt(5)))
+	balance, err := instance.BalanceOf(&bind.CallOpts{}, owner.PublicKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, big.NewInt(5), balance)
+}
+
+func TestRPCClient(t *testing.T) {
+	backend, err := New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	cli := backend.RPCClient()
+	defer cli.Close()
+
+	chainID, err := cli.ChainID(context.Background())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, params.AllEthashProtocolChanges.ChainID, chainID)
+
+	_, err = backend.NewAccount(big.NewInt(1))
+	assert.Equal(t, nil, err)
+	number, err := cli.BlockNumber(context.Background())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(1), number)
+}
+
+func TestTxPool(t *testing.T) {
+	backend, err := New(1)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+	backend.SetTxPool(true)
+	backend.SetAutoCommit(false)
+
+	ctx := context.Background()
+	signer := backend.Accounts[0]
+	chainID, _ := backend.ChainID(ctx)
+	to := common.HexToAddress("0x1000")
+	send := func(nonce uint64, price int64) (*types.Transaction, error) {
+		tx, err := signer.SignTx(types.NewTransaction(nonce, to, big.NewInt(1), params.TxGas, big.NewInt(price), nil), chainID)
+		assert.Equal(t, nil, err)
+		return tx, backend.SendTransaction(ctx, tx)
+	}
+
+	head, err := backend.HeaderByNumber(ctx, nil)
+	assert.Equal(t, nil, err)
+	price := head.BaseFee.Int64() * 2
+	first, err := send(0, price)
+	assert.Equal(t, nil, err)
+	_, err = send(2, price)
+	assert.Equal(t, nil, err)
+
+	// 10% 미만 인상은 거부
+	_, err = send(0, price+1)
+	assert.NotEqual(t, nil, err)
+	replacement, err := send(0, price*2)
+	assert.Equal(t, nil, err)
+
+	_, isPending, err := backend.TransactionByHash(ctx, replacement.Hash())
+	assert.Equal(t, nil, err)
+	assert.True(t, isPending)
+	nonce, err := backend.PendingNonceAt(ctx, signer.Address())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(1), nonce)
+
+	pool, err := backend.TxPoolContent(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(pool.Pending[signer.Address()]))
+	assert.Equal(t, 1, len(pool.Queued[signer.Address()]))
+
+	// JSON-RPC txpool_content 도 같은 내용
+	cli := backend.RPCClient()
+	defer cli.Close()
+	content, err := cli.TxPoolContent(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, replacement.Hash(), content.Pending[signer.Address()][0].Hash())
+	assert.Equal(t, uint64(2), content.Queued[signer.Address()][0].Nonce())
+
+	// nonce 1 이 비어 있으므로 nonce 2 는 Commit 해도 대기
+	backend.Commit()
+	_, err = backend.TransactionReceipt(ctx, first.Hash())
+	assert.NotEqual(t, nil, err)
+	receipt, err := backend.TransactionReceipt(ctx, replacement.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	pool, err = backend.TxPoolContent(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(pool.Pending[signer.Address()]))
+	assert.Equal(t, 1, len(pool.Queued[signer.Address()]))
+
+	_, err = send(1, price)
+	assert.Equal(t, nil, err)
+	backend.Commit()
+	pool, err = backend.TxPoolContent(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(pool.Pending)+len(pool.Queued))
+	nonce, err = backend.NonceAt(ctx, signer.Address(), nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(3), nonce)
+}
+
+func TestBumped(t *testing.T) {
+	cases := []struct {
+		old, next int64
+		expected  bool
+	}{
+		{100, 110, true},
+		{100, 109, false},
+		{0, 0, false},
+		{0, 1, true},
+		{1, 1, false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, bumped(big.NewInt(tc.old), big.NewInt(tc.next)), tc)
+	}
+}
@@ -0,0 +1,188 @@
+package simulated
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// priceBump : 같은 nonce 의 트랜잭션을 교체할 때 필요한 최소 가격 인상 비율 (geth txpool 기본값 %)
+const priceBump = 10
+
+// txPool : 계정별 nonce → 아직 블록에 포함되지 않은 트랜잭션
+type txPool map[common.Address]map[uint64]*types.Transaction
+
+// SetTxPool : true 이면 노드의 txpool 처럼 전송한 트랜잭션을 보관하여
+// 같은 nonce 의 트랜잭션 교체(가격을 10% 이상 올려야 함)와 nonce 가 비어 대기하는 트랜잭션을 재현
+// (블록은 자동 블록 생성 또는 Commit 에서 nonce 가 이어지는 트랜잭션으로 생성)
+func (b *Backend) SetTxPool(enabled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !enabled {
+		b.pool = nil
+	} else if b.pool == nil {
+		b.pool = txPool{}
+	}
+}
+
+// addToPool : b.mu 를 잡은 상태에서 호출
+func (b *Backend) addToPool(ctx context.Context, tx *types.Transaction) error {
+	chainID, _ := b.ChainID(ctx)
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return err
+	}
+	nonce, err := b.SimulatedBackend.PendingNonceAt(ctx, from)
+	if err != nil {
+		return err
+	}
+	if tx.Nonce() < nonce {
+		return errors.New("nonce too low")
+	}
+
+	if old, exist := b.pool[from][tx.Nonce()]; exist {
+		if old.Hash() == tx.Hash() {
+			return errors.New("already known")
+		}
+		if !bumped(old.GasFeeCap(), tx.GasFeeCap()) || !bumped(old.GasTipCap(), tx.GasTipCap()) {
+			return errors.New("replacement transaction underpriced")
+		}
+	}
+	if b.pool[from] == nil {
+		b.pool[from] = map[uint64]*types.Transaction{}
+	}
+	b.pool[from][tx.Nonce()] = tx
+	return nil
+}
+
+// bumped : next 가 old 보다 높고 priceBump% 이상 높은지 (geth 와 같이 0 에서 0 으로는 교체할 수 없음)
+func bumped(old, next *big.Int) bool {
+	if next.Cmp(old) <= 0 {
+		return false
+	}
+	threshold := new(big.Int).Mul(old, big.NewInt(100+priceBump))
+	return new(big.Int).Mul(next, big.NewInt(100)).Cmp(threshold) >= 0
+}
+
+// promote : nonce 가 이어지는 트랜잭션을 pending 블록으로 옮김, 실행할 수 없는 트랜잭션은 버림 (b.mu 를 잡은 상태에서 호출)
+func (b *Backend) promote(ctx context.Context) {
+	for from, txs := range b.pool {
+		nonce, _ := b.SimulatedBackend.PendingNonceAt(ctx, from)
+		for tx, exist := txs[nonce]; exist; tx, exist = txs[nonce] {
+			delete(txs, nonce)
+			if err := b.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+				break
+			}
+			nonce++
+		}
+		// 이미 사용된 nonce 의 트랜잭션은 포함될 수 없으므로 제거
+		for stale := range txs {
+			if stale < nonce {
+				delete(txs, stale)
+			}
+		}
+		if len(txs) == 0 {
+			delete(b.pool, from)
+		}
+	}
+}
+
+// TxPoolContent : txpool 의 트랜잭션, pending 은 바로 실행할 수 있는 (nonce 가 이어지는) 트랜잭션, queued 는 nonce 가 비어 대기 중인 트랜잭션
+// (SetTxPool(true) 가 아니면 비어 있음)
+func (b *Backend) TxPoolContent(ctx context.Context) (*backend.TxPool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pending := map[common.Address][]*types.Transaction{}
+	queued := map[common.Address][]*types.Transaction{}
+	for from, txs := range b.pool {
+		next, _ := b.SimulatedBackend.PendingNonceAt(ctx, from)
+		nonces := make([]uint64, 0, len(txs))
+		for nonce := range txs {
+			nonces = append(nonces, nonce)
+		}
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+		for _, nonce := range nonces {
+			if nonce == next {
+				pending[from] = append(pending[from], txs[nonce])
+				next++
+				continue
+			}
+			queued[from] = append(queued[from], txs[nonce])
+		}
+	}
+	return &backend.TxPool{Pending: pending, Queued: queued}, nil
+}
+
+// txPoolService : txpool_content (geth 와 같은 pending/queued → 계정 → nonce → 트랜잭션 형식)
+type txPoolService struct {
+	backend *Backend
+}
+
+func (s *txPoolService) Content(ctx context.Context) (map[string]map[common.Address]map[string]*types.Transaction, error) {
+	pool, err := s.backend.TxPoolContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byNonce := func(accounts map[common.Address][]*types.Transaction) map[common.Address]map[string]*types.Transaction {
+		content := make(map[common.Address]map[string]*types.Transaction, len(accounts))
+		for account, txs := range accounts {
+			content[account] = make(map[string]*types.Transaction, len(txs))
+			for _, tx := range txs {
+				content[account][strconv.FormatUint(tx.Nonce(), 10)] = tx
+			}
+		}
+		return content
+	}
+	return map[string]map[common.Address]map[string]*types.Transaction{
+		"pending": byNonce(pool.Pending),
+		"queued":  byNonce(pool.Queued),
+	}, nil
+}
+
+// DropFromPool : txpool 에서 트랜잭션 제거 (노드 재시작이나 txpool 에서 밀려난 경우 재현), 없으면 false
+func (b *Backend) DropFromPool(hash common.Hash) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for from, txs := range b.pool {
+		for nonce, tx := range txs {
+			if tx.Hash() != hash {
+				continue
+			}
+			delete(txs, nonce)
+			if len(txs) == 0 {
+				delete(b.pool, from)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// poolTransaction : txpool 에 있는 트랜잭션 (b.mu 를 잡은 상태에서 호출)
+func (b *Backend) poolTransaction(hash common.Hash) *types.Transaction {
+	for _, txs := range b.pool {
+		for _, tx := range txs {
+			if tx.Hash() == hash {
+				return tx
+			}
+		}
+	}
+	return nil
+}
+
+// poolNonce : txpool 의 트랜잭션을 포함한 다음 nonce (b.mu 를 잡은 상태에서 호출)
+func (b *Backend) poolNonce(from common.Address, nonce uint64) uint64 {
+	for {
+		if _, exist := b.pool[from][nonce]; !exist {
+			return nonce
+		}
+		nonce++
+	}
+}
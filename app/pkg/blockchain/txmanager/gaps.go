@@ -0,0 +1,183 @@
+package txmanager
+
+import (
+	"context"
+	"math/big"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/logging"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// maxRecorded : 계정별로 기록하는 전송 트랜잭션 수 (넘으면 가장 낮은 nonce 부터 삭제)
+const maxRecorded = 1024
+
+// PoolTransaction : txpool 에 있는 트랜잭션
+type PoolTransaction struct {
+	Hash  common.Hash     `json:"hash"`
+	Nonce uint64          `json:"nonce"`
+	To    *common.Address `json:"to,omitempty"`
+}
+
+// NonceReport : 계정의 채굴된 nonce, 노드의 pending nonce 와 txpool 비교
+type NonceReport struct {
+	Account common.Address `json:"account"`
+	// Nonce : 채굴된 트랜잭션 수 (다음에 채굴될 nonce)
+	Nonce uint64 `json:"nonce"`
+	// PendingNonce : txpool 의 실행 가능한 트랜잭션까지 포함한 다음 nonce
+	PendingNonce uint64 `json:"pendingNonce"`
+	// Pending : 채굴을 기다리는 실행 가능한 트랜잭션 (Nonce ~ PendingNonce-1)
+	Pending []PoolTransaction `json:"pending"`
+	// Stuck : 앞 nonce 가 비어 실행되지 않는 트랜잭션 (txpool queued)
+	Stuck []PoolTransaction `json:"stuck"`
+	// Gaps : PendingNonce 부터 Stuck 의 마지막 nonce 사이에서 txpool 에 없는 nonce
+	Gaps []uint64 `json:"gaps"`
+}
+
+// Fill : 비어 있는 nonce 에 전송한 트랜잭션
+type Fill struct {
+	Nonce uint64      `json:"nonce"`
+	Hash  common.Hash `json:"hash"`
+	// Resubmitted : 기록된 트랜잭션을 다시 전송 (false 이면 자기 자신에게 보내는 0 전송)
+	Resubmitted bool `json:"resubmitted"`
+}
+
+// Inspect : account 의 NonceAt, PendingNonceAt 과 txpool_content 로 비어 있는 nonce 와 막힌 트랜잭션 확인
+// (노드에 txpool API 가 없으면 backend.ErrTxPoolUnsupported)
+func (m *Manager) Inspect(ctx context.Context, account common.Address) (*NonceReport, error) {
+	nonce, err := m.client.NonceAt(ctx, account, nil)
+	if err != nil {
+		return nil, err
+	}
+	pendingNonce, err := m.client.PendingNonceAt(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := backend.TxPoolContent(ctx, m.client)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &NonceReport{Account: account, Nonce: nonce, PendingNonce: pendingNonce, Pending: []PoolTransaction{}, Stuck: []PoolTransaction{}, Gaps: []uint64{}}
+	for _, tx := range pool.Pending[account] {
+		report.Pending = append(report.Pending, poolTransaction(tx))
+	}
+	next := pendingNonce
+	for _, tx := range pool.Queued[account] {
+		if tx.Nonce() < next {
+			continue
+		}
+		for ; next < tx.Nonce(); next++ {
+			report.Gaps = append(report.Gaps, next)
+		}
+		report.Stuck = append(report.Stuck, poolTransaction(tx))
+		next = tx.Nonce() + 1
+	}
+	m.prune(account, nonce)
+	return report, nil
+}
+
+// RepairGaps : signer 계정의 비어 있는 nonce 를 채워 막힌 트랜잭션이 실행되도록 함
+// 기록된 트랜잭션(Backend() 로 전송, 교체 또는 Records)이 있으면 다시 전송하고, 없거나 거부되면 자기 자신에게 0 을 보내는 트랜잭션 전송
+func (m *Manager) RepairGaps(ctx context.Context, signer wallet.Signer) (*NonceReport, []Fill, error) {
+	m.replacing.Lock()
+	defer m.replacing.Unlock()
+
+	report, err := m.Inspect(ctx, signer.Address())
+	if err != nil {
+		return nil, nil, err
+	}
+	fills := []Fill{}
+	if len(report.Gaps) == 0 {
+		return report, fills, nil
+	}
+
+	chainID, err := m.client.ChainID(ctx)
+	if err != nil {
+		return report, fills, err
+	}
+	price, err := m.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return report, fills, err
+	}
+	log := logging.FromContext(ctx, logger)
+	for _, nonce := range report.Gaps {
+		if tx := m.recorded(signer.Address(), nonce); tx != nil {
+			err := m.client.SendTransaction(ctx, tx)
+			if err == nil {
+				log.Info("Resubmitted transaction", "from", signer.Address(), "nonce", nonce, "tx", tx.Hash())
+				fills = append(fills, Fill{Nonce: nonce, Hash: tx.Hash(), Resubmitted: true})
+				continue
+			}
+			log.Warn("Failed to resubmit transaction, sending no-op", "from", signer.Address(), "nonce", nonce, "tx", tx.Hash(), "err", err)
+		}
+
+		to := signer.Address()
+		noop, err := signer.SignTx(types.NewTransaction(nonce, to, new(big.Int), params.TxGas, price, nil), chainID)
+		if err != nil {
+			return report, fills, err
+		}
+		if err := m.client.SendTransaction(ctx, noop); err != nil {
+			return report, fills, err
+		}
+		m.record(signer.Address(), noop)
+		log.Info("Filled nonce gap", "from", signer.Address(), "nonce", nonce, "tx", noop.Hash())
+		fills = append(fills, Fill{Nonce: nonce, Hash: noop.Hash()})
+	}
+	return report, fills, nil
+}
+
+// record : from 의 nonce 에 마지막으로 전송한 트랜잭션
+func (m *Manager) record(from common.Address, tx *types.Transaction) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	txs := m.sent[from]
+	if txs == nil {
+		txs = map[uint64]*types.Transaction{}
+		m.sent[from] = txs
+	}
+	txs[tx.Nonce()] = tx
+	if len(txs) > maxRecorded {
+		lowest := tx.Nonce()
+		for nonce := range txs {
+			if nonce < lowest {
+				lowest = nonce
+			}
+		}
+		delete(txs, lowest)
+	}
+}
+
+// recorded : 메모리 기록, 없으면 Records 에서 조회
+func (m *Manager) recorded(from common.Address, nonce uint64) *types.Transaction {
+	m.mu.Lock()
+	tx := m.sent[from][nonce]
+	m.mu.Unlock()
+	if tx == nil && m.Records != nil {
+		tx = m.Records.Transaction(from, nonce)
+	}
+	return tx
+}
+
+// prune : 채굴된 nonce(confirmed 미만)의 기록과 교체 이력 삭제
+func (m *Manager) prune(from common.Address, confirmed uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for nonce := range m.sent[from] {
+		if nonce < confirmed {
+			delete(m.sent[from], nonce)
+		}
+	}
+	for hash, chain := range m.chains {
+		if chain.From == from && chain.Nonce < confirmed {
+			delete(m.chains, hash)
+		}
+	}
+}
+
+func poolTransaction(tx *types.Transaction) PoolTransaction {
+	return PoolTransaction{Hash: tx.Hash(), Nonce: tx.Nonce(), To: tx.To()}
+}
@@ -0,0 +1,104 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_RepairGaps(t *testing.T) {
+	sim, manager := newPoolChain(t)
+	ctx := context.Background()
+	signer := sim.Accounts[0]
+	chainID, _ := sim.ChainID(ctx)
+	price, _ := sim.SuggestGasPrice(ctx)
+	sign := func(nonce uint64) *types.Transaction {
+		tx, err := signer.SignTx(types.NewTransaction(nonce, receiver, big.NewInt(1), params.TxGas, price, nil), chainID)
+		assert.Equal(t, nil, err)
+		return tx
+	}
+
+	// nonce 0 은 채굴, 1 은 기록만 되고 노드에 없음, 3 은 어디에도 없음
+	assert.Equal(t, nil, manager.Backend().SendTransaction(ctx, sign(0)))
+	sim.Commit()
+	lost := sign(1)
+	manager.record(signer.Address(), lost)
+	for _, nonce := range []uint64{2, 4} {
+		assert.Equal(t, nil, sim.SendTransaction(ctx, sign(nonce)))
+	}
+
+	report, err := manager.Inspect(ctx, signer.Address())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(1), report.Nonce)
+	assert.Equal(t, uint64(1), report.PendingNonce)
+	assert.Equal(t, 0, len(report.Pending))
+	assert.Equal(t, []uint64{1, 3}, report.Gaps)
+	assert.Equal(t, 2, len(report.Stuck))
+	assert.Equal(t, uint64(4), report.Stuck[1].Nonce)
+	// 채굴된 nonce 의 기록은 삭제
+	assert.Equal(t, (*types.Transaction)(nil), manager.recorded(signer.Address(), 0))
+
+	_, fills, err := manager.RepairGaps(ctx, signer)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Fill{{Nonce: 1, Hash: lost.Hash(), Resubmitted: true}, {Nonce: 3, Hash: fills[1].Hash}}, fills)
+	noop, _, err := sim.TransactionByHash(ctx, fills[1].Hash)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, signer.Address(), *noop.To())
+	assert.Equal(t, 0, noop.Value().Sign())
+
+	report, err = manager.Inspect(ctx, signer.Address())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(5), report.PendingNonce)
+	assert.Equal(t, 4, len(report.Pending))
+	assert.Equal(t, 0, len(report.Gaps)+len(report.Stuck))
+
+	sim.Commit()
+	nonce, err := sim.NonceAt(ctx, signer.Address(), nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(5), nonce)
+	_, fills, err = manager.RepairGaps(ctx, signer)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(fills))
+}
+
+func TestManager_InspectRPC(t *testing.T) {
+	sim, _ := newPoolChain(t)
+	ctx := context.Background()
+	signer := sim.Accounts[0]
+	chainID, _ := sim.ChainID(ctx)
+	price, _ := sim.SuggestGasPrice(ctx)
+	tx, err := signer.SignTx(types.NewTransaction(1, receiver, big.NewInt(1), params.TxGas, price, nil), chainID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, sim.SendTransaction(ctx, tx))
+
+	// txpool_content 를 JSON-RPC 로 조회 (nonce 는 simulated.Backend 에서 조회)
+	cli := sim.RPCClient()
+	defer cli.Close()
+	manager := New(&rpcTxPool{Backend: sim, client: cli})
+	report, err := manager.Inspect(ctx, signer.Address())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []uint64{0}, report.Gaps)
+	assert.Equal(t, []PoolTransaction{{Hash: tx.Hash(), Nonce: 1, To: &receiver}}, report.Stuck)
+
+	// txpool 을 조회할 수 없는 연결
+	manager = New(struct{ backend.Backend }{sim})
+	_, err = manager.Inspect(ctx, signer.Address())
+	assert.True(t, errors.Is(err, backend.ErrTxPoolUnsupported))
+}
+
+// rpcTxPool : txpool 만 JSON-RPC 로 조회
+type rpcTxPool struct {
+	*simulated.Backend
+	client *backend.Client
+}
+
+func (b *rpcTxPool) TxPoolContent(ctx context.Context) (*backend.TxPool, error) {
+	return b.client.TxPoolContent(ctx)
+}
@@ -0,0 +1,487 @@
+// txmanager : 전송한 트랜잭션을 같은 nonce 로 교체(speed-up, cancel)하고 교체 이력을 따라 receipt 를 기다림
+//
+// txpool 에 남은 트랜잭션은 같은 nonce 의 더 높은 가격 트랜잭션으로만 바꿀 수 있으며,
+// 어느 트랜잭션이 채굴될지는 알 수 없으므로 처음 전송한 hash 로 기다려도 교체된 트랜잭션의 receipt 를 반환
+// 앞 nonce 가 빠져 txpool 에 막힌 트랜잭션은 Inspect 로 확인하고 RepairGaps 로 빈 nonce 를 채움
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/logging"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var logger = logging.New("txmanager")
+
+// DefaultPriceBump : 교체 트랜잭션의 가격 인상 비율 기본값 (geth txpool 최소값 %)
+const DefaultPriceBump = 10
+
+var (
+	// ErrNotPending : 이미 채굴되었거나 nonce 가 사용되어 교체할 수 없음
+	ErrNotPending = errors.New("transaction is not pending")
+	// ErrNotSender : 서명 계정이 트랜잭션을 보낸 계정이 아님
+	ErrNotSender = errors.New("signer is not the sender of the transaction")
+	// ErrDropped : 교체 이력에 없는 트랜잭션이 같은 nonce 를 사용함
+	ErrDropped = errors.New("nonce was used by another transaction")
+	// ErrZeroGasPrice : 가스 가격이 0 인 트랜잭션은 교체할 수 없음
+	// (txpool 은 더 높은 가격만 교체로 받고, 가스 가격이 0 인 Quorum 네트워크는 0 이 아닌 가격을 거부)
+	ErrZeroGasPrice = errors.New("cannot replace a transaction with zero gas price")
+)
+
+// Records : 계정의 nonce 로 전송한 서명 트랜잭션 조회 (없으면 nil)
+type Records interface {
+	Transaction(from common.Address, nonce uint64) *types.Transaction
+}
+
+// Chain : 같은 nonce 로 교체한 트랜잭션 목록 (처음 전송한 트랜잭션부터)
+type Chain struct {
+	From         common.Address       `json:"from"`
+	Nonce        uint64               `json:"nonce"`
+	Transactions []*types.Transaction `json:"-"`
+	// Cancelled : 마지막 교체가 자기 자신에게 보내는 0 전송
+	Cancelled bool `json:"cancelled"`
+}
+
+// Hashes : 전송 순서
+func (c *Chain) Hashes() []common.Hash {
+	hashes := make([]common.Hash, len(c.Transactions))
+	for i, tx := range c.Transactions {
+		hashes[i] = tx.Hash()
+	}
+	return hashes
+}
+
+func (c *Chain) latest() *types.Transaction {
+	return c.Transactions[len(c.Transactions)-1]
+}
+
+// Manager : 트랜잭션 교체와 교체 이력 (메모리에만 보관)
+type Manager struct {
+	client backend.Backend
+
+	// PriceBump : 이전 가격 대비 인상 비율 (%), 노드의 제안 가격이 더 높으면 제안 가격
+	PriceBump int
+	// PollInterval : WaitMined 의 receipt 조회 간격
+	PollInterval time.Duration
+	// Records : 재시작 후에도 남는 전송 기록 (journal), RepairGaps 에서 메모리 기록이 없을 때 조회 (nil 이면 사용하지 않음)
+	Records Records
+
+	replacing sync.Mutex
+	mu        sync.Mutex
+	// chains : 교체 이력 (채굴된 nonce 의 이력은 다음 교체나 Inspect 에서 삭제)
+	chains map[common.Hash]*Chain
+	// sent : Backend() 로 전송했거나 교체한 계정별 nonce 의 마지막 트랜잭션 (RepairGaps 에서 다시 전송)
+	sent map[common.Address]map[uint64]*types.Transaction
+}
+
+func New(client backend.Backend) *Manager {
+	return &Manager{
+		client:       client,
+		PriceBump:    DefaultPriceBump,
+		PollInterval: time.Second,
+		chains:       map[common.Hash]*Chain{},
+		sent:         map[common.Address]map[uint64]*types.Transaction{},
+	}
+}
+
+// Chain : hash 가 속한 교체 이력 (교체한 적이 없으면 nil)
+func (m *Manager) Chain(hash common.Hash) *Chain {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	chain, exist := m.chains[hash]
+	if !exist {
+		return nil
+	}
+	copied := *chain
+	copied.Transactions = append([]*types.Transaction(nil), chain.Transactions...)
+	return &copied
+}
+
+// Sender : hash 트랜잭션을 보낸 계정
+func (m *Manager) Sender(ctx context.Context, hash common.Hash) (common.Address, error) {
+	if chain := m.Chain(hash); chain != nil {
+		return chain.From, nil
+	}
+	tx, _, err := m.client.TransactionByHash(ctx, hash)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return sender(tx)
+}
+
+// SpeedUp : hash(교체되었으면 마지막 교체)와 같은 내용을 같은 nonce, 높은 가격으로 다시 서명하여 전송
+func (m *Manager) SpeedUp(ctx context.Context, signer wallet.Signer, hash common.Hash) (*types.Transaction, error) {
+	return m.replace(ctx, signer, hash, false)
+}
+
+// Cancel : hash 를 같은 nonce, 높은 가격의 자기 자신에게 보내는 0 전송으로 교체
+func (m *Manager) Cancel(ctx context.Context, signer wallet.Signer, hash common.Hash) (*types.Transaction, error) {
+	return m.replace(ctx, signer, hash, true)
+}
+
+func (m *Manager) replace(ctx context.Context, signer wallet.Signer, hash common.Hash, cancel bool) (*types.Transaction, error) {
+	m.replacing.Lock()
+	defer m.replacing.Unlock()
+
+	chain, err := m.lookup(ctx, hash, nil)
+	if err != nil {
+		return nil, err
+	}
+	if signer.Address() != chain.From {
+		return nil, fmt.Errorf("%w: %s sent %s", ErrNotSender, chain.From.Hex(), hash.Hex())
+	}
+
+	receipt, err := m.receipt(ctx, chain.Hashes())
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return nil, err
+	}
+	if receipt != nil {
+		return nil, fmt.Errorf("%w: %s is mined as %s", ErrNotPending, hash.Hex(), receipt.TxHash.Hex())
+	}
+	confirmed, err := m.client.NonceAt(ctx, chain.From, nil)
+	if err != nil {
+		return nil, err
+	}
+	m.prune(chain.From, confirmed)
+	if confirmed > chain.Nonce {
+		return nil, fmt.Errorf("%w: nonce %d of %s is already used", ErrNotPending, chain.Nonce, chain.From.Hex())
+	}
+
+	chainID, err := m.client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	next, err := m.bump(ctx, chain.latest(), cancel)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := signer.SignTx(next, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.client.SendTransaction(ctx, signed); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	chain.Transactions = append(chain.Transactions, signed)
+	chain.Cancelled = cancel
+	for _, tx := range chain.Transactions {
+		m.chains[tx.Hash()] = chain
+	}
+	m.mu.Unlock()
+	m.record(chain.From, signed)
+
+	action := "Sped up"
+	if cancel {
+		action = "Cancelled"
+	}
+	logging.FromContext(ctx, logger).Info(action+" transaction", "from", chain.From, "nonce", chain.Nonce,
+		"replaced", chain.Transactions[len(chain.Transactions)-2].Hash(), "tx", signed.Hash(), "gasPrice", signed.GasPrice())
+	return signed, nil
+}
+
+// bump : 가격을 올린 같은 nonce 의 트랜잭션 (cancel 이면 자기 자신에게 보내는 0 전송)
+func (m *Manager) bump(ctx context.Context, tx *types.Transaction, cancel bool) (*types.Transaction, error) {
+	from, err := sender(tx)
+	if err != nil {
+		return nil, err
+	}
+	to, value, data, gas := tx.To(), tx.Value(), tx.Data(), tx.Gas()
+	if cancel {
+		to, value, data, gas = &from, new(big.Int), nil, params.TxGas
+	}
+
+	if tx.Type() == types.DynamicFeeTxType {
+		suggested, err := m.client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tip, err := m.raise(tx.GasTipCap(), suggested)
+		if err != nil {
+			return nil, err
+		}
+		feeCap, err := m.raise(tx.GasFeeCap(), tip)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tip,
+			GasFeeCap:  feeCap,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: tx.AccessList(),
+		}), nil
+	}
+
+	suggested, err := m.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	price, err := m.raise(tx.GasPrice(), suggested)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type() == types.AccessListTxType {
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasPrice:   price,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: tx.AccessList(),
+		}), nil
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    tx.Nonce(),
+		GasPrice: price,
+		Gas:      gas,
+		To:       to,
+		Value:    value,
+		Data:     data,
+	}), nil
+}
+
+// raise : old 를 PriceBump% 올린 값(올림)과 suggested 중 큰 값
+// 둘 다 0 이면 가스 가격이 0 인 네트워크(Quorum)이므로 0 보다 높은 가격은 거부되고 같은 가격은 교체되지 않아 ErrZeroGasPrice
+func (m *Manager) raise(old, suggested *big.Int) (*big.Int, error) {
+	if old.Sign() == 0 && suggested.Sign() == 0 {
+		return nil, ErrZeroGasPrice
+	}
+	bump := m.PriceBump
+	if bump <= 0 {
+		bump = DefaultPriceBump
+	}
+	raised := new(big.Int).Mul(old, big.NewInt(int64(100+bump)))
+	raised.Add(raised, big.NewInt(99)).Div(raised, big.NewInt(100))
+	if raised.Cmp(old) <= 0 {
+		raised.Add(old, big.NewInt(1))
+	}
+	if suggested.Cmp(raised) > 0 {
+		return new(big.Int).Set(suggested), nil
+	}
+	return raised, nil
+}
+
+// receipt : hashes 중 채굴된 트랜잭션의 receipt (없으면 ethereum.NotFound)
+func (m *Manager) receipt(ctx context.Context, hashes []common.Hash) (*types.Receipt, error) {
+	for i := len(hashes) - 1; i >= 0; i-- {
+		receipt, err := m.client.TransactionReceipt(ctx, hashes[i])
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+func (m *Manager) hashes(hash common.Hash) []common.Hash {
+	if chain := m.Chain(hash); chain != nil {
+		return chain.Hashes()
+	}
+	return []common.Hash{hash}
+}
+
+// TransactionReceipt : hash 또는 그 교체 중 채굴된 트랜잭션의 receipt (receipt.TxHash 가 채굴된 hash)
+func (m *Manager) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return m.receipt(ctx, m.hashes(hash))
+}
+
+// WaitMined : hash 또는 그 교체가 채굴될 때까지 PollInterval 간격으로 조회
+// 교체 이력에 없는 트랜잭션이 nonce 를 사용하면 ErrDropped
+func (m *Manager) WaitMined(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	ticker := time.NewTicker(m.PollInterval)
+	defer ticker.Stop()
+	// 노드에서 트랜잭션이 사라지거나 교체 이력이 삭제된 뒤에도 확인할 수 있도록 마지막으로 조회한 이력을 유지
+	var known *Chain
+	for {
+		hashes := m.hashes(hash)
+		if known != nil && len(known.Transactions) > len(hashes) {
+			hashes = known.Hashes()
+		}
+		receipt, err := m.receipt(ctx, hashes)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			logging.FromContext(ctx, logger).Warn("Failed to get receipt", "tx", hash, "err", err)
+		} else if chain, err := m.lookup(ctx, hash, known); err == nil {
+			known = chain
+			if dropped, err := m.dropped(ctx, chain); err == nil && dropped {
+				return nil, fmt.Errorf("%w: %s", ErrDropped, hash.Hex())
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// lookup : hash 의 교체 이력, 교체한 적이 없으면 노드에서 조회한 트랜잭션 (노드에 없으면 known)
+func (m *Manager) lookup(ctx context.Context, hash common.Hash, known *Chain) (*Chain, error) {
+	if chain := m.Chain(hash); chain != nil {
+		return chain, nil
+	}
+	tx, _, err := m.client.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) && known != nil {
+		return known, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	from, err := sender(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &Chain{From: from, Nonce: tx.Nonce(), Transactions: []*types.Transaction{tx}}, nil
+}
+
+// dropped : nonce 가 사용되었는데 교체 이력의 어느 트랜잭션도 채굴되지 않음
+func (m *Manager) dropped(ctx context.Context, chain *Chain) (bool, error) {
+	confirmed, err := m.client.NonceAt(ctx, chain.From, nil)
+	if err != nil || confirmed <= chain.Nonce {
+		return false, err
+	}
+	// nonce 를 확인하는 사이에 채굴되었을 수 있음
+	_, err = m.receipt(ctx, chain.Hashes())
+	if errors.Is(err, ethereum.NotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+// State : 교체 이력을 포함한 트랜잭션 상태
+//
+//	pending : txpool 에 있음
+//	mined   : 성공 receipt 확인 (교체된 트랜잭션일 수 있음)
+//	failed  : revert 된 receipt 확인
+//	dropped : 교체 이력에 없는 트랜잭션이 nonce 를 사용함
+//	unknown : 노드에 없음
+type State string
+
+const (
+	StatePending State = "pending"
+	StateMined   State = "mined"
+	StateFailed  State = "failed"
+	StateDropped State = "dropped"
+	StateUnknown State = "unknown"
+)
+
+// Status : 조회한 hash 의 상태와 교체 이력
+type Status struct {
+	Hash  common.Hash `json:"hash"`
+	State State       `json:"state"`
+	// Replacements : 같은 nonce 로 전송한 트랜잭션 (처음 전송한 트랜잭션부터, 교체한 적이 없으면 비어 있음)
+	Replacements []common.Hash `json:"replacements,omitempty"`
+	Cancelled    bool          `json:"cancelled,omitempty"`
+	// MinedHash : 채굴된 트랜잭션 (hash 와 다르면 교체된 트랜잭션이 채굴됨)
+	MinedHash   *common.Hash `json:"minedHash,omitempty"`
+	BlockNumber uint64       `json:"blockNumber,omitempty"`
+}
+
+// Status : hash 와 그 교체 중 채굴된 트랜잭션, 없으면 txpool 에 남아 있는지 확인
+func (m *Manager) Status(ctx context.Context, hash common.Hash) (*Status, error) {
+	status := &Status{Hash: hash, State: StateUnknown}
+	hashes := []common.Hash{hash}
+	if chain := m.Chain(hash); chain != nil {
+		hashes = chain.Hashes()
+		status.Replacements = hashes
+		status.Cancelled = chain.Cancelled
+	}
+
+	receipt, err := m.receipt(ctx, hashes)
+	if err == nil {
+		status.State = StateMined
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			status.State = StateFailed
+		}
+		status.MinedHash = &receipt.TxHash
+		status.BlockNumber = receipt.BlockNumber.Uint64()
+		return status, nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return nil, err
+	}
+
+	chain, err := m.lookup(ctx, hash, nil)
+	if errors.Is(err, ethereum.NotFound) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	dropped, err := m.dropped(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
+	if dropped {
+		status.State = StateDropped
+		return status, nil
+	}
+	if _, pending, err := m.client.TransactionByHash(ctx, hashes[len(hashes)-1]); err == nil && pending {
+		status.State = StatePending
+	} else if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Backend : TransactionReceipt 가 교체 이력을 따라가는 client
+// (bind.WaitMined 처럼 처음 전송한 hash 로 기다리는 코드도 교체된 트랜잭션의 receipt 를 받음)
+func (m *Manager) Backend() backend.Backend {
+	return &replacementBackend{Backend: m.client, manager: m}
+}
+
+type replacementBackend struct {
+	backend.Backend
+	manager *Manager
+}
+
+func (b *replacementBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return b.manager.TransactionReceipt(ctx, hash)
+}
+
+// SendTransaction : 전송한 트랜잭션을 nonce 가 비었을 때 다시 전송할 수 있도록 기록
+func (b *replacementBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.Backend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	if from, err := sender(tx); err == nil {
+		b.manager.record(from, tx)
+	}
+	return nil
+}
+
+func (b *replacementBackend) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	return backend.BatchCall(ctx, b.Backend, batch)
+}
+
+func (b *replacementBackend) TxPoolContent(ctx context.Context) (*backend.TxPool, error) {
+	return backend.TxPoolContent(ctx, b.Backend)
+}
+
+func sender(tx *types.Transaction) (common.Address, error) {
+	return types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+}
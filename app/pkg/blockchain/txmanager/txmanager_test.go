@@ -0,0 +1,212 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+var receiver = common.HexToAddress("0x1000")
+
+// newPoolChain : 블록을 Commit 할 때만 만들고 txpool 로 트랜잭션을 보관하는 체인
+func newPoolChain(t *testing.T) (*simulated.Backend, *Manager) {
+	sim, err := simulated.New(2)
+	assert.Equal(t, nil, err)
+	t.Cleanup(func() { sim.Close() })
+	sim.SetTxPool(true)
+	sim.SetAutoCommit(false)
+
+	manager := New(sim)
+	manager.PollInterval = 10 * time.Millisecond
+	return sim, manager
+}
+
+// sendLegacy : signer 의 다음 nonce 로 receiver 에게 value 전송
+func sendLegacy(t *testing.T, sim *simulated.Backend, signer wallet.Signer, value *big.Int) *types.Transaction {
+	ctx := context.Background()
+	nonce, err := sim.PendingNonceAt(ctx, signer.Address())
+	assert.Equal(t, nil, err)
+	price, err := sim.SuggestGasPrice(ctx)
+	assert.Equal(t, nil, err)
+	chainID, _ := sim.ChainID(ctx)
+	tx, err := signer.SignTx(types.NewTransaction(nonce, receiver, value, params.TxGas, price, nil), chainID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, sim.SendTransaction(ctx, tx))
+	return tx
+}
+
+func TestManager_SpeedUp(t *testing.T) {
+	sim, manager := newPoolChain(t)
+	ctx := context.Background()
+	signer := sim.Accounts[0]
+	original := sendLegacy(t, sim, signer, big.NewInt(1))
+
+	replacement, err := manager.SpeedUp(ctx, signer, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, original.Nonce(), replacement.Nonce())
+	assert.Equal(t, original.To(), replacement.To())
+	assert.Equal(t, original.Value(), replacement.Value())
+	assert.True(t, replacement.GasPrice().Cmp(original.GasPrice()) > 0)
+
+	// 교체한 트랜잭션을 다시 교체
+	again, err := manager.SpeedUp(ctx, signer, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.True(t, again.GasPrice().Cmp(replacement.GasPrice()) > 0)
+	assert.Equal(t, []common.Hash{original.Hash(), replacement.Hash(), again.Hash()}, manager.Chain(replacement.Hash()).Hashes())
+
+	status, err := manager.Status(ctx, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StatePending, status.State)
+
+	// 처음 전송한 hash 로 기다려도 채굴된 교체의 receipt
+	mined := make(chan *types.Receipt)
+	go func() {
+		receipt, err := manager.WaitMined(ctx, original.Hash())
+		assert.Equal(t, nil, err)
+		mined <- receipt
+	}()
+	sim.Commit()
+	receipt := <-mined
+	assert.Equal(t, again.Hash(), receipt.TxHash)
+
+	receipt, err = manager.Backend().TransactionReceipt(ctx, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, again.Hash(), receipt.TxHash)
+	_, err = sim.TransactionReceipt(ctx, original.Hash())
+	assert.Equal(t, ethereum.NotFound, err)
+
+	status, err = manager.Status(ctx, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateMined, status.State)
+	assert.Equal(t, again.Hash(), *status.MinedHash)
+	assert.Equal(t, 3, len(status.Replacements))
+
+	_, err = manager.SpeedUp(ctx, signer, original.Hash())
+	assert.True(t, errors.Is(err, ErrNotPending))
+
+	// 다음 교체에서 채굴된 nonce 의 교체 이력은 삭제
+	next := sendLegacy(t, sim, signer, big.NewInt(1))
+	_, err = manager.SpeedUp(ctx, signer, next.Hash())
+	assert.Equal(t, nil, err)
+	assert.True(t, manager.Chain(original.Hash()) == nil)
+	assert.Equal(t, 2, len(manager.chains))
+}
+
+func TestManager_Cancel(t *testing.T) {
+	sim, manager := newPoolChain(t)
+	ctx := context.Background()
+	signer := sim.Accounts[0]
+	original := sendLegacy(t, sim, signer, big.NewInt(params.Ether))
+
+	// 다른 계정으로는 교체할 수 없음
+	_, err := manager.Cancel(ctx, sim.Accounts[1], original.Hash())
+	assert.True(t, errors.Is(err, ErrNotSender))
+
+	cancel, err := manager.Cancel(ctx, signer, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, signer.Address(), *cancel.To())
+	assert.Equal(t, 0, cancel.Value().Sign())
+	assert.Equal(t, params.TxGas, cancel.Gas())
+
+	sim.Commit()
+	balance, err := sim.BalanceAt(ctx, receiver, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, balance.Sign())
+
+	status, err := manager.Status(ctx, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateMined, status.State)
+	assert.True(t, status.Cancelled)
+	assert.Equal(t, cancel.Hash(), *status.MinedHash)
+}
+
+func TestManager_DynamicFee(t *testing.T) {
+	sim, manager := newPoolChain(t)
+	ctx := context.Background()
+	signer := sim.Accounts[0]
+	chainID, _ := sim.ChainID(ctx)
+	head, err := sim.HeaderByNumber(ctx, nil)
+	assert.Equal(t, nil, err)
+
+	original, err := signer.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: new(big.Int).Mul(head.BaseFee, big.NewInt(2)),
+		Gas:       params.TxGas,
+		To:        &receiver,
+		Value:     big.NewInt(1),
+	}), chainID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, sim.SendTransaction(ctx, original))
+
+	replacement, err := manager.SpeedUp(ctx, signer, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), replacement.Type())
+	assert.Equal(t, big.NewInt(params.GWei*11/10), replacement.GasTipCap())
+	assert.True(t, replacement.GasFeeCap().Cmp(original.GasFeeCap()) > 0)
+
+	sim.Commit()
+	receipt, err := manager.WaitMined(ctx, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, replacement.Hash(), receipt.TxHash)
+}
+
+func TestManager_Dropped(t *testing.T) {
+	sim, manager := newPoolChain(t)
+	ctx := context.Background()
+	signer := sim.Accounts[0]
+	original := sendLegacy(t, sim, signer, big.NewInt(1))
+	replacement, err := manager.SpeedUp(ctx, signer, original.Hash())
+	assert.Equal(t, nil, err)
+
+	// manager 를 거치지 않고 같은 nonce 를 다른 트랜잭션으로 교체
+	chainID, _ := sim.ChainID(ctx)
+	price := new(big.Int).Mul(replacement.GasPrice(), big.NewInt(2))
+	other, err := signer.SignTx(types.NewTransaction(original.Nonce(), signer.Address(), big.NewInt(0), params.TxGas, price, nil), chainID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, sim.SendTransaction(ctx, other))
+	sim.Commit()
+
+	_, err = manager.WaitMined(ctx, original.Hash())
+	assert.True(t, errors.Is(err, ErrDropped))
+	status, err := manager.Status(ctx, original.Hash())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateDropped, status.State)
+
+	// 노드에 없는 트랜잭션
+	status, err = manager.Status(ctx, common.HexToHash("0x01"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateUnknown, status.State)
+}
+
+func TestManager_Raise(t *testing.T) {
+	manager := New(nil)
+	cases := []struct {
+		old, suggested, expected int64
+	}{
+		{100, 0, 110},
+		{101, 0, 112},
+		{1, 0, 2},
+		{100, 500, 500},
+		{0, 7, 7},
+	}
+	for _, tc := range cases {
+		raised, err := manager.raise(big.NewInt(tc.old), big.NewInt(tc.suggested))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, big.NewInt(tc.expected), raised, tc)
+	}
+
+	// 가스 가격이 0 인 네트워크에서는 교체할 수 없음
+	_, err := manager.raise(new(big.Int), new(big.Int))
+	assert.True(t, errors.Is(err, ErrZeroGasPrice))
+}
@@ -0,0 +1,265 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ConvertArguments : JSON 인자 목록을 ABI 입력 타입에 맞는 Go 값으로 변환
+func ConvertArguments(inputs abi.Arguments, args []json.RawMessage) ([]interface{}, error) {
+	if len(inputs) != len(args) {
+		return nil, fmt.Errorf("argument count mismatch: expected %d, got %d", len(inputs), len(args))
+	}
+
+	converted := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		value, err := convertValue(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %d (%s): %v", i, input.Name, err)
+		}
+		converted[i] = value.Interface()
+	}
+	return converted, nil
+}
+
+// convertValue : 단일 JSON 값을 ABI 타입의 reflect.Value로 변환
+func convertValue(t abi.Type, raw json.RawMessage) (reflect.Value, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return convertInteger(t, raw)
+	case abi.BoolTy:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return reflect.Value{}, errors.New("expected boolean")
+		}
+		return reflect.ValueOf(b), nil
+	case abi.StringTy:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return reflect.Value{}, errors.New("expected string")
+		}
+		return reflect.ValueOf(s), nil
+	case abi.AddressTy:
+		s, err := unmarshalString(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !common.IsHexAddress(s) {
+			return reflect.Value{}, fmt.Errorf("invalid address %q", s)
+		}
+		return reflect.ValueOf(common.HexToAddress(s)), nil
+	case abi.HashTy:
+		b, err := unmarshalHex(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if len(b) != common.HashLength {
+			return reflect.Value{}, fmt.Errorf("expected %d bytes, got %d", common.HashLength, len(b))
+		}
+		return reflect.ValueOf(common.BytesToHash(b)), nil
+	case abi.BytesTy:
+		b, err := unmarshalHex(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(b), nil
+	case abi.FixedBytesTy, abi.FunctionTy:
+		b, err := unmarshalHex(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value := reflect.New(t.GetType()).Elem()
+		if len(b) != value.Len() {
+			return reflect.Value{}, fmt.Errorf("expected %d bytes, got %d", value.Len(), len(b))
+		}
+		reflect.Copy(value, reflect.ValueOf(b))
+		return value, nil
+	case abi.SliceTy, abi.ArrayTy:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return reflect.Value{}, errors.New("expected array")
+		}
+		var value reflect.Value
+		if t.T == abi.SliceTy {
+			value = reflect.MakeSlice(t.GetType(), len(items), len(items))
+		} else {
+			if len(items) != t.Size {
+				return reflect.Value{}, fmt.Errorf("expected %d elements, got %d", t.Size, len(items))
+			}
+			value = reflect.New(t.GetType()).Elem()
+		}
+		for i, item := range items {
+			elem, err := convertValue(*t.Elem, item)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("[%d]: %v", i, err)
+			}
+			value.Index(i).Set(elem)
+		}
+		return value, nil
+	case abi.TupleTy:
+		return convertTuple(t, raw)
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported type %s", t.String())
+}
+
+// convertInteger : 10진수 문자열, 0x 16진수 문자열, JSON 숫자를 모두 허용
+func convertInteger(t abi.Type, raw json.RawMessage) (reflect.Value, error) {
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return reflect.Value{}, errors.New("expected integer")
+		}
+		text = number.String()
+	}
+
+	n, ok := parseBigInt(text)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("invalid integer %q", text)
+	}
+
+	if t.T == abi.UintTy {
+		if n.Sign() < 0 || n.BitLen() > t.Size {
+			return reflect.Value{}, fmt.Errorf("%s out of range for %s", n, t.String())
+		}
+	} else {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return reflect.Value{}, fmt.Errorf("%s out of range for %s", n, t.String())
+		}
+	}
+
+	goType := t.GetType()
+	switch goType.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.ValueOf(n.Int64()).Convert(goType), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.ValueOf(n.Uint64()).Convert(goType), nil
+	}
+	return reflect.ValueOf(n), nil
+}
+
+// convertTuple : JSON 객체(필드 이름) 또는 배열(순서)로 tuple 구성
+func convertTuple(t abi.Type, raw json.RawMessage) (reflect.Value, error) {
+	items := make([]json.RawMessage, len(t.TupleElems))
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err == nil {
+		for i, name := range t.TupleRawNames {
+			item, exist := fields[name]
+			if !exist {
+				return reflect.Value{}, fmt.Errorf("missing tuple field %q", name)
+			}
+			items[i] = item
+		}
+	} else {
+		var list []json.RawMessage
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return reflect.Value{}, errors.New("expected object or array for tuple")
+		}
+		if len(list) != len(t.TupleElems) {
+			return reflect.Value{}, fmt.Errorf("expected %d tuple elements, got %d", len(t.TupleElems), len(list))
+		}
+		items = list
+	}
+
+	value := reflect.New(t.TupleType).Elem()
+	for i, elemType := range t.TupleElems {
+		elem, err := convertValue(*elemType, items[i])
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%s: %v", t.TupleRawNames[i], err)
+		}
+		value.Field(i).Set(elem)
+	}
+	return value, nil
+}
+
+// FormatOutput : ABI 디코딩 결과를 JSON으로 표현 가능한 값으로 변환
+// (big.Int는 10진수 문자열, bytes/address/hash는 0x 16진수 문자열)
+func FormatOutput(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		list := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			list[i] = FormatOutput(rv.Index(i).Interface())
+		}
+		return list
+	case reflect.Struct:
+		fields := make(map[string]interface{}, rv.NumField())
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			name := field.Tag.Get("json")
+			if name == "" {
+				name = field.Name
+			}
+			fields[name] = FormatOutput(rv.Field(i).Interface())
+		}
+		return fields
+	case reflect.Uint64, reflect.Int64:
+		// JSON 숫자의 정밀도 손실을 막기 위해 64비트 정수는 문자열로 표현
+		return fmt.Sprintf("%d", value)
+	}
+	return value
+}
+
+func parseBigInt(text string) (*big.Int, bool) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+		return new(big.Int).SetString(text[2:], 16)
+	}
+	if strings.HasPrefix(text, "-0x") || strings.HasPrefix(text, "-0X") {
+		n, ok := new(big.Int).SetString(text[3:], 16)
+		if !ok {
+			return nil, false
+		}
+		return n.Neg(n), true
+	}
+	return new(big.Int).SetString(text, 10)
+}
+
+func unmarshalString(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", errors.New("expected string")
+	}
+	return s, nil
+}
+
+func unmarshalHex(raw json.RawMessage) ([]byte, error) {
+	s, err := unmarshalString(raw)
+	if err != nil {
+		return nil, err
+	}
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex %q: %v", s, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,136 @@
+package contract
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var dynamicABI string = `[
+	{"name":"setValues","type":"function","stateMutability":"nonpayable","inputs":[
+		{"name":"amount","type":"uint256"},
+		{"name":"small","type":"int8"},
+		{"name":"flag","type":"bool"},
+		{"name":"owner","type":"address"},
+		{"name":"data","type":"bytes"},
+		{"name":"id","type":"bytes4"},
+		{"name":"holders","type":"address[]"},
+		{"name":"pair","type":"uint64[2]"},
+		{"name":"order","type":"tuple","components":[
+			{"name":"maker","type":"address"},
+			{"name":"amount","type":"uint256"}
+		]}
+	],"outputs":[]},
+	{"name":"getOrder","type":"function","stateMutability":"view","inputs":[],"outputs":[
+		{"name":"","type":"uint256"},
+		{"name":"order","type":"tuple","components":[
+			{"name":"maker","type":"address"},
+			{"name":"amount","type":"uint256"}
+		]}
+	]}
+]`
+
+func parseRawArgs(t *testing.T, args string) []json.RawMessage {
+	var raw []json.RawMessage
+	err := json.Unmarshal([]byte(args), &raw)
+	assert.Equal(t, nil, err)
+	return raw
+}
+
+func TestConvertArguments(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(dynamicABI))
+	assert.Equal(t, nil, err)
+
+	args := parseRawArgs(t, `[
+		"1000000000000000000000",
+		-5,
+		true,
+		"0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448",
+		"0x0102",
+		"0xa9059cbb",
+		["0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448", "0xa11c095c7262e0f1c001c397dfb288cc2c1c516b"],
+		["0x10", 32],
+		{"maker": "0xa11c095c7262e0f1c001c397dfb288cc2c1c516b", "amount": "7"}
+	]`)
+
+	params, err := ConvertArguments(contractABI.Methods["setValues"].Inputs, args)
+	assert.Equal(t, nil, err)
+
+	amount, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	assert.Equal(t, amount, params[0])
+	assert.Equal(t, int8(-5), params[1])
+	assert.Equal(t, true, params[2])
+	assert.Equal(t, common.HexToAddress("0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448"), params[3])
+	assert.Equal(t, []byte{1, 2}, params[4])
+	assert.Equal(t, [4]byte{0xa9, 0x05, 0x9c, 0xbb}, params[5])
+	assert.Equal(t, 2, len(params[6].([]common.Address)))
+	assert.Equal(t, [2]uint64{16, 32}, params[7])
+
+	// 변환된 값은 그대로 ABI 인코딩이 가능해야 함
+	_, err = contractABI.Pack("setValues", params...)
+	assert.Equal(t, nil, err)
+}
+
+func TestConvertArguments_Invalid(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(dynamicABI))
+	assert.Equal(t, nil, err)
+	inputs := contractABI.Methods["setValues"].Inputs
+
+	valid := `"0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448", "0x", "0xa9059cbb", [], [1, 2], ["0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448", 1]`
+	cases := map[string]string{
+		"count":     `["1"]`,
+		"negative":  `["-1", 0, true, ` + valid + `]`,
+		"overflow":  `["1", 128, true, ` + valid + `]`,
+		"bool":      `["1", 0, "yes", ` + valid + `]`,
+		"address":   `["1", 0, true, "0x1234", "0x", "0xa9059cbb", [], [1, 2], [1, 1]]`,
+		"fixedSize": `["1", 0, true, "0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448", "0x", "0xa9", [], [1, 2], [1, 1]]`,
+		"arraySize": `["1", 0, true, "0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448", "0x", "0xa9059cbb", [], [1], [1, 1]]`,
+		"tuple":     `["1", 0, true, "0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448", "0x", "0xa9059cbb", [], [1, 2], {"maker": "0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448"}]`,
+	}
+
+	for name, args := range cases {
+		_, err := ConvertArguments(inputs, parseRawArgs(t, args))
+		assert.NotEqual(t, nil, err, name)
+	}
+}
+
+func TestFormatOutput(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(dynamicABI))
+	assert.Equal(t, nil, err)
+
+	maker := common.HexToAddress("0xa11c095c7262e0f1c001c397dfb288cc2c1c516b")
+	encoded, err := contractABI.Methods["getOrder"].Outputs.Pack(big.NewInt(42), struct {
+		Maker  common.Address
+		Amount *big.Int
+	}{maker, big.NewInt(7)})
+	assert.Equal(t, nil, err)
+
+	values, err := contractABI.Methods["getOrder"].Outputs.Unpack(encoded)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "42", FormatOutput(values[0]))
+	assert.Equal(t, map[string]interface{}{
+		"maker":  maker.Hex(),
+		"amount": "7",
+	}, FormatOutput(values[1]))
+	assert.Equal(t, "0x0102", FormatOutput([]byte{1, 2}))
+	assert.Equal(t, []interface{}{"1", "2"}, FormatOutput([]*big.Int{big.NewInt(1), big.NewInt(2)}))
+}
+
+func TestParseABI(t *testing.T) {
+	quoted, _ := json.Marshal(dynamicABI)
+
+	fromString, err := ParseABI(quoted)
+	assert.Equal(t, nil, err)
+	fromArray, err := ParseABI(json.RawMessage(dynamicABI))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, len(fromString.Methods), len(fromArray.Methods))
+
+	_, err = ParseABI(nil)
+	assert.NotEqual(t, nil, err)
+}
@@ -3,43 +3,85 @@ package contract
 import (
 	"context"
 	"math/big"
+	"strings"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/logging"
+	"tiny-blockchain-app/app/pkg/metrics"
 	"tiny-blockchain-app/app/pkg/wallet"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type ContractResponse struct {
-	Address  common.Address
+	Address common.Address
+	// Method : 호출한 컨트랙트 함수 이름 (메트릭 label)
+	Method   string
 	Tx       *types.Transaction
 	Instance interface{}
 }
 
-func GetAuth(client *ethclient.Client, keyPair wallet.KeyPair) (*bind.TransactOpts, error) {
+// isNonceError : 다른 트랜잭션이 먼저 같은 nonce를 사용한 경우
+func isNonceError(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "nonce too low") || strings.Contains(message, "replacement transaction underpriced")
+}
 
-	nonce, err := client.PendingNonceAt(context.Background(), keyPair.PublicKey)
-	if err != nil {
-		return nil, err
+// metricLabels : 메트릭 label (등록된 컨트랙트 이름, 함수 이름)
+func (r *ContractResponse) metricLabels() (string, string) {
+	contract := "deployment"
+	if r.Address != (common.Address{}) {
+		contract = metrics.ContractLabel(r.Address.Hex())
+	}
+	method := r.Method
+	if method == "" {
+		method = "unknown"
+	}
+	return contract, method
+}
+
+// observeReceipt : 전송 수, receipt 대기 시간, 실행 결과 기록
+func observeReceipt(response *ContractResponse, sentAt time.Time, receipt *types.Receipt) {
+	contract, method := response.metricLabels()
+	metrics.TxSent.Inc(contract, method)
+	if receipt == nil {
+		return
+	}
+	metrics.TxReceiptWait.Observe(time.Since(sentAt).Seconds(), contract, method)
+	status := "success"
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		status = "reverted"
 	}
+	metrics.TxReceipts.Inc(contract, method, status)
+}
 
-	chainId, err := client.ChainID(context.Background())
+var logger = logging.New("contract")
+
+// GetAuth : ctx(요청 correlation ID 포함)로 nonce, gas price 조회 및 트랜잭션 전송
+func GetAuth(ctx context.Context, client backend.Transactor, signer wallet.Signer) (*bind.TransactOpts, error) {
+
+	nonce, err := client.PendingNonceAt(ctx, signer.Address())
 	if err != nil {
 		return nil, err
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(keyPair.PrivateKey, chainId)
+	chainId, err := client.ChainID(ctx)
 	if err != nil {
+		backend.ReleaseNonce(client, signer.Address(), nonce)
 		return nil, err
 	}
 
+	auth := wallet.NewTransactOpts(signer, chainId)
+	auth.Context = ctx
 	auth.Nonce = big.NewInt(int64(nonce))
 	auth.Value = big.NewInt(0)
 	auth.GasLimit = uint64(12500000)
 
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	gasPrice, err := client.SuggestGasPrice(ctx)
 	if err != nil {
+		backend.ReleaseNonce(client, signer.Address(), nonce)
 		return nil, err
 	}
 	// gasPrice, _ := big.NewInt(0).SetString("0", 10)
@@ -48,36 +90,68 @@ func GetAuth(client *ethclient.Client, keyPair wallet.KeyPair) (*bind.TransactOp
 	return auth, nil
 }
 
-func checkMinted(client *ethclient.Client, response *ContractResponse) (*types.Receipt, error) {
-	tx, isPending, err := client.TransactionByHash(context.Background(), response.Tx.Hash())
+// releaseAuth : GetAuth 로 받은 nonce 로 트랜잭션을 보내지 못했으면 반환 (전송을 시도한 경우는 무시됨)
+func releaseAuth(client backend.Transactor, auth *bind.TransactOpts) {
+	backend.ReleaseNonce(client, auth.From, auth.Nonce.Uint64())
+}
+
+// waitMined : 트랜잭션이 블록에 포함될 때까지 대기하며 전송/결과와 발생한 이벤트를 기록
+func waitMined(ctx context.Context, client backend.Transactor, response *ContractResponse) (*types.Receipt, error) {
+	sentAt := time.Now()
+	contract, method := response.metricLabels()
+	txLogger := logging.FromContext(ctx, logger).New("contract", contract, "method", method, "tx", response.Tx.Hash())
+	txLogger.Info("Transaction sent", "nonce", response.Tx.Nonce())
+
+	tx, isPending, err := client.TransactionByHash(ctx, response.Tx.Hash())
 	if err != nil {
+		observeReceipt(response, sentAt, nil)
+		txLogger.Error("Failed to look up transaction", "err", err)
 		return nil, err
 	}
 
-	if !isPending {
-		return nil, nil
-	}
-
-	receipt, err := bind.WaitMined(context.Background(), client, tx)
+	receipt, err := receiptOf(ctx, client, tx, isPending)
+	observeReceipt(response, sentAt, receipt)
 	if err != nil {
+		txLogger.Error("Failed to wait for receipt", "err", err)
 		return nil, err
 	}
+
+	txLogger.Info("Transaction mined", "status", receipt.Status, "block", receipt.BlockNumber, "gasUsed", receipt.GasUsed, "elapsed", time.Since(sentAt))
+	for _, eventLog := range receipt.Logs {
+		if len(eventLog.Topics) > 0 {
+			txLogger.Debug("Event emitted", "address", eventLog.Address, "topic", eventLog.Topics[0], "index", eventLog.Index)
+		}
+	}
 	return receipt, nil
 }
 
-func checkDeployed(client *ethclient.Client, response *ContractResponse) (common.Address, error) {
-	tx, isPending, err := client.TransactionByHash(context.Background(), response.Tx.Hash())
+func checkDeployed(ctx context.Context, client backend.Transactor, response *ContractResponse) (common.Address, error) {
+	sentAt := time.Now()
+	tx, isPending, err := client.TransactionByHash(ctx, response.Tx.Hash())
 	if err != nil {
+		observeReceipt(response, sentAt, nil)
 		return common.Address{}, err
 	}
 
-	if !isPending {
-		return response.Address, nil
+	receipt, err := receiptOf(ctx, client, tx, isPending)
+	observeReceipt(response, sentAt, receipt)
+	if err != nil {
+		return common.Address{}, err
 	}
-
-	address, err := bind.WaitDeployed(context.Background(), client, tx)
+	address, err := bind.WaitDeployed(ctx, client, tx)
 	if err != nil {
+		logger.Error("Failed to deploy contract", "tx", tx.Hash(), "err", err)
 		return common.Address{}, err
 	}
+	logger.Info("Contract deployed", "address", address, "tx", tx.Hash(), "method", response.Method)
 	return address, nil
 }
+
+// receiptOf : pending 이면 블록에 포함될 때까지 대기, 이미 포함되었으면 바로 receipt 조회
+// (raft 처럼 전송 직후 블록이 만들어지는 경우 TransactionByHash 시점에 이미 pending 이 아닐 수 있음)
+func receiptOf(ctx context.Context, client backend.Transactor, tx *types.Transaction, isPending bool) (*types.Receipt, error) {
+	if isPending {
+		return bind.WaitMined(ctx, client, tx)
+	}
+	return client.TransactionReceipt(ctx, tx.Hash())
+}
@@ -0,0 +1,143 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/logging"
+	"tiny-blockchain-app/app/pkg/metrics"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DynamicOutput : ABI로 디코딩된 함수 반환값
+type DynamicOutput struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// ParseABI : JSON 배열 또는 JSON 배열을 담은 문자열 형태의 ABI 파싱
+func ParseABI(raw json.RawMessage) (abi.ABI, error) {
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		text = string(raw)
+	}
+	if strings.TrimSpace(text) == "" {
+		return abi.ABI{}, fmt.Errorf("empty abi")
+	}
+	return abi.JSON(strings.NewReader(text))
+}
+
+// CallContract : 임의 컨트랙트의 method를 eth_call로 호출하고 반환값을 디코딩
+func CallContract(ctx context.Context, client backend.Transactor, contractAbi abi.ABI, contractAddress common.Address, from common.Address, method string, args []json.RawMessage) ([]DynamicOutput, error) {
+	abiMethod, exist := contractAbi.Methods[method]
+	if !exist {
+		return nil, fmt.Errorf("method %q not found in abi", method)
+	}
+
+	params, err := ConvertArguments(abiMethod.Inputs, args)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := contractAbi.Pack(method, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.CallContract(ctx, ethereum.CallMsg{
+		From: from,
+		To:   &contractAddress,
+		Data: input,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := abiMethod.Outputs.Unpack(output)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]DynamicOutput, len(values))
+	for i, value := range values {
+		name := abiMethod.Outputs[i].Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		outputs[i] = DynamicOutput{
+			Name:  name,
+			Type:  abiMethod.Outputs[i].Type.String(),
+			Value: FormatOutput(value),
+		}
+	}
+	return outputs, nil
+}
+
+// TransactContract : 임의 컨트랙트의 method를 트랜잭션으로 실행하고 receipt 반환
+// nonceErrRetryCnt : nonce 충돌로 전송이 실패했을 때 nonce를 다시 조회하여 재전송하는 횟수 (blockchain.nonceErrRetryCnt)
+func TransactContract(ctx context.Context, client backend.Transactor, signer wallet.Signer, contractAbi abi.ABI, contractAddress common.Address, method string, args []json.RawMessage, value *big.Int, nonceErrRetryCnt uint8) (*types.Transaction, *types.Receipt, error) {
+	abiMethod, exist := contractAbi.Methods[method]
+	if !exist {
+		return nil, nil, fmt.Errorf("method %q not found in abi", method)
+	}
+	if abiMethod.IsConstant() {
+		return nil, nil, fmt.Errorf("method %q is read-only, use call instead", method)
+	}
+	if value != nil && value.Sign() > 0 && !abiMethod.IsPayable() {
+		return nil, nil, fmt.Errorf("method %q is not payable", method)
+	}
+
+	params, err := ConvertArguments(abiMethod.Inputs, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instance := bind.NewBoundContract(contractAddress, contractAbi, client, client, client)
+
+	// 다른 트랜잭션이 같은 nonce를 먼저 사용했다면 nonce를 다시 조회하여 재전송
+	var tx *types.Transaction
+	for attempt := 0; ; attempt++ {
+		auth, err := GetAuth(ctx, client, signer)
+		if err != nil {
+			return nil, nil, err
+		}
+		if value != nil {
+			auth.Value = value
+		}
+
+		tx, err = instance.Transact(auth, method, params...)
+		if err == nil {
+			break
+		}
+		releaseAuth(client, auth)
+		if !isNonceError(err) || attempt >= int(nonceErrRetryCnt) {
+			return nil, nil, err
+		}
+		metrics.NonceRetries.Inc(method)
+		logging.FromContext(ctx, logger).Warn("Nonce conflict, retrying with refreshed nonce", "contract", contractAddress, "method", method, "attempt", attempt+1, "err", err)
+	}
+
+	response := &ContractResponse{
+		Address:  contractAddress,
+		Method:   method,
+		Tx:       tx,
+		Instance: instance,
+	}
+
+	receipt, err := waitMined(ctx, client, response)
+	if err != nil {
+		return tx, nil, err
+	}
+	return tx, receipt, nil
+}
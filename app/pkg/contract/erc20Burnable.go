@@ -3,13 +3,13 @@ package contract
 import (
 	"context"
 	"math/big"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
 	"tiny-blockchain-app/app/pkg/wallet"
 	smartcontract "tiny-blockchain-app/smartcontract/golang"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -19,23 +19,25 @@ type ERC20Constructor struct {
 	Decimals uint8
 }
 
-func DeployERC20Burnable(client *ethclient.Client, keyPair wallet.KeyPair, c ERC20Constructor) (*ContractResponse, error) {
-	auth, err := GetAuth(client, keyPair)
+func DeployERC20Burnable(ctx context.Context, client backend.Transactor, signer wallet.Signer, c ERC20Constructor) (*ContractResponse, error) {
+	auth, err := GetAuth(ctx, client, signer)
 	if err != nil {
 		return nil, err
 	}
 
 	_, tx, instance, err := smartcontract.DeployERC20Burnable(auth, client, c.Name, c.Symbol, c.Decimals)
 	if err != nil {
+		releaseAuth(client, auth)
 		return nil, err
 	}
 
 	response := &ContractResponse{
+		Method:   "deploy",
 		Tx:       tx,
 		Instance: instance,
 	}
 
-	address, err := checkDeployed(client, response)
+	address, err := checkDeployed(ctx, client, response)
 	if err != nil {
 		return nil, err
 	}
@@ -44,8 +46,8 @@ func DeployERC20Burnable(client *ethclient.Client, keyPair wallet.KeyPair, c ERC
 	return response, nil
 }
 
-func MintERC20Burnable(client *ethclient.Client, keyPair wallet.KeyPair, contractAddress_ string, toAddress_ string, amount_ int) (*types.Receipt, error) {
-	auth, err := GetAuth(client, keyPair)
+func MintERC20Burnable(ctx context.Context, client backend.Transactor, signer wallet.Signer, contractAddress_ string, toAddress_ string, amount_ *big.Int) (*types.Receipt, error) {
+	auth, err := GetAuth(ctx, client, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -53,22 +55,26 @@ func MintERC20Burnable(client *ethclient.Client, keyPair wallet.KeyPair, contrac
 	contractAddress := common.HexToAddress(contractAddress_)
 	instance, err := smartcontract.NewERC20Burnable(contractAddress, client)
 	if err != nil {
+		releaseAuth(client, auth)
 		return nil, err
 	}
 
 	toAddress := common.HexToAddress(toAddress_)
-	tx, err := instance.Mint(auth, toAddress, big.NewInt(int64(amount_)))
+	tx, err := instance.Mint(auth, toAddress, amount_)
 
 	if err != nil {
+		releaseAuth(client, auth)
 		return nil, err
 	}
 
 	response := &ContractResponse{
+		Address:  contractAddress,
+		Method:   "mint",
 		Tx:       tx,
 		Instance: instance,
 	}
 
-	receipt, err := checkMinted(client, response)
+	receipt, err := waitMined(ctx, client, response)
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +82,8 @@ func MintERC20Burnable(client *ethclient.Client, keyPair wallet.KeyPair, contrac
 	return receipt, nil
 }
 
-func ApproveERc20UsingABIGen(client *ethclient.Client, keyPair wallet.KeyPair, contractAddress_ string, spender common.Address, amount *big.Int) (*types.Receipt, error) {
-	auth, err := GetAuth(client, keyPair)
+func ApproveERc20UsingABIGen(ctx context.Context, client backend.Transactor, signer wallet.Signer, contractAddress_ string, spender common.Address, amount *big.Int) (*types.Receipt, error) {
+	auth, err := GetAuth(ctx, client, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -85,20 +91,24 @@ func ApproveERc20UsingABIGen(client *ethclient.Client, keyPair wallet.KeyPair, c
 	contractAddress := common.HexToAddress(contractAddress_)
 	instance, err := smartcontract.NewERC20Burnable(contractAddress, client)
 	if err != nil {
+		releaseAuth(client, auth)
 		return nil, err
 	}
 
 	tx, err := instance.Approve(auth, spender, amount)
 	if err != nil {
+		releaseAuth(client, auth)
 		return nil, err
 	}
 
 	response := &ContractResponse{
+		Address:  contractAddress,
+		Method:   "approve",
 		Tx:       tx,
 		Instance: instance,
 	}
 
-	receipt, err := checkMinted(client, response)
+	receipt, err := waitMined(ctx, client, response)
 	if err != nil {
 		return nil, err
 	}
@@ -106,8 +116,8 @@ func ApproveERc20UsingABIGen(client *ethclient.Client, keyPair wallet.KeyPair, c
 	return receipt, nil
 }
 
-func TransferERC20UsingABIGen(client *ethclient.Client, keyPair wallet.KeyPair, contractAddress_ string, toAddress_ string, amount_ int) (*types.Receipt, error) {
-	auth, err := GetAuth(client, keyPair)
+func TransferERC20UsingABIGen(ctx context.Context, client backend.Transactor, signer wallet.Signer, contractAddress_ string, toAddress_ string, amount_ *big.Int) (*types.Receipt, error) {
+	auth, err := GetAuth(ctx, client, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -115,22 +125,26 @@ func TransferERC20UsingABIGen(client *ethclient.Client, keyPair wallet.KeyPair,
 	contractAddress := common.HexToAddress(contractAddress_)
 	instance, err := smartcontract.NewERC20Burnable(contractAddress, client)
 	if err != nil {
+		releaseAuth(client, auth)
 		return nil, err
 	}
 
 	toAddress := common.HexToAddress(toAddress_)
-	tx, err := instance.Transfer(auth, toAddress, big.NewInt(int64(amount_)))
+	tx, err := instance.Transfer(auth, toAddress, amount_)
 
 	if err != nil {
+		releaseAuth(client, auth)
 		return nil, err
 	}
 
 	response := &ContractResponse{
+		Address:  contractAddress,
+		Method:   "transfer",
 		Tx:       tx,
 		Instance: instance,
 	}
 
-	receipt, err := checkMinted(client, response)
+	receipt, err := waitMined(ctx, client, response)
 	if err != nil {
 		return nil, err
 	}
@@ -138,28 +152,29 @@ func TransferERC20UsingABIGen(client *ethclient.Client, keyPair wallet.KeyPair,
 	return receipt, nil
 }
 
-func BalanceERC20Burnable(client *ethclient.Client, contractAddress_ string, address_ string) (string, error) {
+func BalanceERC20Burnable(ctx context.Context, client backend.Transactor, contractAddress_ string, address_ string) (string, error) {
 	instance, err := smartcontract.NewERC20Burnable(common.HexToAddress(contractAddress_), client)
 	if err != nil {
 		return "", err
 	}
-	balance, err := instance.BalanceOf(&bind.CallOpts{}, common.HexToAddress(address_))
+	balance, err := instance.BalanceOf(&bind.CallOpts{Context: ctx}, common.HexToAddress(address_))
 	if err != nil {
 		return "", err
 	}
 	return balance.String(), nil
 }
 
-func TransferERC20Burnable(client *ethclient.Client, keyPair wallet.KeyPair, ca string, to string, amount_ int) (*types.Receipt, error) {
+func TransferERC20Burnable(ctx context.Context, client backend.Transactor, signer wallet.Signer, ca string, to string, amount_ *big.Int) (*types.Receipt, error) {
 
-	nonce, err := client.PendingNonceAt(context.Background(), keyPair.PublicKey)
+	nonce, err := client.PendingNonceAt(ctx, signer.Address())
 	if err != nil {
 		return nil, err
 	}
 
 	value := big.NewInt(0)
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	gasPrice, err := client.SuggestGasPrice(ctx)
 	if err != nil {
+		backend.ReleaseNonce(client, signer.Address(), nonce)
 		return nil, err
 	}
 
@@ -173,17 +188,14 @@ func TransferERC20Burnable(client *ethclient.Client, keyPair wallet.KeyPair, ca
 
 	paddedAddress := common.LeftPadBytes(toAddress.Bytes(), 32)
 
-	amount := big.NewInt(int64(amount_))
-	// amount := new(big.Int)
-	// amount.SetString("10000000000000000000000", 10)
-	paddedAmount := common.LeftPadBytes(amount.Bytes(), 32)
+	paddedAmount := common.LeftPadBytes(amount_.Bytes(), 32)
 
 	var data []byte
 	data = append(data, methodId...)
 	data = append(data, paddedAddress...)
 	data = append(data, paddedAmount...)
 
-	// gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{
+	// gasLimit, err := client.EstimateGas(ctx, ethereum.CallMsg{
 	// 	To:   &toAddress,
 	// 	Data: data,
 	// })
@@ -193,32 +205,108 @@ func TransferERC20Burnable(client *ethclient.Client, keyPair wallet.KeyPair, ca
 	gasLimit := uint64(12500000)
 
 	tx := types.NewTransaction(nonce, contractAddress, value, gasLimit, gasPrice, data)
-	chainId, err := client.ChainID(context.Background())
+	chainId, err := client.ChainID(ctx)
 	if err != nil {
+		backend.ReleaseNonce(client, signer.Address(), nonce)
 		return nil, err
 	}
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainId), keyPair.PrivateKey)
+	signedTx, err := signer.SignTx(tx, chainId)
 	if err != nil {
+		backend.ReleaseNonce(client, signer.Address(), nonce)
 		return nil, err
 	}
 
-	err = client.SendTransaction(context.Background(), signedTx)
+	err = client.SendTransaction(ctx, signedTx)
 	if err != nil {
 		return nil, err
 	}
 
-	////
-	tx, isPending, err := client.TransactionByHash(context.Background(), signedTx.Hash())
+	response := &ContractResponse{
+		Address: contractAddress,
+		Method:  "transfer",
+		Tx:      signedTx,
+	}
+
+	receipt, err := waitMined(ctx, client, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+func BurnERC20Burnable(ctx context.Context, client backend.Transactor, signer wallet.Signer, contractAddress_ string, amount_ *big.Int) (*types.Receipt, error) {
+	auth, err := GetAuth(ctx, client, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddress := common.HexToAddress(contractAddress_)
+	instance, err := smartcontract.NewERC20Burnable(contractAddress, client)
+	if err != nil {
+		releaseAuth(client, auth)
+		return nil, err
+	}
+
+	tx, err := instance.Burn(auth, amount_)
 	if err != nil {
+		releaseAuth(client, auth)
 		return nil, err
 	}
 
-	if !isPending {
-		return nil, nil
+	response := &ContractResponse{
+		Address:  contractAddress,
+		Method:   "burn",
+		Tx:       tx,
+		Instance: instance,
+	}
+
+	receipt, err := waitMined(ctx, client, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// PauseERC20Burnable : pause가 true이면 pause, false이면 unPause 호출
+func PauseERC20Burnable(ctx context.Context, client backend.Transactor, signer wallet.Signer, contractAddress_ string, pause bool) (*types.Receipt, error) {
+	auth, err := GetAuth(ctx, client, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddress := common.HexToAddress(contractAddress_)
+	instance, err := smartcontract.NewERC20Burnable(contractAddress, client)
+	if err != nil {
+		releaseAuth(client, auth)
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	if pause {
+		tx, err = instance.Pause(auth)
+	} else {
+		tx, err = instance.UnPause(auth)
+	}
+	if err != nil {
+		releaseAuth(client, auth)
+		return nil, err
+	}
+
+	method := "pause"
+	if !pause {
+		method = "unPause"
+	}
+	response := &ContractResponse{
+		Address:  contractAddress,
+		Method:   method,
+		Tx:       tx,
+		Instance: instance,
 	}
 
-	receipt, err := bind.WaitMined(context.Background(), client, tx)
+	receipt, err := waitMined(ctx, client, response)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,110 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// MulticallBytecode : 여러 조회를 한 번의 eth_call 로 실행하는 컨트랙트 (ABI 없음, Constantinople 이상)
+//
+// 입력은 호출마다 [대상 주소 20바이트][데이터 길이 2바이트][데이터]를 이어 붙인 것이고,
+// 호출마다 STATICCALL 한 뒤 [32바이트 헤더][반환값]을 이어 붙여 반환
+// 헤더의 첫 바이트는 성공 여부(1/0), 나머지 31바이트는 반환값 길이 (big-endian)
+//
+//	  PUSH1 0 PUSH1 0                          ; [i, o] i: 입력 위치, o: 출력 위치
+//	loop:
+//	  JUMPDEST DUP1 CALLDATASIZE GT ISZERO PUSH2 end JUMPI
+//	  DUP1 PUSH1 20 ADD CALLDATALOAD PUSH1 240 SHR                    ; len
+//	  DUP1 DUP3 PUSH1 22 ADD DUP5 PUSH1 32 ADD CALLDATACOPY            ; mem[o+32:] = data
+//	  PUSH1 0 PUSH1 0 DUP3 DUP6 PUSH1 32 ADD DUP6 CALLDATALOAD PUSH1 96 SHR GAS STATICCALL
+//	  RETURNDATASIZE DUP5 MSTORE DUP4 MSTORE8                          ; mem[o] = 헤더
+//	  RETURNDATASIZE PUSH1 0 DUP5 PUSH1 32 ADD RETURNDATACOPY          ; mem[o+32:] = 반환값
+//	  DUP3 RETURNDATASIZE ADD PUSH1 32 ADD SWAP3 POP                   ; o += 32 + 반환값 길이
+//	  ADD PUSH1 22 ADD PUSH2 loop JUMP                                 ; i += 22 + len
+//	end:
+//	  JUMPDEST POP PUSH1 0 RETURN                                      ; return mem[0:o]
+var MulticallBytecode = hexutil.MustDecode("0x605180600b6000396000f3" +
+	"600060005b8036111561004c57806014013560f01c80826016018460200137600060008285602001853560601c5afa3d845283533d6000846020013e823d01602001925001601601610004565b506000f3")
+
+// maxMulticallData : 호출 데이터 길이는 2바이트로 전달
+const maxMulticallData = 0xffff
+
+var errMalformedMulticall = errors.New("malformed multicall output")
+
+// DeployMulticall : Reader.Multicall 에 사용할 컨트랙트 배포
+func DeployMulticall(ctx context.Context, client backend.Transactor, signer wallet.Signer) (*ContractResponse, error) {
+	auth, err := GetAuth(ctx, client, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	_, tx, instance, err := bind.DeployContract(auth, abi.ABI{}, MulticallBytecode, client)
+	if err != nil {
+		releaseAuth(client, auth)
+		return nil, err
+	}
+
+	response := &ContractResponse{
+		Method:   "deploy",
+		Tx:       tx,
+		Instance: instance,
+	}
+	address, err := checkDeployed(ctx, client, response)
+	if err != nil {
+		return nil, err
+	}
+	response.Address = address
+	return response, nil
+}
+
+// encodeMulticall : MulticallBytecode 입력 형식
+func encodeMulticall(calls []Call) ([]byte, error) {
+	size := 0
+	for _, call := range calls {
+		if len(call.Data) > maxMulticallData {
+			return nil, fmt.Errorf("multicall data to %s is %d bytes, limit is %d", call.To.Hex(), len(call.Data), maxMulticallData)
+		}
+		size += common.AddressLength + 2 + len(call.Data)
+	}
+	input := make([]byte, 0, size)
+	for _, call := range calls {
+		input = append(input, call.To.Bytes()...)
+		input = append(input, byte(len(call.Data)>>8), byte(len(call.Data)))
+		input = append(input, call.Data...)
+	}
+	return input, nil
+}
+
+// decodeMulticall : 호출 수만큼의 [헤더][반환값], 실패한 호출은 revert 데이터로 Err 설정
+func decodeMulticall(output []byte, count int) ([]CallResult, error) {
+	results := make([]CallResult, 0, count)
+	for len(results) < count {
+		if len(output) < 32 {
+			return nil, errMalformedMulticall
+		}
+		length := new(big.Int).SetBytes(output[1:32])
+		if !length.IsUint64() || length.Uint64() > uint64(len(output)-32) {
+			return nil, errMalformedMulticall
+		}
+		data := output[32 : 32+length.Uint64()]
+		result := CallResult{Data: data}
+		if output[0] != 1 {
+			result = CallResult{Err: revertError(data)}
+		}
+		results = append(results, result)
+		output = output[32+length.Uint64():]
+	}
+	if len(output) != 0 {
+		return nil, errMalformedMulticall
+	}
+	return results, nil
+}
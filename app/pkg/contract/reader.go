@@ -0,0 +1,242 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	smartcontract "tiny-blockchain-app/smartcontract/golang"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DefaultBatchSize : 한 번의 JSON-RPC batch 또는 multicall 에 묶는 호출 수 기본값
+const DefaultBatchSize = 100
+
+// ErrCallReverted : 묶어서 실행한 호출 중 하나가 revert
+var ErrCallReverted = errors.New("execution reverted")
+
+var erc20ABI abi.ABI
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(smartcontract.ERC20BurnableMetaData.ABI))
+	if err != nil {
+		panic(err)
+	}
+	erc20ABI = parsed
+}
+
+// Call : 하나의 eth_call
+type Call struct {
+	To   common.Address
+	Data []byte
+}
+
+// CallResult : 호출별 결과 (Err 가 nil 이 아니면 Data 없음)
+type CallResult struct {
+	Data []byte
+	Err  error
+}
+
+// Reader : 여러 eth_call 을 묶어서 조회
+//
+// Multicall 이 설정되어 있으면 BatchSize 개씩 Multicall 컨트랙트 호출 한 번으로,
+// 아니면 JSON-RPC batch 요청 한 번으로 보내고, batch 를 지원하지 않는 client 는 하나씩 호출
+type Reader struct {
+	client backend.Transactor
+
+	// Multicall : DeployMulticall 로 배포한 컨트랙트 주소 (0 주소이면 JSON-RPC batch)
+	Multicall common.Address
+	// BatchSize : 0 이면 DefaultBatchSize
+	BatchSize int
+}
+
+func NewReader(client backend.Transactor) *Reader {
+	return &Reader{client: client, BatchSize: DefaultBatchSize}
+}
+
+// Calls : calls 순서대로 결과 반환, revert 등 호출별 오류는 CallResult.Err (연결 오류는 error)
+func (r *Reader) Calls(ctx context.Context, calls []Call) ([]CallResult, error) {
+	size := r.BatchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	results := make([]CallResult, 0, len(calls))
+	for start := 0; start < len(calls); start += size {
+		end := start + size
+		if end > len(calls) {
+			end = len(calls)
+		}
+		chunk, err := r.calls(ctx, calls[start:end])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, chunk...)
+	}
+	return results, nil
+}
+
+func (r *Reader) calls(ctx context.Context, calls []Call) ([]CallResult, error) {
+	if r.Multicall != (common.Address{}) {
+		return r.multicall(ctx, calls)
+	}
+	results, err := r.batch(ctx, calls)
+	if errors.Is(err, backend.ErrBatchUnsupported) {
+		return r.sequential(ctx, calls)
+	}
+	return results, err
+}
+
+func (r *Reader) multicall(ctx context.Context, calls []Call) ([]CallResult, error) {
+	input, err := encodeMulticall(calls)
+	if err != nil {
+		return nil, err
+	}
+	output, err := r.client.CallContract(ctx, ethereum.CallMsg{To: &r.Multicall, Data: input}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("multicall %s: %w", r.Multicall.Hex(), err)
+	}
+	if len(output) == 0 && len(calls) > 0 {
+		return nil, fmt.Errorf("no multicall contract at %s", r.Multicall.Hex())
+	}
+	return decodeMulticall(output, len(calls))
+}
+
+func (r *Reader) batch(ctx context.Context, calls []Call) ([]CallResult, error) {
+	outputs := make([]hexutil.Bytes, len(calls))
+	elems := make([]rpc.BatchElem, len(calls))
+	for i, call := range calls {
+		elems[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{map[string]interface{}{
+				"to":   call.To,
+				"data": hexutil.Bytes(call.Data),
+			}, "latest"},
+			Result: &outputs[i],
+		}
+	}
+	if err := backend.BatchCall(ctx, r.client, elems); err != nil {
+		return nil, err
+	}
+
+	results := make([]CallResult, len(calls))
+	for i, elem := range elems {
+		if elem.Error != nil {
+			results[i] = CallResult{Err: elem.Error}
+			continue
+		}
+		results[i] = CallResult{Data: outputs[i]}
+	}
+	return results, nil
+}
+
+func (r *Reader) sequential(ctx context.Context, calls []Call) ([]CallResult, error) {
+	results := make([]CallResult, len(calls))
+	for i, call := range calls {
+		to := call.To
+		output, err := r.client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: call.Data}, nil)
+		if backend.IsConnectionError(ctx, err) {
+			return nil, err
+		}
+		results[i] = CallResult{Data: output, Err: err}
+	}
+	return results, nil
+}
+
+// BalancesOf : holders 순서대로 token 잔액
+func (r *Reader) BalancesOf(ctx context.Context, token common.Address, holders []common.Address) ([]*big.Int, error) {
+	calls := make([]Call, len(holders))
+	for i, holder := range holders {
+		data, err := erc20ABI.Pack("balanceOf", holder)
+		if err != nil {
+			return nil, err
+		}
+		calls[i] = Call{To: token, Data: data}
+	}
+	results, err := r.Calls(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]*big.Int, len(holders))
+	for i, result := range results {
+		values, err := unpackResult("balanceOf", result)
+		if err != nil {
+			return nil, fmt.Errorf("balanceOf(%s) on %s: %w", holders[i].Hex(), token.Hex(), err)
+		}
+		balances[i] = values[0].(*big.Int)
+	}
+	return balances, nil
+}
+
+// TokenInfo : ERC20 토큰 정보
+type TokenInfo struct {
+	Address     common.Address `json:"address"`
+	Name        string         `json:"name"`
+	Symbol      string         `json:"symbol"`
+	Decimals    uint8          `json:"decimals"`
+	TotalSupply *big.Int       `json:"totalSupply"`
+}
+
+// tokenInfoMethods : 토큰마다 묶어서 호출하는 함수
+var tokenInfoMethods = []string{"name", "symbol", "decimals", "totalSupply"}
+
+// TokenInfo : tokens 순서대로 name, symbol, decimals, totalSupply
+func (r *Reader) TokenInfo(ctx context.Context, tokens ...common.Address) ([]TokenInfo, error) {
+	calls := make([]Call, 0, len(tokens)*len(tokenInfoMethods))
+	for _, token := range tokens {
+		for _, method := range tokenInfoMethods {
+			data, err := erc20ABI.Pack(method)
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, Call{To: token, Data: data})
+		}
+	}
+	results, err := r.Calls(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]TokenInfo, len(tokens))
+	for i, token := range tokens {
+		values := make([]interface{}, len(tokenInfoMethods))
+		for j, method := range tokenInfoMethods {
+			unpacked, err := unpackResult(method, results[i*len(tokenInfoMethods)+j])
+			if err != nil {
+				return nil, fmt.Errorf("%s() on %s: %w", method, token.Hex(), err)
+			}
+			values[j] = unpacked[0]
+		}
+		infos[i] = TokenInfo{
+			Address:     token,
+			Name:        values[0].(string),
+			Symbol:      values[1].(string),
+			Decimals:    values[2].(uint8),
+			TotalSupply: values[3].(*big.Int),
+		}
+	}
+	return infos, nil
+}
+
+// unpackResult : 반환값이 없으면 (컨트랙트가 아닌 주소) 디코딩 오류
+func unpackResult(method string, result CallResult) ([]interface{}, error) {
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	return erc20ABI.Unpack(method, result.Data)
+}
+
+// revertError : revert 데이터에 Error(string) 사유가 있으면 포함
+func revertError(data []byte) error {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return fmt.Errorf("%w: %s", ErrCallReverted, reason)
+	}
+	return ErrCallReverted
+}
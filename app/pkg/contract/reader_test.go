@@ -0,0 +1,173 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingClient : BatchCallContext 호출마다 요청 수 기록
+type countingClient struct {
+	*backend.Client
+	batches []int
+}
+
+func (c *countingClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	c.batches = append(c.batches, len(batch))
+	return c.Client.BatchCallContext(ctx, batch)
+}
+
+// newReaderChain : 토큰 2개를 배포하고 holders 에게 1, 2, 3 ... 을 mint 한 체인
+func newReaderChain(t *testing.T, holders int) (*simulated.Backend, []common.Address, []common.Address) {
+	sim, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	t.Cleanup(func() { sim.Close() })
+
+	owner := sim.Accounts[0]
+	first, _, err := sim.DeployERC20Burnable(owner, "First", "FST", 18)
+	assert.Equal(t, nil, err)
+	second, _, err := sim.DeployERC20Burnable(owner, "Second", "SND", 6)
+	assert.Equal(t, nil, err)
+
+	addresses := make([]common.Address, holders)
+	for i := range addresses {
+		addresses[i] = common.BigToAddress(big.NewInt(int64(1000 + i)))
+		err = sim.MintERC20Burnable(owner, first, addresses[i], big.NewInt(int64(i+1)))
+		assert.Equal(t, nil, err)
+	}
+	return sim, []common.Address{first, second}, addresses
+}
+
+func assertBalances(t *testing.T, reader *Reader, token common.Address, holders []common.Address) {
+	balances, err := reader.BalancesOf(context.Background(), token, holders)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, len(holders), len(balances))
+	for i, balance := range balances {
+		assert.Equal(t, big.NewInt(int64(i+1)), balance)
+	}
+}
+
+func TestReader_Sequential(t *testing.T) {
+	sim, tokens, holders := newReaderChain(t, 5)
+
+	// simulated.Backend 는 batch 를 지원하지 않으므로 하나씩 호출
+	reader := NewReader(sim)
+	assertBalances(t, reader, tokens[0], holders)
+}
+
+func TestReader_Batch(t *testing.T) {
+	sim, tokens, holders := newReaderChain(t, 25)
+	client := &countingClient{Client: sim.RPCClient()}
+	defer client.Close()
+
+	reader := NewReader(client)
+	reader.BatchSize = 10
+	assertBalances(t, reader, tokens[0], holders)
+	assert.Equal(t, []int{10, 10, 5}, client.batches)
+
+	// middleware 로 감싸도 batch 로 전송
+	client.batches = nil
+	reader = NewReader(backend.Wrap(client, backend.Metrics()))
+	assertBalances(t, reader, tokens[0], holders)
+	assert.Equal(t, []int{25}, client.batches)
+}
+
+func TestReader_Multicall(t *testing.T) {
+	sim, tokens, holders := newReaderChain(t, 30)
+
+	response, err := DeployMulticall(context.Background(), sim, sim.Accounts[0])
+	assert.Equal(t, nil, err)
+	reader := NewReader(sim)
+	reader.Multicall = response.Address
+	reader.BatchSize = 12
+	assertBalances(t, reader, tokens[0], holders)
+
+	// 배포되지 않은 주소
+	reader.Multicall = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	_, err = reader.BalancesOf(context.Background(), tokens[0], holders)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestReader_TokenInfo(t *testing.T) {
+	sim, tokens, _ := newReaderChain(t, 3)
+	response, err := DeployMulticall(context.Background(), sim, sim.Accounts[0])
+	assert.Equal(t, nil, err)
+
+	rpcClient := sim.RPCClient()
+	defer rpcClient.Close()
+	multicall := NewReader(sim)
+	multicall.Multicall = response.Address
+
+	for name, reader := range map[string]*Reader{"sequential": NewReader(sim), "batch": NewReader(rpcClient), "multicall": multicall} {
+		infos, err := reader.TokenInfo(context.Background(), tokens...)
+		assert.Equal(t, nil, err, name)
+		assert.Equal(t, 2, len(infos), name)
+		assert.Equal(t, TokenInfo{Address: tokens[0], Name: "First", Symbol: "FST", Decimals: 18, TotalSupply: infos[0].TotalSupply}, infos[0], name)
+		assert.Equal(t, TokenInfo{Address: tokens[1], Name: "Second", Symbol: "SND", Decimals: 6, TotalSupply: infos[1].TotalSupply}, infos[1], name)
+		assert.Equal(t, "6", infos[0].TotalSupply.String(), name)
+		assert.Equal(t, "0", infos[1].TotalSupply.String(), name)
+
+		// 컨트랙트가 아닌 주소
+		_, err = reader.TokenInfo(context.Background(), sim.Accounts[0].Address())
+		assert.NotEqual(t, nil, err, name)
+	}
+}
+
+func TestReader_CallErrors(t *testing.T) {
+	sim, tokens, holders := newReaderChain(t, 1)
+	response, err := DeployMulticall(context.Background(), sim, sim.Accounts[0])
+	assert.Equal(t, nil, err)
+
+	data, err := erc20ABI.Pack("balanceOf", holders[0])
+	assert.Equal(t, nil, err)
+	calls := []Call{
+		{To: tokens[0], Data: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{To: tokens[0], Data: data},
+	}
+
+	reader := NewReader(sim)
+	reader.Multicall = response.Address
+	results, err := reader.Calls(context.Background(), calls)
+	assert.Equal(t, nil, err)
+	assert.True(t, errors.Is(results[0].Err, ErrCallReverted))
+	assert.Equal(t, common.LeftPadBytes([]byte{1}, 32), results[1].Data)
+
+	// JSON-RPC batch 는 호출별 오류를 그대로 전달
+	rpcClient := sim.RPCClient()
+	defer rpcClient.Close()
+	results, err = NewReader(rpcClient).Calls(context.Background(), calls)
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, nil, results[0].Err)
+	assert.Equal(t, common.LeftPadBytes([]byte{1}, 32), results[1].Data)
+
+	// 2바이트로 표현할 수 없는 길이
+	_, err = reader.Calls(context.Background(), []Call{{To: tokens[0], Data: make([]byte, maxMulticallData+1)}})
+	assert.NotEqual(t, nil, err)
+}
+
+func TestDecodeMulticall(t *testing.T) {
+	header := func(success byte, length int) []byte {
+		h := common.LeftPadBytes(big.NewInt(int64(length)).Bytes(), 32)
+		h[0] = success
+		return h
+	}
+	output := append(header(1, 2), 0xab, 0xcd)
+	output = append(output, header(0, 0)...)
+
+	results, err := decodeMulticall(output, 2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xab, 0xcd}, results[0].Data)
+	assert.True(t, errors.Is(results[1].Err, ErrCallReverted))
+
+	_, err = decodeMulticall(output, 3)
+	assert.Equal(t, errMalformedMulticall, err)
+	_, err = decodeMulticall(output[:33], 1)
+	assert.Equal(t, errMalformedMulticall, err)
+}
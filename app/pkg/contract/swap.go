@@ -0,0 +1,115 @@
+package contract
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SwapABI : smartcontract/solidity/contracts/Swap.sol ABI
+// abigen 바인딩이 없으므로 bind.BoundContract 로 호출
+const SwapABI = `[
+	{"inputs":[],"stateMutability":"nonpayable","type":"constructor"},
+	{"anonymous":false,"inputs":[
+		{"indexed":true,"internalType":"address","name":"erc20Owner","type":"address"},
+		{"indexed":false,"internalType":"uint256","name":"erc20Amount","type":"uint256"},
+		{"indexed":true,"internalType":"address","name":"erc1400Owner","type":"address"},
+		{"indexed":false,"internalType":"uint256","name":"erc1400Amount","type":"uint256"}
+	],"name":"SwapSuccess","type":"event"},
+	{"inputs":[],"name":"owner","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[
+		{"internalType":"address","name":"erc20Token","type":"address"},
+		{"internalType":"address","name":"erc20Owner","type":"address"},
+		{"internalType":"uint256","name":"erc20Amount","type":"uint256"},
+		{"internalType":"address","name":"erc1400Token","type":"address"},
+		{"internalType":"address","name":"erc1400Owner","type":"address"},
+		{"internalType":"uint256","name":"erc1400Amount","type":"uint256"}
+	],"name":"isSwapAvailable","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[
+		{"internalType":"address","name":"erc20Token","type":"address"},
+		{"internalType":"address","name":"erc20Owner","type":"address"},
+		{"internalType":"uint256","name":"erc20Amount","type":"uint256"},
+		{"internalType":"address","name":"erc1400Token","type":"address"},
+		{"internalType":"address","name":"erc1400Owner","type":"address"},
+		{"internalType":"uint256","name":"erc1400Amount","type":"uint256"}
+	],"name":"swapToken","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var swapABI abi.ABI
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(SwapABI))
+	if err != nil {
+		panic(err)
+	}
+	swapABI = parsed
+}
+
+// SwapOrder : ERC20 과 ERC1400 토큰 교환 조건 (각 토큰은 Swap 컨트랙트에 approve 되어 있어야 함)
+type SwapOrder struct {
+	ERC20Token    common.Address
+	ERC20Owner    common.Address
+	ERC20Amount   *big.Int
+	ERC1400Token  common.Address
+	ERC1400Owner  common.Address
+	ERC1400Amount *big.Int
+}
+
+func (o SwapOrder) args() []interface{} {
+	return []interface{}{o.ERC20Token, o.ERC20Owner, o.ERC20Amount, o.ERC1400Token, o.ERC1400Owner, o.ERC1400Amount}
+}
+
+// IsSwapAvailable : 양쪽 잔액이 충분한지 eth_call 로 확인 (부족하면 revert 사유를 error 로 반환)
+func IsSwapAvailable(ctx context.Context, client backend.Transactor, swapAddress common.Address, from common.Address, order SwapOrder) error {
+	input, err := swapABI.Pack("isSwapAvailable", order.args()...)
+	if err != nil {
+		return err
+	}
+	_, err = client.CallContract(ctx, ethereum.CallMsg{
+		From: from,
+		To:   &swapAddress,
+		Data: input,
+	}, nil)
+	return err
+}
+
+// SwapToken : Swap 컨트랙트 owner(signer)로 swapToken 실행
+func SwapToken(ctx context.Context, client backend.Transactor, signer wallet.Signer, swapAddress common.Address, order SwapOrder) (*types.Receipt, error) {
+	auth, err := GetAuth(ctx, client, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := bind.NewBoundContract(swapAddress, swapABI, client, client, client)
+	tx, err := instance.Transact(auth, "swapToken", order.args()...)
+	if err != nil {
+		releaseAuth(client, auth)
+		return nil, err
+	}
+
+	response := &ContractResponse{
+		Address:  swapAddress,
+		Method:   "swapToken",
+		Tx:       tx,
+		Instance: instance,
+	}
+	return waitMined(ctx, client, response)
+}
+
+// SwapOwner : swapToken 을 호출할 수 있는 계정
+func SwapOwner(ctx context.Context, client backend.Transactor, swapAddress common.Address) (common.Address, error) {
+	instance := bind.NewBoundContract(swapAddress, swapABI, client, client, client)
+	var out []interface{}
+	if err := instance.Call(&bind.CallOpts{Context: ctx}, &out, "owner"); err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(out[0], new(common.Address)).(*common.Address), nil
+}
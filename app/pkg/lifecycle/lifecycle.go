@@ -0,0 +1,154 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"tiny-blockchain-app/app/pkg/logging"
+)
+
+var logger = logging.New("lifecycle")
+
+// Component : 순서대로 시작하고 역순으로 종료할 구성 요소
+type Component struct {
+	Name string
+	// Start : 준비가 끝나면 반환 (계속 실행되는 작업은 Lifecycle.Go 로 실행), nil 이면 생략
+	Start func(ctx context.Context) error
+	// Stop : 진행 중인 작업을 ctx(종료 deadline)가 끝날 때까지 마무리, nil 이면 생략
+	Stop func(ctx context.Context) error
+}
+
+// Lifecycle : 구성 요소를 등록 순서대로 시작하고, ctx 가 끝나거나(SIGINT, SIGTERM) 작업이 실패하면 역순으로 종료
+type Lifecycle struct {
+	// ShutdownTimeout : 종료 deadline (0이면 제한 없음)
+	ShutdownTimeout time.Duration
+
+	mu         sync.Mutex
+	components []Component
+	runCtx     context.Context
+	failed     chan error
+	jobs       sync.WaitGroup
+}
+
+func New(shutdownTimeout time.Duration) *Lifecycle {
+	return &Lifecycle{
+		ShutdownTimeout: shutdownTimeout,
+		failed:          make(chan error, 1),
+	}
+}
+
+// Add : Run 전에 등록
+func (l *Lifecycle) Add(components ...Component) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.components = append(l.components, components...)
+}
+
+// Go : Run 이 끝날 때까지 실행되는 작업 (서버, 구독, 주기 작업)
+// fn 은 ctx 가 끝나면 반환해야 하며, 그 전에 오류를 반환하면 전체를 종료
+func (l *Lifecycle) Go(name string, fn func(ctx context.Context) error) {
+	l.mu.Lock()
+	ctx := l.runCtx
+	l.mu.Unlock()
+	if ctx == nil {
+		panic("lifecycle: Go called outside Run")
+	}
+
+	l.jobs.Add(1)
+	go func() {
+		defer l.jobs.Done()
+		err := fn(ctx)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		logger.Error("Job failed", "job", name, "err", err)
+		select {
+		case l.failed <- fmt.Errorf("%s: %w", name, err):
+		default:
+		}
+	}()
+}
+
+// Run : 모두 시작하고 ctx 가 끝나거나 작업이 실패할 때까지 대기한 뒤 종료
+// 시작 또는 작업 실패 오류가 있으면 그 오류, 없으면 종료 중 첫 오류 반환
+func (l *Lifecycle) Run(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	l.mu.Lock()
+	l.runCtx = runCtx
+	components := append([]Component(nil), l.components...)
+	l.mu.Unlock()
+
+	var runErr error
+	started := 0
+	for _, component := range components {
+		if component.Start != nil {
+			if err := component.Start(runCtx); err != nil {
+				runErr = fmt.Errorf("start %s: %w", component.Name, err)
+				logger.Error("Failed to start", "component", component.Name, "err", err)
+				break
+			}
+		}
+		logger.Info("Started", "component", component.Name)
+		started++
+	}
+
+	if runErr == nil {
+		select {
+		case <-runCtx.Done():
+			logger.Info("Shutting down")
+		case runErr = <-l.failed:
+			logger.Info("Shutting down after failure", "err", runErr)
+		}
+	}
+	cancel()
+
+	stopErr := l.shutdown(components[:started])
+	if runErr != nil {
+		return runErr
+	}
+	return stopErr
+}
+
+// shutdown : 역순으로 Stop 호출 후 Go 작업 종료 대기 (모두 ShutdownTimeout 안에서)
+func (l *Lifecycle) shutdown(components []Component) error {
+	ctx := context.Background()
+	if l.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.ShutdownTimeout)
+		defer cancel()
+	}
+
+	var firstErr error
+	for i := len(components) - 1; i >= 0; i-- {
+		component := components[i]
+		if component.Stop == nil {
+			continue
+		}
+		if err := component.Stop(ctx); err != nil {
+			logger.Warn("Failed to stop", "component", component.Name, "err", err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("stop %s: %w", component.Name, err)
+			}
+			continue
+		}
+		logger.Info("Stopped", "component", component.Name)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		l.jobs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Warn("Jobs did not finish before deadline")
+		if firstErr == nil {
+			firstErr = ctx.Err()
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,111 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder : 시작, 종료 순서 기록
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) component(name string, startErr error) Component {
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			r.add("start " + name)
+			return startErr
+		},
+		Stop: func(ctx context.Context) error {
+			r.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestLifecycle_Run(t *testing.T) {
+	r := &recorder{}
+	app := New(time.Second)
+	app.Add(r.component("a", nil), r.component("b", nil))
+
+	var jobStopped bool
+	app.Add(Component{
+		Name: "job",
+		Start: func(ctx context.Context) error {
+			app.Go("job", func(ctx context.Context) error {
+				<-ctx.Done()
+				jobStopped = true
+				return nil
+			})
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	assert.Equal(t, nil, app.Run(ctx))
+
+	assert.Equal(t, []string{"start a", "start b", "stop b", "stop a"}, r.events)
+	assert.True(t, jobStopped)
+}
+
+func TestLifecycle_StartFailure(t *testing.T) {
+	r := &recorder{}
+	app := New(time.Second)
+	app.Add(r.component("a", nil), r.component("b", errors.New("boom")), r.component("c", nil))
+
+	err := app.Run(context.Background())
+	assert.NotEqual(t, nil, err)
+
+	// 시작하지 못한 b, c 는 종료하지 않음
+	assert.Equal(t, []string{"start a", "start b", "stop a"}, r.events)
+}
+
+func TestLifecycle_JobFailure(t *testing.T) {
+	r := &recorder{}
+	app := New(time.Second)
+	app.Add(r.component("a", nil), Component{
+		Name: "server",
+		Start: func(ctx context.Context) error {
+			app.Go("server", func(ctx context.Context) error {
+				return errors.New("address already in use")
+			})
+			return nil
+		},
+	})
+
+	err := app.Run(context.Background())
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, []string{"start a", "stop a"}, r.events)
+}
+
+func TestLifecycle_ShutdownTimeout(t *testing.T) {
+	app := New(50 * time.Millisecond)
+	app.Add(Component{
+		Name: "slow",
+		Stop: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	err := app.Run(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, time.Since(start) < time.Second)
+}
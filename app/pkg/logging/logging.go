@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// debug : DebugMode 설정 여부 (RPC 요청/응답 추적에 사용)
+var debug int32
+
+// Setup : 전역 로거 설정
+// debugMode가 true이면 debug 레벨까지 출력하고 RPC 요청/응답을 추적, format은 logfmt(기본) 또는 json
+func Setup(w io.Writer, debugMode bool, format string) {
+	if w == nil {
+		w = os.Stderr
+	}
+
+	formatter := log.LogfmtFormat()
+	if format == "json" {
+		formatter = log.JSONFormat()
+	}
+
+	level := log.LvlInfo
+	var flag int32
+	if debugMode {
+		level = log.LvlDebug
+		flag = 1
+	}
+	atomic.StoreInt32(&debug, flag)
+	log.Root().SetHandler(log.LvlFilterHandler(level, log.SyncHandler(log.StreamHandler(w, formatter))))
+}
+
+// Debug : DebugMode 여부
+func Debug() bool {
+	return atomic.LoadInt32(&debug) == 1
+}
+
+// New : 패키지(모듈) 단위 로거
+func New(module string) log.Logger {
+	return log.Root().New("module", module)
+}
+
+type requestIDKey struct{}
+
+// WithRequestID : REST 요청의 correlation ID를 context에 저장
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID : context의 correlation ID (없으면 빈 문자열)
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// FromContext : context에 correlation ID가 있으면 requestId 필드를 추가한 로거
+func FromContext(ctx context.Context, logger log.Logger) log.Logger {
+	if requestID := RequestID(ctx); requestID != "" {
+		return logger.New("requestId", requestID)
+	}
+	return logger
+}
+
+// RedactBytes : 서명된 트랜잭션 등 민감한 바이트 값은 길이만 기록
+func RedactBytes(data []byte) string {
+	return fmt.Sprintf("<redacted %d bytes>", len(data))
+}
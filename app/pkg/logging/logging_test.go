@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetup_JSONDebug(t *testing.T) {
+	var buf bytes.Buffer
+	Setup(&buf, true, "json")
+	defer Setup(nil, false, "")
+
+	assert.Equal(t, true, Debug())
+
+	logger := FromContext(WithRequestID(context.Background(), "req-1"), New("test"))
+	logger.Debug("debug message", "key", "value")
+
+	var record map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &record)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "debug message", record["msg"])
+	assert.Equal(t, "test", record["module"])
+	assert.Equal(t, "req-1", record["requestId"])
+	assert.Equal(t, "value", record["key"])
+}
+
+func TestSetup_InfoFiltersDebug(t *testing.T) {
+	var buf bytes.Buffer
+	Setup(&buf, false, "logfmt")
+	defer Setup(nil, false, "")
+
+	assert.Equal(t, false, Debug())
+
+	logger := New("test")
+	logger.Debug("hidden")
+	logger.Info("shown")
+
+	assert.Equal(t, false, strings.Contains(buf.String(), "hidden"))
+	assert.Equal(t, true, strings.Contains(buf.String(), "shown"))
+}
+
+func TestRequestID_Empty(t *testing.T) {
+	assert.Equal(t, "", RequestID(context.Background()))
+}
+
+func TestRedactRequest_SendRawTransaction(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(1), 21000, big.NewInt(1), nil)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(10)), key)
+	assert.Equal(t, nil, err)
+	raw, _ := signed.MarshalBinary()
+
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["%s"]}`, hexutil.Encode(raw))
+	redacted := redactRequest([]byte(body))
+
+	assert.Equal(t, false, strings.Contains(redacted, hexutil.Encode(raw)))
+	assert.Equal(t, true, strings.Contains(redacted, signed.Hash().Hex()))
+}
+
+func TestRedactRequest_Plain(t *testing.T) {
+	body := `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`
+	assert.Equal(t, body, redactRequest([]byte(body)))
+
+	body = `[{"id":1,"method":"personal_unlockAccount","params":["0x01","secret"]}]`
+	assert.Equal(t, false, strings.Contains(redactRequest([]byte(body)), "secret"))
+}
+
+func TestRedactResponse(t *testing.T) {
+	response := []byte(`{"jsonrpc":"2.0","id":1,"result":{"hash":"0x01","r":"0x02","s":"0x03","v":"0x1b"}}`)
+
+	// 서명 값이 포함되는 응답은 크기만 기록
+	request := `{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionByHash","params":["0x01"]}`
+	assert.Equal(t, fmt.Sprintf("<%d bytes>", len(response)), redactResponse([]byte(request), response))
+	request = `[{"id":1,"method":"eth_blockNumber","params":[]},{"id":2,"method":"txpool_content","params":[]}]`
+	assert.Equal(t, fmt.Sprintf("<%d bytes>", len(response)), redactResponse([]byte(request), response))
+	request = `{"id":1,"method":"eth_signTransaction","params":[{}]}`
+	assert.Equal(t, fmt.Sprintf("<%d bytes>", len(response)), redactResponse([]byte(request), response))
+
+	request = `{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionReceipt","params":["0x01"]}`
+	assert.Equal(t, string(response), redactResponse([]byte(request), response))
+	long := []byte(strings.Repeat("a", maxTraceBody+1))
+	assert.Equal(t, strings.Repeat("a", maxTraceBody)+"...", redactResponse([]byte(request), long))
+}
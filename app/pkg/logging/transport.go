@@ -0,0 +1,173 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxTraceBody : 추적 로그에 남길 응답 본문 최대 길이
+const maxTraceBody = 1024
+
+// redactedMethods : 파라미터에 서명된 트랜잭션, 비밀번호, 서명 대상 데이터가 포함되는 메서드
+var redactedMethods = []string{"eth_sendRawTransaction", "personal_", "account_sign", "eth_sign"}
+
+// sizeOnlyMethods : 응답에 서명된 트랜잭션(서명 값 포함)이나 서명이 포함되는 메서드 (응답은 크기만 기록)
+var sizeOnlyMethods = []string{
+	"eth_getTransactionBy", "eth_getRawTransactionBy", "eth_getBlockBy", "eth_pendingTransactions", "txpool_content",
+	"eth_sign", "personal_", "account_sign",
+}
+
+// TraceTransport : DebugMode일 때 HTTP JSON-RPC 요청과 응답을 debug 레벨로 기록
+type TraceTransport struct {
+	base   http.RoundTripper
+	logger log.Logger
+}
+
+func NewTraceTransport(base http.RoundTripper) *TraceTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &TraceTransport{base: base, logger: New("rpc")}
+}
+
+type traceMessage struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+func (t *TraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !Debug() || req.Body == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	logger := FromContext(req.Context(), t.logger)
+	logger.Debug("RPC request", "url", req.URL.Redacted(), "body", redactRequest(body))
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		logger.Debug("RPC request failed", "elapsed", time.Since(start), "err", err)
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	logger.Debug("RPC response", "status", resp.StatusCode, "elapsed", time.Since(start), "body", redactResponse(body, respBody))
+	return resp, nil
+}
+
+// redactResponse : 요청에 sizeOnlyMethods 가 있으면 응답 크기만, 아니면 maxTraceBody 까지의 응답 본문
+func redactResponse(request, response []byte) string {
+	for _, method := range requestMethods(request) {
+		if hasMethodPrefix(method, sizeOnlyMethods) {
+			return fmt.Sprintf("<%d bytes>", len(response))
+		}
+	}
+	trimmed := string(response)
+	if len(trimmed) > maxTraceBody {
+		trimmed = trimmed[:maxTraceBody] + "..."
+	}
+	return trimmed
+}
+
+// requestMethods : 요청(batch 포함)의 메서드 (해석할 수 없으면 nil)
+func requestMethods(body []byte) []string {
+	var messages []traceMessage
+	if err := json.Unmarshal(body, &messages); err != nil {
+		var message traceMessage
+		if err := json.Unmarshal(body, &message); err != nil {
+			return nil
+		}
+		messages = []traceMessage{message}
+	}
+	methods := make([]string, len(messages))
+	for i, message := range messages {
+		methods[i] = message.Method
+	}
+	return methods
+}
+
+// redactRequest : 민감한 메서드의 파라미터를 가리고, 서명된 트랜잭션은 해시만 남김
+func redactRequest(body []byte) string {
+	var messages []traceMessage
+	batch := len(bytes.TrimSpace(body)) > 0 && bytes.TrimSpace(body)[0] == '['
+	if batch {
+		if err := json.Unmarshal(body, &messages); err != nil {
+			return RedactBytes(body)
+		}
+	} else {
+		var message traceMessage
+		if err := json.Unmarshal(body, &message); err != nil {
+			return RedactBytes(body)
+		}
+		messages = []traceMessage{message}
+	}
+
+	redacted := false
+	for i, message := range messages {
+		if !hasMethodPrefix(message.Method, redactedMethods) {
+			continue
+		}
+		redacted = true
+		params := make([]json.RawMessage, len(message.Params))
+		for j, param := range message.Params {
+			params[j] = redactParam(message.Method, param)
+		}
+		messages[i].Params = params
+	}
+	if !redacted {
+		return string(body)
+	}
+
+	var encoded []byte
+	if batch {
+		encoded, _ = json.Marshal(messages)
+	} else {
+		encoded, _ = json.Marshal(messages[0])
+	}
+	return string(encoded)
+}
+
+func hasMethodPrefix(method string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactParam(method string, param json.RawMessage) json.RawMessage {
+	if method == "eth_sendRawTransaction" {
+		var raw hexutil.Bytes
+		tx := new(types.Transaction)
+		if json.Unmarshal(param, &raw) == nil && tx.UnmarshalBinary(raw) == nil {
+			encoded, _ := json.Marshal("<signed tx " + tx.Hash().Hex() + ">")
+			return encoded
+		}
+	}
+	encoded, _ := json.Marshal(RedactBytes(param))
+	return encoded
+}
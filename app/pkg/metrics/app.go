@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"strings"
+	"sync"
+)
+
+// 애플리케이션 메트릭 (이름은 tba_ 접두사)
+var (
+	// JSON-RPC 호출 (method: eth_call 등, status: ok|error)
+	RPCRequests = NewCounterVec("tba_rpc_requests_total", "JSON-RPC requests sent to nodes.", "method", "status")
+	RPCDuration = NewHistogramVec("tba_rpc_request_duration_seconds", "JSON-RPC request latency.", nil, "method")
+
+	// backend middleware (method: Backend 메서드 이름, status: ok|not_found|error)
+	BackendCalls    = NewCounterVec("tba_backend_calls_total", "Backend method calls.", "method", "status")
+	BackendDuration = NewHistogramVec("tba_backend_call_duration_seconds", "Backend method call latency including retries.", nil, "method")
+
+	// 트랜잭션 (contract: ContractLabel, method: 호출 함수, status: success|reverted)
+	TxSent        = NewCounterVec("tba_tx_sent_total", "Transactions sent.", "contract", "method")
+	TxReceipts    = NewCounterVec("tba_tx_receipts_total", "Transaction receipts by execution status.", "contract", "method", "status")
+	TxReceiptWait = NewHistogramVec("tba_tx_receipt_wait_seconds", "Time from sending a transaction to receiving its receipt.", nil, "contract", "method")
+	NonceRetries  = NewCounterVec("tba_tx_nonce_retries_total", "Transactions resent with a refreshed nonce.", "method")
+
+	// 이벤트 구독 (event: 이벤트 이름)
+	EventLag        = NewGaugeVec("tba_event_subscription_lag_blocks", "Blocks between the chain head and the last delivered event.", "event")
+	EventReconnects = NewCounterVec("tba_event_subscription_reconnects_total", "Event subscription reconnect attempts.", "event")
+
+	// REST API (route: 등록된 경로 패턴)
+	HTTPRequests = NewCounterVec("tba_http_requests_total", "REST API requests.", "method", "route", "status")
+	HTTPDuration = NewHistogramVec("tba_http_request_duration_seconds", "REST API request latency.", nil, "method", "route")
+	// 요청 제한으로 거부된 요청 (limit: read|transaction|pending)
+	HTTPRateLimited = NewCounterVec("tba_http_rate_limited_total", "REST API requests rejected by rate limits.", "limit")
+)
+
+// contractNames : contract label 로 사용할 등록된 컨트랙트 (소문자 주소 → 이름)
+var contractNames sync.Map
+
+// LabelContract : address 의 contract label 을 name 으로 지정 (client.Registry 에 등록된 컨트랙트)
+func LabelContract(address, name string) {
+	contractNames.Store(strings.ToLower(address), name)
+}
+
+// ContractLabel : 등록된 컨트랙트는 이름, 아니면 "other" (임의 주소를 label 로 쓰면 시계열 수가 제한되지 않음)
+func ContractLabel(address string) string {
+	if name, exist := contractNames.Load(strings.ToLower(address)); exist {
+		return name.(string)
+	}
+	return overflowLabel
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	counter := NewCounterVec("test_counter_total", "Test counter.", "method")
+	gauge := NewGaugeVec("test_gauge", "Test gauge.")
+	histogram := NewHistogramVec("test_histogram_seconds", "Test histogram.", []float64{0.1, 1}, "route")
+
+	counter.Inc("b")
+	counter.Add(2, "a")
+	gauge.Set(7)
+	histogram.Observe(0.05, "/")
+	histogram.Observe(0.5, "/")
+
+	var buf bytes.Buffer
+	DefaultRegistry.Write(&buf)
+	output := buf.String()
+
+	assert.True(t, strings.Contains(output, "# TYPE test_counter_total counter\ntest_counter_total{method=\"a\"} 2\ntest_counter_total{method=\"b\"} 1\n"))
+	assert.True(t, strings.Contains(output, "test_gauge 7\n"))
+	assert.True(t, strings.Contains(output, "test_histogram_seconds_bucket{route=\"/\",le=\"0.1\"} 1\n"))
+	assert.True(t, strings.Contains(output, "test_histogram_seconds_bucket{route=\"/\",le=\"1\"} 2\n"))
+	assert.True(t, strings.Contains(output, "test_histogram_seconds_bucket{route=\"/\",le=\"+Inf\"} 2\n"))
+	assert.True(t, strings.Contains(output, "test_histogram_seconds_count{route=\"/\"} 2\n"))
+
+	assert.Panics(t, func() { NewGaugeVec("test_gauge", "duplicate") })
+	assert.Panics(t, func() { counter.Inc() })
+}
+
+func TestRegistry_Escape(t *testing.T) {
+	counter := NewCounterVec("test_escape_total", "Help with \\ and\nnewline.", "value")
+	counter.Inc("a\"b\\c\nd")
+
+	var buf bytes.Buffer
+	DefaultRegistry.Write(&buf)
+	output := buf.String()
+	assert.True(t, strings.Contains(output, "# HELP test_escape_total Help with \\\\ and\\nnewline.\n"))
+	assert.True(t, strings.Contains(output, `test_escape_total{value="a\"b\\c\nd"} 1`+"\n"))
+}
+
+func TestCounterVec_MaxSeries(t *testing.T) {
+	counter := NewCounterVec("test_series_total", "Test series.", "contract", "method")
+	for i := 0; i < maxSeries+10; i++ {
+		counter.Inc(strconv.Itoa(i), "transfer")
+	}
+	// 상한을 넘은 조합은 모두 other 로 기록
+	assert.Equal(t, maxSeries+1, len(counter.series))
+	assert.Equal(t, float64(10), counter.Value("other", "other"))
+}
+
+func TestContractLabel(t *testing.T) {
+	LabelContract("0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9", "token")
+	assert.Equal(t, "token", ContractLabel("0xb9d171f81716ee2ce29b85ba44b3966992512ec9"))
+	assert.Equal(t, "other", ContractLabel("0x0000000000000000000000000000000000001000"))
+}
+
+type stubEth struct{}
+
+func (s *stubEth) BlockNumber() hexutil.Uint64 { return 1 }
+func (s *stubEth) ChainId() (*hexutil.Big, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestTransport(t *testing.T) {
+	server := rpc.NewServer()
+	assert.Equal(t, nil, server.RegisterName("eth", &stubEth{}))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	cli, err := rpc.DialHTTPWithClient(httpServer.URL, &http.Client{Transport: NewTransport(nil)})
+	assert.Equal(t, nil, err)
+	client := ethclient.NewClient(cli)
+	defer client.Close()
+
+	okBefore := RPCRequests.Value("eth_blockNumber", "ok")
+	errBefore := RPCRequests.Value("eth_chainId", "error")
+
+	_, err = client.BlockNumber(context.Background())
+	assert.Equal(t, nil, err)
+	_, err = client.ChainID(context.Background())
+	assert.NotEqual(t, nil, err)
+
+	assert.Equal(t, okBefore+1, RPCRequests.Value("eth_blockNumber", "ok"))
+	assert.Equal(t, errBefore+1, RPCRequests.Value("eth_chainId", "error"))
+	assert.True(t, RPCDuration.Count("eth_blockNumber") >= 1)
+}
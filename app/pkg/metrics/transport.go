@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Transport : HTTP JSON-RPC 요청 본문에서 method를 읽어 호출 수, 오류 수, 지연 시간 기록
+//
+//	rpc.DialHTTPWithClient(endpoint, &http.Client{Transport: metrics.NewTransport(nil)})
+type Transport struct {
+	base http.RoundTripper
+}
+
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{base: base}
+}
+
+type rpcMessage struct {
+	Method string          `json:"method"`
+	Error  json.RawMessage `json:"error"`
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil {
+		return t.base.RoundTrip(req)
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	methods := parseMethods(body)
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Seconds()
+
+	// 배치 요청은 응답 순서가 요청과 다를 수 있으므로 method별 오류는 단건 요청에서만 구분
+	failed := err != nil || resp.StatusCode >= http.StatusBadRequest
+	if !failed && len(methods) == 1 {
+		var respBody []byte
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+		var message rpcMessage
+		failed = json.Unmarshal(respBody, &message) == nil && len(message.Error) > 0 && string(message.Error) != "null"
+	}
+
+	status := "ok"
+	if failed {
+		status = "error"
+	}
+	for _, method := range methods {
+		RPCRequests.Inc(method, status)
+		RPCDuration.Observe(elapsed, method)
+	}
+	return resp, err
+}
+
+func parseMethods(body []byte) []string {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []rpcMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return []string{"unknown"}
+		}
+		methods := make([]string, len(batch))
+		for i, message := range batch {
+			methods[i] = message.Method
+		}
+		return methods
+	}
+
+	var message rpcMessage
+	if err := json.Unmarshal(body, &message); err != nil || message.Method == "" {
+		return []string{"unknown"}
+	}
+	return []string{message.Method}
+}
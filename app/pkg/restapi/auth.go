@@ -0,0 +1,295 @@
+package restapi
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+)
+
+// 역할 (뒤의 역할은 앞의 역할 권한을 모두 가짐)
+//
+//	viewer      : 조회 (/status, call, 서명 검증, raft 조회)
+//	operator    : 트랜잭션 전송 (transact)
+//	token-admin : 토큰 관리 메서드 (mint, pause, unPause, transferOwnership, renounceOwnership)
+//	admin       : 노드 관리 (raft 멤버 추가, 삭제, 승격)
+const (
+	RoleViewer     = "viewer"
+	RoleOperator   = "operator"
+	RoleTokenAdmin = "token-admin"
+	RoleAdmin      = "admin"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer:     1,
+	RoleOperator:   2,
+	RoleTokenAdmin: 3,
+	RoleAdmin:      4,
+}
+
+// tokenAdminMethods : transact 에서 token-admin 역할이 필요한 메서드
+var tokenAdminMethods = map[string]bool{
+	"mint":              true,
+	"pause":             true,
+	"unPause":           true,
+	"transferOwnership": true,
+	"renounceOwnership": true,
+}
+
+// tokenAdminSelectors : token-admin 역할이 필요한 함수 selector
+// abi 는 요청자가 보내므로 이름(오버로드면 mint0 등)이 아닌 실제로 호출되는 selector 로 확인
+var tokenAdminSelectors = selectors(
+	"mint(address,uint256)",
+	"pause()",
+	"unPause()",
+	"transferOwnership(address)",
+	"renounceOwnership()",
+)
+
+func selectors(signatures ...string) map[[4]byte]bool {
+	result := make(map[[4]byte]bool, len(signatures))
+	for _, signature := range signatures {
+		var selector [4]byte
+		copy(selector[:], crypto.Keccak256([]byte(signature))[:4])
+		result[selector] = true
+	}
+	return result
+}
+
+// AnyAccount : Accounts 에 포함하면 모든 서명 계정 사용 가능
+const AnyAccount = "*"
+
+// AuthConfig : Enabled 가 false 이면 인증 없이 모든 요청 허용
+type AuthConfig struct {
+	Enabled bool
+	// JWTSecret : HS256 서명 키 (비어있으면 JWT 인증 사용 안 함)
+	JWTSecret string
+	// JWTIssuer : 비어있지 않으면 iss 가 같은 토큰만 허용
+	JWTIssuer string
+	APIKeys   []APIKeyConfig
+}
+
+// APIKeyConfig : X-API-Key 헤더로 인증하는 호출자 (키 원문 대신 SHA-256 해시 저장)
+type APIKeyConfig struct {
+	Name      string
+	KeySha256 string
+	Role      string
+	// Accounts : 서명에 사용할 수 있는 계정 주소 ("*" 이면 모든 계정)
+	Accounts []string
+}
+
+// Principal : 인증된 호출자
+type Principal struct {
+	Name     string
+	Role     string
+	Accounts []string
+}
+
+// Has : role 이상의 역할인지
+func (p Principal) Has(role string) bool {
+	return roleRanks[p.Role] >= roleRanks[role]
+}
+
+// CanSign : address 계정으로 서명할 수 있는지
+func (p Principal) CanSign(address common.Address) bool {
+	for _, account := range p.Accounts {
+		if account == AnyAccount || (common.IsHexAddress(account) && common.HexToAddress(account) == address) {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims : JWT payload (sub 가 호출자 이름)
+type Claims struct {
+	Role     string   `json:"role"`
+	Accounts []string `json:"accounts,omitempty"`
+	jwt.StandardClaims
+}
+
+// Authenticator : API key 또는 Bearer JWT 로 Principal 확인
+type Authenticator struct {
+	conf    AuthConfig
+	apiKeys []apiKey
+}
+
+type apiKey struct {
+	hash      []byte
+	principal Principal
+}
+
+func NewAuthenticator(conf AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{conf: conf}
+	for _, key := range conf.APIKeys {
+		hash, err := hex.DecodeString(strings.TrimPrefix(key.KeySha256, "0x"))
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("invalid keySha256 for api key %q", key.Name)
+		}
+		if _, exist := roleRanks[key.Role]; !exist {
+			return nil, fmt.Errorf("unknown role %q for api key %q", key.Role, key.Name)
+		}
+		a.apiKeys = append(a.apiKeys, apiKey{
+			hash:      hash,
+			principal: Principal{Name: key.Name, Role: key.Role, Accounts: key.Accounts},
+		})
+	}
+	if conf.Enabled && len(a.apiKeys) == 0 && conf.JWTSecret == "" {
+		return nil, errors.New("auth is enabled but neither apiKeys nor jwtSecret is configured")
+	}
+	return a, nil
+}
+
+// HashAPIKey : 설정 파일의 keySha256 값
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// IssueToken : secret 으로 서명한 JWT 발급
+func IssueToken(secret, issuer, subject, role string, accounts []string, ttl time.Duration) (string, error) {
+	if _, exist := roleRanks[role]; !exist {
+		return "", fmt.Errorf("unknown role %q", role)
+	}
+	now := time.Now()
+	claims := Claims{
+		Role:     role,
+		Accounts: accounts,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   subject,
+			Issuer:    issuer,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(ttl).Unix(),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+// Authenticate : X-API-Key 헤더 또는 Authorization: Bearer 헤더 확인
+func (a *Authenticator) Authenticate(r *http.Request) (Principal, error) {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return a.authenticateKey(key)
+	}
+	if header := r.Header.Get(echo.HeaderAuthorization); strings.HasPrefix(header, "Bearer ") {
+		return a.authenticateToken(strings.TrimPrefix(header, "Bearer "))
+	}
+	return Principal{}, errors.New("missing credentials")
+}
+
+func (a *Authenticator) authenticateKey(key string) (Principal, error) {
+	hash := sha256.Sum256([]byte(key))
+	for _, k := range a.apiKeys {
+		if subtle.ConstantTimeCompare(hash[:], k.hash) == 1 {
+			return k.principal, nil
+		}
+	}
+	return Principal{}, errors.New("invalid api key")
+}
+
+func (a *Authenticator) authenticateToken(token string) (Principal, error) {
+	if a.conf.JWTSecret == "" {
+		return Principal{}, errors.New("jwt authentication is not configured")
+	}
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return []byte(a.conf.JWTSecret), nil
+	})
+	if err != nil {
+		return Principal{}, err
+	}
+	if claims.ExpiresAt == 0 {
+		return Principal{}, errors.New("token has no expiry")
+	}
+	if a.conf.JWTIssuer != "" && !claims.VerifyIssuer(a.conf.JWTIssuer, true) {
+		return Principal{}, errors.New("invalid token issuer")
+	}
+	if _, exist := roleRanks[claims.Role]; !exist {
+		return Principal{}, fmt.Errorf("unknown role %q", claims.Role)
+	}
+	return Principal{Name: claims.Subject, Role: claims.Role, Accounts: claims.Accounts}, nil
+}
+
+const principalKey = "principal"
+
+// authenticate : 인증이 켜져 있으면 Principal 을 context 에 저장, 인증 정보가 없거나 잘못되면 401
+func (s *Server) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if s.auth == nil || !s.auth.conf.Enabled {
+			return next(c)
+		}
+		principal, err := s.auth.Authenticate(c.Request())
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+		c.Set(principalKey, principal)
+		// 이 요청으로 전송하는 트랜잭션의 journal 요청자
+		c.SetRequest(c.Request().WithContext(journal.WithRequester(c.Request().Context(), principal.Name)))
+		return next(c)
+	}
+}
+
+// require : role 이상의 역할이 필요한 route
+func (s *Server) require(role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return s.authenticate(func(c echo.Context) error {
+			if err := authorize(c, role); err != nil {
+				return err
+			}
+			return next(c)
+		})
+	}
+}
+
+// authorize : 인증이 꺼져 있으면 항상 허용
+func authorize(c echo.Context, role string) error {
+	principal, ok := c.Get(principalKey).(Principal)
+	if !ok {
+		return nil
+	}
+	if !principal.Has(role) {
+		return echo.NewHTTPError(http.StatusForbidden, "role "+role+" is required")
+	}
+	return nil
+}
+
+// authorizeSigner : 호출자가 address 계정으로 서명할 수 있는지 (인증이 꺼져 있으면 항상 허용)
+func authorizeSigner(c echo.Context, address common.Address) error {
+	principal, ok := c.Get(principalKey).(Principal)
+	if !ok {
+		return nil
+	}
+	if !principal.CanSign(address) {
+		return echo.NewHTTPError(http.StatusForbidden, "not allowed to sign with "+address.Hex())
+	}
+	return nil
+}
+
+// methodRole : transact 메서드에 필요한 역할 (abi 의 selector 와 오버로드 전 함수 이름으로 확인)
+func methodRole(contractAbi abi.ABI, method string) string {
+	abiMethod, exist := contractAbi.Methods[method]
+	if !exist {
+		if tokenAdminMethods[method] {
+			return RoleTokenAdmin
+		}
+		return RoleOperator
+	}
+	var selector [4]byte
+	copy(selector[:], abiMethod.ID)
+	if tokenAdminSelectors[selector] || tokenAdminMethods[abiMethod.RawName] {
+		return RoleTokenAdmin
+	}
+	return RoleOperator
+}
@@ -0,0 +1,126 @@
+package restapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const testJWTSecret = "test-secret"
+
+func newKeyPair(t *testing.T) wallet.KeyPair {
+	privateKey, err := crypto.GenerateKey()
+	assert.Equal(t, nil, err)
+	return wallet.KeyPair{PublicKey: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+}
+
+// newAuthServer : viewer, operator(signer 만 사용 가능), token-admin(모든 계정) API key 와 JWT 를 허용하는 서버
+func newAuthServer(t *testing.T, signer, other wallet.KeyPair) *Server {
+	auth, err := NewAuthenticator(AuthConfig{
+		Enabled:   true,
+		JWTSecret: testJWTSecret,
+		JWTIssuer: "test",
+		APIKeys: []APIKeyConfig{
+			{Name: "viewer", KeySha256: HashAPIKey("viewer-key"), Role: RoleViewer},
+			{Name: "operator", KeySha256: HashAPIKey("operator-key"), Role: RoleOperator, Accounts: []string{signer.PublicKey.Hex()}},
+			{Name: "token-admin", KeySha256: HashAPIKey("admin-key"), Role: RoleTokenAdmin, Accounts: []string{AnyAccount}},
+		},
+	})
+	assert.Equal(t, nil, err)
+
+	server := NewServer(nil, signer)
+	server.AddSigner(other)
+	server.SetAuth(auth)
+	return server
+}
+
+func serveAuth(server *Server, method, path, body string, header map[string]string) int {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestAuth_Roles(t *testing.T) {
+	signer, other := newKeyPair(t), newKeyPair(t)
+	server := newAuthServer(t, signer, other)
+
+	viewer := map[string]string{"X-API-Key": "viewer-key"}
+	operator := map[string]string{"X-API-Key": "operator-key"}
+	admin := map[string]string{"X-API-Key": "admin-key"}
+	transact := "/contracts/0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9/transact"
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		header map[string]string
+		code   int
+	}{
+		{"public health", http.MethodGet, "/health", "", nil, http.StatusOK},
+		{"no credentials", http.MethodGet, "/status", "", nil, http.StatusUnauthorized},
+		{"invalid key", http.MethodGet, "/status", "", map[string]string{"X-API-Key": "wrong"}, http.StatusUnauthorized},
+		{"viewer status", http.MethodGet, "/status", "", viewer, http.StatusServiceUnavailable},
+		{"viewer transact", http.MethodPost, transact, `{"abi":"[]","method":"transfer"}`, viewer, http.StatusForbidden},
+		// 인가를 통과하면 abi 에 메서드가 없어 400
+		{"operator transfer", http.MethodPost, transact, `{"abi":"[]","method":"transfer"}`, operator, http.StatusBadRequest},
+		{"operator mint", http.MethodPost, transact, `{"abi":"[]","method":"mint"}`, operator, http.StatusForbidden},
+		// 오버로드로 이름이 바뀌어도 mint(address,uint256) selector 는 token-admin
+		{"operator overloaded mint", http.MethodPost, transact, `{"abi":"[{\"type\":\"function\",\"name\":\"mint\",\"inputs\":[]},{\"type\":\"function\",\"name\":\"mint\",\"inputs\":[{\"name\":\"to\",\"type\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\"}]}]","method":"mint0"}`, operator, http.StatusForbidden},
+		{"operator other overload", http.MethodPost, transact, `{"abi":"[{\"type\":\"function\",\"name\":\"pause\",\"inputs\":[{\"name\":\"id\",\"type\":\"uint256\"}]}]","method":"pause"}`, operator, http.StatusForbidden},
+		{"operator other account", http.MethodPost, transact, `{"abi":"[]","method":"transfer","from":"` + other.PublicKey.Hex() + `"}`, operator, http.StatusForbidden},
+		{"token-admin mint", http.MethodPost, transact, `{"abi":"[]","method":"mint"}`, admin, http.StatusBadRequest},
+		{"token-admin other account", http.MethodPost, transact, `{"abi":"[]","method":"pause","from":"` + other.PublicKey.Hex() + `"}`, admin, http.StatusBadRequest},
+		{"token-admin raft", http.MethodDelete, "/raft/peers/2", "", admin, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.code, serveAuth(server, tc.method, tc.path, tc.body, tc.header), tc.name)
+	}
+}
+
+func TestAuth_JWT(t *testing.T) {
+	signer, other := newKeyPair(t), newKeyPair(t)
+	server := newAuthServer(t, signer, other)
+	transact := "/contracts/0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9/transact"
+
+	token, err := IssueToken(testJWTSecret, "test", "ci", RoleTokenAdmin, []string{other.PublicKey.Hex()}, time.Hour)
+	assert.Equal(t, nil, err)
+	bearer := map[string]string{"Authorization": "Bearer " + token}
+	assert.Equal(t, http.StatusBadRequest, serveAuth(server, http.MethodPost, transact, `{"abi":"[]","method":"mint","from":"`+other.PublicKey.Hex()+`"}`, bearer))
+	// 기본 서명 계정(signer)은 허용되지 않음
+	assert.Equal(t, http.StatusForbidden, serveAuth(server, http.MethodPost, transact, `{"abi":"[]","method":"mint"}`, bearer))
+
+	expired, err := IssueToken(testJWTSecret, "test", "ci", RoleTokenAdmin, nil, -time.Minute)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(server, http.MethodGet, "/status", "", map[string]string{"Authorization": "Bearer " + expired}))
+
+	forged, err := IssueToken("other-secret", "test", "ci", RoleAdmin, nil, time.Hour)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(server, http.MethodGet, "/status", "", map[string]string{"Authorization": "Bearer " + forged}))
+
+	wrongIssuer, err := IssueToken(testJWTSecret, "someone", "ci", RoleAdmin, nil, time.Hour)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(server, http.MethodGet, "/status", "", map[string]string{"Authorization": "Bearer " + wrongIssuer}))
+}
+
+func TestNewAuthenticator_Invalid(t *testing.T) {
+	_, err := NewAuthenticator(AuthConfig{Enabled: true})
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewAuthenticator(AuthConfig{APIKeys: []APIKeyConfig{{Name: "a", KeySha256: "1234", Role: RoleViewer}}})
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewAuthenticator(AuthConfig{APIKeys: []APIKeyConfig{{Name: "a", KeySha256: HashAPIKey("a"), Role: "root"}}})
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,164 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"time"
+	"tiny-blockchain-app/app/pkg/contract"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/labstack/echo/v4"
+)
+
+type contractRequest struct {
+	ABI    json.RawMessage   `json:"abi"`
+	Method string            `json:"method"`
+	Args   []json.RawMessage `json:"args"`
+	From   string            `json:"from"`
+	Value  string            `json:"value"`
+}
+
+type callResponse struct {
+	Outputs []contract.DynamicOutput `json:"outputs"`
+}
+
+type transactResponse struct {
+	TxHash  string         `json:"txHash"`
+	Receipt *types.Receipt `json:"receipt"`
+}
+
+var contractRequestSchema = object([]string{"abi", "method"}, map[string]*Schema{
+	"abi": {
+		Description: "Contract ABI as a JSON array or a string containing it",
+		AnyOf:       []*Schema{{Type: "string"}, {Type: "array"}},
+	},
+	"method": stringSchema("Method name"),
+	"args":   {Type: "array", Description: "Method arguments (addresses as hex, integers as decimal/0x strings or numbers)", Items: &Schema{}},
+	"from":   addressSchema("call: msg.sender, transact: signing account (default: server signer)"),
+	"value":  amountSchema("transact: wei to send with a payable method"),
+})
+
+var callContractOperation = Operation{
+	Summary:     "Call a read-only contract method",
+	Tags:        []string{"contracts"},
+	Role:        RoleViewer,
+	Parameters:  []Parameter{pathParam("address", addressSchema("Contract address"))},
+	RequestBody: jsonBody(contractRequestSchema),
+	Responses: map[string]Response{
+		"200": jsonResponse("Decoded outputs", object(nil, map[string]*Schema{"outputs": {Type: "array", Items: object(nil, map[string]*Schema{"name": {}, "type": {}, "value": {}})}})),
+	},
+}
+
+var transactContractOperation = Operation{
+	Summary:     "Send a transaction to a contract method and wait for the receipt",
+	Description: "mint, pause, unPause, transferOwnership and renounceOwnership (by selector or name, including overloads) require the token-admin role.",
+	Tags:        []string{"contracts"},
+	Role:        RoleOperator,
+	Parameters:  []Parameter{pathParam("address", addressSchema("Contract address"))},
+	RequestBody: jsonBody(contractRequestSchema),
+	Idempotent:  true,
+	Responses: map[string]Response{
+		"200": jsonResponse("Mined transaction", object(nil, map[string]*Schema{"txHash": hexSchema("Transaction hash"), "receipt": {Type: "object"}})),
+		"502": jsonResponse("Transaction failed or reverted", errorSchema),
+		"503": jsonResponse("No signing key configured", errorSchema),
+	},
+}
+
+// POST /contracts/:address/call
+func (s *Server) callContract(c echo.Context) error {
+	address, contractAbi, request, err := bindContractRequest(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	from := common.Address{}
+	if request.From != "" {
+		if !common.IsHexAddress(request.From) {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid from address")
+		}
+		from = common.HexToAddress(request.From)
+	}
+
+	outputs, err := contract.CallContract(c.Request().Context(), s.client, contractAbi, address, from, request.Method, request.Args)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusOK, callResponse{Outputs: outputs})
+}
+
+// POST /contracts/:address/transact
+func (s *Server) transactContract(c echo.Context) error {
+	address, contractAbi, request, err := bindContractRequest(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := authorize(c, methodRole(contractAbi, request.Method)); err != nil {
+		return err
+	}
+	if s.signer == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "no signing key configured")
+	}
+
+	// from 이 없으면 기본 서명 계정
+	signer := s.signer
+	if request.From != "" {
+		if !common.IsHexAddress(request.From) {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid from address")
+		}
+		var exist bool
+		if signer, exist = s.signers[common.HexToAddress(request.From)]; !exist {
+			return echo.NewHTTPError(http.StatusBadRequest, "no signing key for "+request.From)
+		}
+	}
+	if err := authorizeSigner(c, signer.Address()); err != nil {
+		return err
+	}
+	if !s.pendingLimiter.acquire(signer.Address()) {
+		return tooManyRequests(c, "pending", time.Second)
+	}
+	defer s.pendingLimiter.release(signer.Address())
+
+	var value *big.Int
+	if request.Value != "" {
+		var ok bool
+		value, ok = new(big.Int).SetString(request.Value, 0)
+		if !ok || value.Sign() < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid value")
+		}
+	}
+
+	tx, receipt, err := contract.TransactContract(c.Request().Context(), idempotentTransactor(c, s.client), signer, contractAbi, address, request.Method, request.Args, value, s.nonceErrRetryCnt)
+	if err != nil {
+		if tx == nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusOK, transactResponse{TxHash: tx.Hash().Hex(), Receipt: receipt})
+}
+
+func bindContractRequest(c echo.Context) (common.Address, abi.ABI, contractRequest, error) {
+	var request contractRequest
+
+	addressParam := c.Param("address")
+	if !common.IsHexAddress(addressParam) {
+		return common.Address{}, abi.ABI{}, request, errors.New("invalid contract address")
+	}
+
+	if err := c.Bind(&request); err != nil {
+		return common.Address{}, abi.ABI{}, request, errors.New("invalid request body")
+	}
+	if request.Method == "" {
+		return common.Address{}, abi.ABI{}, request, errors.New("method is required")
+	}
+
+	contractAbi, err := contract.ParseABI(request.ABI)
+	if err != nil {
+		return common.Address{}, abi.ABI{}, request, err
+	}
+	return common.HexToAddress(addressParam), contractAbi, request, nil
+}
@@ -0,0 +1,34 @@
+package restapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractHandler_InvalidRequest(t *testing.T) {
+	server := NewServer(nil, nil)
+
+	cases := []struct {
+		path string
+		body string
+		code int
+	}{
+		{"/contracts/0x1234/call", `{"abi":"[]","method":"balanceOf"}`, http.StatusBadRequest},
+		{"/contracts/0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9/call", `{"abi":"[]"}`, http.StatusBadRequest},
+		{"/contracts/0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9/call", `{"abi":"[]","method":"balanceOf"}`, http.StatusBadRequest},
+		{"/contracts/0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9/transact", `{"abi":"[]","method":"mint"}`, http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		server.echo.ServeHTTP(rec, req)
+		assert.Equal(t, tc.code, rec.Code, tc.path+" "+tc.body)
+	}
+}
@@ -0,0 +1,59 @@
+package restapi
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type healthResponse struct {
+	Status    string   `json:"status"`
+	Unhealthy []string `json:"unhealthy,omitempty"`
+}
+
+var healthOperation = Operation{
+	Summary: "Liveness and node health",
+	Tags:    []string{"status"},
+	Responses: map[string]Response{
+		"200": jsonResponse("Healthy", object(nil, map[string]*Schema{"status": stringSchema("ok")})),
+		"503": jsonResponse("Starting, stale (polling stopped) or unhealthy nodes", object(nil, map[string]*Schema{"status": {}, "unhealthy": {Type: "array", Items: stringSchema("")}})),
+	},
+}
+
+var statusOperation = Operation{
+	Summary: "Node monitor status",
+	Tags:    []string{"status"},
+	Role:    RoleViewer,
+	Responses: map[string]Response{
+		"200": jsonResponse("Last poll result of every node", &Schema{Type: "object"}),
+		"503": jsonResponse("Node monitor is not configured", errorSchema),
+	},
+}
+
+// GET /health
+// 모니터가 없으면 서버 자체의 생존 여부만, 있으면 마지막 조회 결과로 판정 (비정상이면 503)
+func (s *Server) health(c echo.Context) error {
+	if s.monitor == nil {
+		return c.JSON(http.StatusOK, healthResponse{Status: "ok"})
+	}
+
+	status := s.monitor.Status()
+	if status.CheckedAt.IsZero() {
+		return c.JSON(http.StatusServiceUnavailable, healthResponse{Status: "starting"})
+	}
+	if status.Stale {
+		return c.JSON(http.StatusServiceUnavailable, healthResponse{Status: "stale"})
+	}
+	if !status.Healthy {
+		return c.JSON(http.StatusServiceUnavailable, healthResponse{Status: "unhealthy", Unhealthy: status.Unhealthy()})
+	}
+	return c.JSON(http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// GET /status
+func (s *Server) status(c echo.Context) error {
+	if s.monitor == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "node monitor is not configured")
+	}
+	return c.JSON(http.StatusOK, s.monitor.Status())
+}
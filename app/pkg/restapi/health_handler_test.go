@@ -0,0 +1,50 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"tiny-blockchain-app/app/pkg/blockchain/monitor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealth(t *testing.T) {
+	server := NewServer(nil, nil)
+
+	rec := serve(server, http.MethodGet, "/health")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(server, http.MethodGet, "/status")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestHealth_Unhealthy(t *testing.T) {
+	nodeMonitor, err := monitor.New(monitor.Config{
+		Nodes: []monitor.NodeConfig{{Name: "node-1", Endpoint: "http://127.0.0.1:1"}},
+	})
+	assert.Equal(t, nil, err)
+	defer nodeMonitor.Close()
+
+	server := NewServer(nil, nil)
+	server.SetMonitor(nodeMonitor)
+
+	// 첫 조회 전에는 starting
+	rec := serve(server, http.MethodGet, "/health")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	nodeMonitor.Poll(context.Background())
+
+	rec = serve(server, http.MethodGet, "/health")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var health healthResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &health))
+	assert.Equal(t, []string{"node-1"}, health.Unhealthy)
+
+	rec = serve(server, http.MethodGet, "/status")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var status monitor.Status
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.False(t, status.Nodes[0].Up)
+}
@@ -0,0 +1,414 @@
+package restapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	defaultIdempotencyTTL    = 24 * time.Hour
+	idempotencyEntryKey      = "idempotency"
+)
+
+// IdempotencyConfig : Idempotency-Key 로 저장한 결과를 보관하는 시간 (0이면 24시간)
+type IdempotencyConfig struct {
+	TTLMinutes int
+	// File : 전송한 트랜잭션 hash 와 결과를 기록하는 JSON Lines 파일 (비어 있으면 메모리에만 보관하여 다시 시작하면 사라짐)
+	File string
+}
+
+// idempotencyStore : 호출자와 Idempotency-Key 별 요청 결과
+type idempotencyStore struct {
+	ttl       time.Duration
+	mu        sync.Mutex
+	entries   map[string]*idempotencyEntry
+	lastSweep time.Time
+
+	// file : 비어 있지 않으면 전송 직후와 끝난 뒤 entry 를 한 줄씩 추가
+	file   string
+	fileMu sync.Mutex
+}
+
+// idempotencyEntry : 처리 중(done 이 닫히기 전)이면 전송한 트랜잭션 hash, 끝나면 응답
+// 전송 후 응답 전에 서버가 종료되어 파일에서 읽은 entry 는 done 이 닫혀 있고 status 가 0
+type idempotencyEntry struct {
+	store       *idempotencyStore
+	key         string
+	fingerprint string
+	done        chan struct{}
+
+	mu          sync.Mutex
+	txHash      string
+	status      int
+	contentType string
+	body        []byte
+	expires     time.Time
+}
+
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+	return &idempotencyStore{ttl: ttl, entries: map[string]*idempotencyEntry{}, lastSweep: time.Now()}
+}
+
+// begin : 처음 보는 key 이면 새 entry 와 true, 이미 있으면 기존 entry 와 false
+func (s *idempotencyStore) begin(key, fingerprint string, now time.Time) (*idempotencyEntry, bool) {
+	if s.sweep(now) {
+		s.compact()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, exist := s.entries[key]; exist && !entry.expired(now) {
+		return entry, false
+	}
+	entry := &idempotencyEntry{store: s, key: key, fingerprint: fingerprint, done: make(chan struct{})}
+	s.entries[key] = entry
+	return entry, true
+}
+
+// sweep : 1분마다 만료된 entry 삭제, 삭제한 entry 가 있으면 true
+func (s *idempotencyStore) sweep(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) <= time.Minute {
+		return false
+	}
+	s.lastSweep = now
+	removed := false
+	for k, entry := range s.entries {
+		if entry.expired(now) {
+			delete(s.entries, k)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// finish : 서명한 트랜잭션이 있거나 성공한 요청만 결과를 보관, 아무것도 서명하지 못한 실패는 key 를 지워 다시 시도할 수 있게 함
+// (전송이 실패했어도 노드에는 도달했을 수 있으므로 서명한 트랜잭션이 있으면 다시 처리하지 않음)
+func (s *idempotencyStore) finish(key string, entry *idempotencyEntry, status int, contentType string, body []byte, now time.Time) {
+	entry.mu.Lock()
+	keep := entry.txHash != "" || status < http.StatusBadRequest
+	entry.status, entry.contentType, entry.body = status, contentType, body
+	entry.expires = now.Add(s.ttl)
+	record := entry.record()
+	entry.mu.Unlock()
+	close(entry.done)
+
+	if keep {
+		s.save(record)
+	} else {
+		s.mu.Lock()
+		if s.entries[key] == entry {
+			delete(s.entries, key)
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (e *idempotencyEntry) expired(now time.Time) bool {
+	select {
+	case <-e.done:
+	default:
+		return false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return now.After(e.expires)
+}
+
+// setTxHash : 전송할 트랜잭션 hash 는 전송 전에 파일에 기록 (응답 전에 종료되어도 다시 시작한 뒤 같은 key 로 다시 전송하지 않음)
+func (e *idempotencyEntry) setTxHash(hash string, now time.Time) {
+	e.mu.Lock()
+	e.txHash = hash
+	e.expires = now.Add(e.store.ttl)
+	record := e.record()
+	e.mu.Unlock()
+	e.store.save(record)
+}
+
+// idempotencyRecord : 파일에 기록하는 entry (같은 key 는 마지막 줄)
+type idempotencyRecord struct {
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"`
+	TxHash      string    `json:"txHash,omitempty"`
+	Status      int       `json:"status,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+	Expires     time.Time `json:"expires"`
+}
+
+func (e *idempotencyEntry) record() idempotencyRecord {
+	return idempotencyRecord{Key: e.key, Fingerprint: e.fingerprint, TxHash: e.txHash, Status: e.status, ContentType: e.contentType, Body: e.body, Expires: e.expires}
+}
+
+// save : 기록에 실패해도 요청은 처리 (메모리에는 남아 있으므로 다시 시작하기 전까지는 재전송하지 않음)
+func (s *idempotencyStore) save(record idempotencyRecord) {
+	if s.file == "" {
+		return
+	}
+	line, err := json.Marshal(record)
+	if err == nil {
+		s.fileMu.Lock()
+		err = appendLine(s.file, line)
+		s.fileMu.Unlock()
+	}
+	if err != nil {
+		logger.Error("Failed to save idempotency key", "file", s.file, "err", err)
+	}
+}
+
+// compact : 메모리에 남은 entry 로 파일을 다시 써서 만료된 기록 제거 (처리 중이고 아직 기록하지 않은 entry 는 제외)
+func (s *idempotencyStore) compact() {
+	if s.file == "" {
+		return
+	}
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+
+	s.mu.Lock()
+	records := make([]idempotencyRecord, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entry.mu.Lock()
+		if entry.txHash != "" || entry.status != 0 {
+			records = append(records, entry.record())
+		}
+		entry.mu.Unlock()
+	}
+	s.mu.Unlock()
+
+	if err := writeIdempotencyFile(s.file, records); err != nil {
+		logger.Error("Failed to compact idempotency file", "file", s.file, "err", err)
+	}
+}
+
+// writeIdempotencyFile : records 만 남기고 다시 씀 (임시 파일에 쓴 뒤 rename)
+func writeIdempotencyFile(path string, records []idempotencyRecord) error {
+	var buf bytes.Buffer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(line, '\n'))
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func appendLine(path string, line []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// openIdempotencyStore : file 에서 만료되지 않은 entry 를 읽고 최신 기록만 남겨 다시 씀 (쓰는 중에 종료되어 잘린 줄은 건너뜀)
+func openIdempotencyStore(ttl time.Duration, file string, now time.Time) (*idempotencyStore, error) {
+	s := newIdempotencyStore(ttl)
+	s.file = file
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records := map[string]idempotencyRecord{}
+	var keys []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record idempotencyRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			logger.Warn("Skipped malformed idempotency line", "file", file, "err", err)
+			continue
+		}
+		if _, exist := records[record.Key]; !exist {
+			keys = append(keys, record.Key)
+		}
+		records[record.Key] = record
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var kept []idempotencyRecord
+	for _, key := range keys {
+		record := records[key]
+		if !now.Before(record.Expires) {
+			continue
+		}
+		done := make(chan struct{})
+		close(done)
+		s.entries[key] = &idempotencyEntry{
+			store: s, key: key, fingerprint: record.Fingerprint, done: done,
+			txHash: record.TxHash, status: record.Status, contentType: record.ContentType, body: record.Body, expires: record.Expires,
+		}
+		kept = append(kept, record)
+	}
+	return s, writeIdempotencyFile(file, kept)
+}
+
+// SetIdempotency : Idempotency-Key 결과 보관 설정
+func (s *Server) SetIdempotency(conf IdempotencyConfig) error {
+	ttl := time.Duration(conf.TTLMinutes) * time.Minute
+	if conf.File == "" {
+		s.idempotency = newIdempotencyStore(ttl)
+		return nil
+	}
+	store, err := openIdempotencyStore(ttl, conf.File, time.Now())
+	if err != nil {
+		return err
+	}
+	s.idempotency = store
+	return nil
+}
+
+// idempotencyScope : key 를 구분하는 범위, 인증된 호출자별 (인증이 꺼져 있으면 모든 요청이 같은 범위)
+// IP 는 proxy, NAT 뒤에서 바뀌거나 겹치므로 사용하지 않음
+func idempotencyScope(c echo.Context) string {
+	if principal, ok := c.Get(principalKey).(Principal); ok {
+		return "principal:" + principal.Name
+	}
+	return "global"
+}
+
+// idempotencyPendingResponse : 첫 요청이 아직 처리 중일 때 재요청에 대한 202 응답
+// 전송 후 응답 전에 서버가 다시 시작되었으면 status 는 interrupted (결과는 txHash 로 조회)
+type idempotencyPendingResponse struct {
+	Status string `json:"status"`
+	TxHash string `json:"txHash,omitempty"`
+}
+
+// idempotent : Idempotency-Key 가 있으면 같은 호출자의 같은 key 재요청에 첫 요청의 결과를 그대로 응답
+// 첫 요청이 처리 중이면 202 와 전송한 트랜잭션 hash, 다른 요청에 같은 key 를 사용하면 422
+func (s *Server) idempotent(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return next(c)
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return echo.NewHTTPError(http.StatusBadRequest, "Idempotency-Key is too long")
+		}
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "failed to read request body")
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+		hash := sha256.Sum256(body)
+		fingerprint := c.Request().Method + " " + c.Request().URL.Path + " " + hex.EncodeToString(hash[:])
+
+		storeKey := idempotencyScope(c) + " " + key
+		entry, created := s.idempotency.begin(storeKey, fingerprint, time.Now())
+		if !created {
+			return replay(c, entry, fingerprint)
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = recorder
+		c.Set(idempotencyEntryKey, entry)
+
+		finished := false
+		defer func() {
+			// handler 가 panic 하면 처리 중으로 남지 않도록 종료 처리 (Recover middleware 가 500 응답)
+			if !finished {
+				s.idempotency.finish(storeKey, entry, http.StatusInternalServerError, "", nil, time.Now())
+			}
+		}()
+
+		err = next(c)
+		if err != nil {
+			// 오류 응답도 보관하기 위해 여기서 기록 (이후 error handler 는 이미 응답한 요청을 건너뜀)
+			c.Error(err)
+		}
+		s.idempotency.finish(storeKey, entry, c.Response().Status, c.Response().Header().Get(echo.HeaderContentType), recorder.body.Bytes(), time.Now())
+		finished = true
+		return err
+	}
+}
+
+func replay(c echo.Context, entry *idempotencyEntry, fingerprint string) error {
+	if entry.fingerprint != fingerprint {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+	}
+
+	c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	select {
+	case <-entry.done:
+		if entry.status == 0 {
+			return c.JSON(http.StatusAccepted, idempotencyPendingResponse{Status: "interrupted", TxHash: entry.txHash})
+		}
+		return c.Blob(entry.status, entry.contentType, entry.body)
+	default:
+		c.Response().Header().Set(echo.HeaderRetryAfter, "1")
+		return c.JSON(http.StatusAccepted, idempotencyPendingResponse{Status: "pending", TxHash: entry.txHash})
+	}
+}
+
+// responseRecorder : 응답을 보내면서 본문을 보관
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// idempotentTransactor : Idempotency-Key 요청이면 전송하는 트랜잭션 hash 를 entry 에 기록하여 처리 중 재요청에 응답
+func idempotentTransactor(c echo.Context, client backend.Transactor) backend.Transactor {
+	entry, ok := c.Get(idempotencyEntryKey).(*idempotencyEntry)
+	if !ok {
+		return client
+	}
+	return &sentRecorder{Transactor: client, entry: entry}
+}
+
+type sentRecorder struct {
+	backend.Transactor
+	entry *idempotencyEntry
+}
+
+// SendTransaction : 연결 오류여도 노드가 받았을 수 있으므로 전송 전에 기록
+func (r *sentRecorder) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	r.entry.setTxHash(tx.Hash().Hex(), time.Now())
+	return r.Transactor.SendTransaction(ctx, tx)
+}
@@ -0,0 +1,229 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	smartcontract "tiny-blockchain-app/smartcontract/golang"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveIdempotent(server *Server, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderIdempotencyKey, key)
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency_Transact(t *testing.T) {
+	sim, err := simulated.New(2)
+	assert.Equal(t, nil, err)
+	defer sim.Close()
+	owner, user := sim.Accounts[0], sim.Accounts[1]
+	token, erc20, err := sim.DeployERC20Burnable(owner, "Token", "TKN", 18)
+	assert.Equal(t, nil, err)
+
+	server := NewServer(sim, owner)
+	abiJSON, _ := json.Marshal(smartcontract.ERC20BurnableABI)
+	path := "/contracts/" + token.Hex() + "/transact"
+	body := `{"abi":` + string(abiJSON) + `,"method":"mint","args":["` + user.Address().Hex() + `","100"]}`
+
+	// receipt 를 기다리는 동안 같은 key 로 다시 요청하면 202 와 트랜잭션 hash
+	sim.SetAutoCommit(false)
+	first := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		first <- serveIdempotent(server, path, "mint-1", body)
+	}()
+
+	var pending idempotencyPendingResponse
+	assert.Eventually(t, func() bool {
+		rec := serveIdempotent(server, path, "mint-1", body)
+		if rec.Code != http.StatusAccepted || rec.Header().Get(HeaderIdempotentReplayed) != "true" {
+			return false
+		}
+		_ = json.Unmarshal(rec.Body.Bytes(), &pending)
+		return pending.TxHash != ""
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// 같은 key 를 다른 요청에 사용하면 422
+	other := strings.Replace(body, `"100"`, `"200"`, 1)
+	assert.Equal(t, http.StatusUnprocessableEntity, serveIdempotent(server, path, "mint-1", other).Code)
+
+	sim.Commit()
+	rec := <-first
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response transactResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, pending.TxHash, response.TxHash)
+
+	// 끝난 뒤 재요청은 같은 응답, 다시 전송하지 않음
+	sim.SetAutoCommit(true)
+	replayed := serveIdempotent(server, path, "mint-1", body)
+	assert.Equal(t, http.StatusOK, replayed.Code)
+	assert.Equal(t, "true", replayed.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, rec.Body.String(), replayed.Body.String())
+
+	// 인증이 꺼져 있으면 key 는 IP 와 관계없이 하나의 범위
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderIdempotencyKey, "mint-1")
+	req.RemoteAddr = "10.0.0.9:40000"
+	replayed = httptest.NewRecorder()
+	server.echo.ServeHTTP(replayed, req)
+	assert.Equal(t, "true", replayed.Header().Get(HeaderIdempotentReplayed))
+
+	balance, err := erc20.BalanceOf(&bind.CallOpts{Context: context.Background()}, user.Address())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, big.NewInt(100), balance)
+
+	// 다른 key 는 새 요청
+	assert.Equal(t, http.StatusOK, serveIdempotent(server, path, "mint-2", body).Code)
+	balance, err = erc20.BalanceOf(&bind.CallOpts{Context: context.Background()}, user.Address())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, big.NewInt(200), balance)
+}
+
+func TestIdempotency_FailureBeforeSend(t *testing.T) {
+	sim, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer sim.Close()
+	token, _, err := sim.DeployERC20Burnable(sim.Accounts[0], "Token", "TKN", 18)
+	assert.Equal(t, nil, err)
+
+	server := NewServer(sim, sim.Accounts[0])
+	path := "/contracts/" + token.Hex() + "/transact"
+	body := `{"abi":"[]","method":"mint"}`
+
+	// 트랜잭션을 전송하지 못한 실패는 보관하지 않으므로 같은 key 로 다시 처리
+	rec := serveIdempotent(server, path, "retry", body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serveIdempotent(server, path, "retry", body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "", rec.Header().Get(HeaderIdempotentReplayed))
+}
+
+// lostResponse : 노드에 전달한 뒤 응답을 받지 못한 것처럼 오류 반환
+type lostResponse struct {
+	backend.Backend
+}
+
+func (l *lostResponse) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := l.Backend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	return errors.New("connection reset")
+}
+
+func TestIdempotency_FailureAfterSign(t *testing.T) {
+	sim, err := simulated.New(2)
+	assert.Equal(t, nil, err)
+	defer sim.Close()
+	owner, user := sim.Accounts[0], sim.Accounts[1]
+	token, erc20, err := sim.DeployERC20Burnable(owner, "Token", "TKN", 18)
+	assert.Equal(t, nil, err)
+
+	server := NewServer(&lostResponse{Backend: sim}, owner)
+	abiJSON, _ := json.Marshal(smartcontract.ERC20BurnableABI)
+	path := "/contracts/" + token.Hex() + "/transact"
+	body := `{"abi":` + string(abiJSON) + `,"method":"mint","args":["` + user.Address().Hex() + `","100"]}`
+
+	// 전송이 실패해도 노드에 도달했을 수 있으므로 같은 key 로 다시 전송하지 않고 같은 오류 응답
+	rec := serveIdempotent(server, path, "lost", body)
+	replayed := serveIdempotent(server, path, "lost", body)
+	assert.Equal(t, rec.Code, replayed.Code)
+	assert.Equal(t, "true", replayed.Header().Get(HeaderIdempotentReplayed))
+
+	balance, err := erc20.BalanceOf(&bind.CallOpts{Context: context.Background()}, user.Address())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, big.NewInt(100), balance)
+}
+
+func TestIdempotencyStore_Expire(t *testing.T) {
+	store := newIdempotencyStore(time.Minute)
+	now := time.Now()
+
+	entry, created := store.begin("caller key", "fingerprint", now)
+	assert.True(t, created)
+	_, created = store.begin("caller key", "fingerprint", now.Add(time.Hour))
+	assert.False(t, created, "pending entry never expires")
+
+	store.finish("caller key", entry, http.StatusOK, "", nil, now)
+	_, created = store.begin("caller key", "fingerprint", now.Add(30*time.Second))
+	assert.False(t, created)
+	_, created = store.begin("caller key", "fingerprint", now.Add(2*time.Minute))
+	assert.True(t, created)
+}
+
+func TestIdempotencyStore_File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "idempotency.jsonl")
+	store, err := openIdempotencyStore(time.Minute, file, time.Now())
+	assert.Equal(t, nil, err)
+	now := time.Now()
+
+	// 전송 후 응답 전에 종료된 요청, 끝난 요청, 만료된 요청
+	sent, _ := store.begin("principal:a sent", "fingerprint", now)
+	sent.setTxHash("0x01", now)
+	finished, _ := store.begin("principal:a finished", "fingerprint", now)
+	store.finish("principal:a finished", finished, http.StatusOK, echo.MIMEApplicationJSON, []byte(`{"ok":true}`), now)
+	expired, _ := store.begin("principal:a expired", "fingerprint", now)
+	store.finish("principal:a expired", expired, http.StatusOK, "", nil, now.Add(-2*time.Minute))
+
+	reopened, err := openIdempotencyStore(time.Minute, file, now)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(reopened.entries))
+	entry, created := reopened.begin("principal:a sent", "fingerprint", now)
+	assert.False(t, created)
+	assert.Equal(t, "0x01", entry.txHash)
+	assert.Equal(t, 0, entry.status)
+	entry, created = reopened.begin("principal:a finished", "fingerprint", now)
+	assert.False(t, created)
+	assert.Equal(t, `{"ok":true}`, string(entry.body))
+	_, created = reopened.begin("principal:a expired", "fingerprint", now)
+	assert.True(t, created)
+
+	// 다시 시작하기 전에 전송한 요청은 다시 처리하지 않고 202 와 트랜잭션 hash
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+	assert.Equal(t, nil, replay(c, reopened.entries["principal:a sent"], "fingerprint"))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var pending idempotencyPendingResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &pending))
+	assert.Equal(t, idempotencyPendingResponse{Status: "interrupted", TxHash: "0x01"}, pending)
+}
+
+func TestIdempotencyStore_Compact(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "idempotency.jsonl")
+	store, err := openIdempotencyStore(time.Minute, file, time.Now())
+	assert.Equal(t, nil, err)
+	now := time.Now()
+
+	expired, _ := store.begin("principal:a expired", "fingerprint", now)
+	store.finish("principal:a expired", expired, http.StatusOK, "", nil, now)
+	sent, _ := store.begin("principal:a sent", "fingerprint", now)
+	sent.setTxHash("0x01", now.Add(2*time.Minute))
+
+	// 실행 중에도 만료된 기록을 지우면 파일을 다시 씀
+	_, created := store.begin("principal:a new", "fingerprint", now.Add(2*time.Minute))
+	assert.True(t, created)
+	records, err := os.ReadFile(file)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, strings.Count(string(records), "\n"))
+	assert.True(t, strings.Contains(string(records), `"principal:a sent"`))
+}
@@ -0,0 +1,98 @@
+package restapi
+
+import (
+	"net/http"
+	"strconv"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+)
+
+var journalEntrySchema = object(nil, map[string]*Schema{
+	"hash":       hexSchema(""),
+	"from":       addressSchema(""),
+	"nonce":      {Type: "integer"},
+	"to":         addressSchema(""),
+	"value":      amountSchema("Wei"),
+	"requester":  stringSchema("Authenticated user that sent the transaction"),
+	"requestId":  stringSchema("Request correlation ID"),
+	"status":     stringSchema("pending, mined, reverted, dropped, rejected or replaced"),
+	"replacedBy": hexSchema("Transaction that replaced this one with the same nonce"),
+	"attempts":   {Type: "integer", Description: "Number of sends including rebroadcasts"},
+	"block":      {Type: "integer"},
+	"error":      stringSchema("Last send error or drop reason"),
+	"createdAt":  {Type: "string", Format: "date-time"},
+	"updatedAt":  {Type: "string", Format: "date-time"},
+	"sentAt":     {Type: "string", Format: "date-time", Description: "Last successful send"},
+})
+
+var listJournalOperation = Operation{
+	Summary:     "Signed transactions recorded before sending, oldest first",
+	Description: "Pending entries are rebroadcast while the node does not know them. The signed raw transaction is not returned.",
+	Tags:        []string{"transactions"},
+	Role:        RoleViewer,
+	Parameters: []Parameter{
+		queryParam("status", &Schema{Type: "string", Pattern: "^(pending|mined|reverted|dropped|rejected|replaced)$"}),
+		queryParam("from", addressSchema("Sender")),
+		queryParam("limit", &Schema{Type: "integer", Minimum: new(float64), Description: "Most recent entries (0 for all)"}),
+	},
+	Responses: map[string]Response{
+		"200": jsonResponse("Journal entries", &Schema{Type: "array", Items: journalEntrySchema}),
+		"503": jsonResponse("Journal is not configured", errorSchema),
+	},
+}
+
+var journalEntryOperation = Operation{
+	Summary:    "Journal entry of a signed transaction",
+	Tags:       []string{"transactions"},
+	Role:       RoleViewer,
+	Parameters: []Parameter{txHashParam},
+	Responses: map[string]Response{
+		"200": jsonResponse("Journal entry", journalEntrySchema),
+		"404": jsonResponse("Transaction is not in the journal", errorSchema),
+		"503": jsonResponse("Journal is not configured", errorSchema),
+	},
+}
+
+// SetJournal : 전송한 트랜잭션 기록 조회에 사용할 journal 설정 (설정하지 않으면 503 응답)
+func (s *Server) SetJournal(j *journal.Journal) {
+	s.journal = j
+}
+
+// GET /journal
+func (s *Server) listJournal(c echo.Context) error {
+	if s.journal == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "journal is not configured")
+	}
+	filter := journal.Filter{Status: journal.Status(c.QueryParam("status"))}
+	if from := c.QueryParam("from"); from != "" {
+		address := common.HexToAddress(from)
+		filter.From = &address
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		filter.Limit, _ = strconv.Atoi(limit)
+	}
+	entries := s.journal.List(filter)
+	if entries == nil {
+		entries = []journal.Entry{}
+	}
+	for i := range entries {
+		entries[i].RawTx = nil
+	}
+	return c.JSON(http.StatusOK, entries)
+}
+
+// GET /journal/:hash
+func (s *Server) journalEntry(c echo.Context) error {
+	if s.journal == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "journal is not configured")
+	}
+	entry := s.journal.Entry(common.HexToHash(c.Param("hash")))
+	if entry == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "transaction is not in the journal")
+	}
+	// 서명된 원본은 누구나 다시 전송할 수 있으므로 응답에서 제외
+	entry.RawTx = nil
+	return c.JSON(http.StatusOK, entry)
+}
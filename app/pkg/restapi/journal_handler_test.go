@@ -0,0 +1,92 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalHandler(t *testing.T) {
+	sim, err := simulated.New(2)
+	assert.Equal(t, nil, err)
+	defer sim.Close()
+	sim.SetTxPool(true)
+	sim.SetAutoCommit(false)
+
+	txJournal := journal.New(sim)
+	server := NewServer(sim, sim.Accounts[0])
+	server.SetTxManager(txmanager.New(txJournal.Backend()))
+	server.SetJournal(txJournal)
+	auth, err := NewAuthenticator(AuthConfig{
+		Enabled: true,
+		APIKeys: []APIKeyConfig{
+			{Name: "viewer", KeySha256: HashAPIKey("viewer-key"), Role: RoleViewer},
+			{Name: "operator", KeySha256: HashAPIKey("operator-key"), Role: RoleOperator, Accounts: []string{AnyAccount}},
+		},
+	})
+	assert.Equal(t, nil, err)
+	server.SetAuth(auth)
+	viewer := map[string]string{"X-API-Key": "viewer-key"}
+
+	ctx := context.Background()
+	chainID, _ := sim.ChainID(ctx)
+	price, _ := sim.SuggestGasPrice(ctx)
+	tx, err := sim.Accounts[0].SignTx(types.NewTransaction(0, common.HexToAddress("0x1000"), big.NewInt(1), params.TxGas, price, nil), chainID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, txJournal.Backend().SendTransaction(ctx, tx))
+
+	// 인증된 사용자가 보낸 교체 트랜잭션은 요청자와 함께 기록
+	rec := serveFrom(server, http.MethodPost, "/transactions/"+tx.Hash().Hex()+"/speedup", "192.0.2.1", map[string]string{"X-API-Key": "operator-key"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var replaced replaceResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &replaced))
+
+	// 교체된 원본은 replaced
+	rec = serveFrom(server, http.MethodGet, "/journal?from="+sim.Accounts[0].Address().Hex(), "192.0.2.1", viewer)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var entries []journal.Entry
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &entries))
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "", entries[0].Requester)
+	assert.Equal(t, journal.StatusReplaced, entries[0].Status)
+	assert.Equal(t, replaced.TxHash, entries[0].ReplacedBy.Hex())
+	assert.Equal(t, replaced.TxHash, entries[1].Hash.Hex())
+	assert.Equal(t, journal.StatusPending, entries[1].Status)
+	assert.Equal(t, 0, len(entries[1].RawTx))
+	assert.NotContains(t, rec.Body.String(), "rawTx")
+	assert.Equal(t, "operator", entries[1].Requester)
+	assert.NotEqual(t, "", entries[1].RequestID)
+
+	rec = serveFrom(server, http.MethodGet, "/journal?limit=1", "192.0.2.1", viewer)
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &entries))
+	assert.Equal(t, 1, len(entries))
+	rec = serveFrom(server, http.MethodGet, "/journal?status=mined", "192.0.2.1", viewer)
+	assert.Equal(t, "[]\n", rec.Body.String())
+
+	rec = serveFrom(server, http.MethodGet, "/journal/"+tx.Hash().Hex(), "192.0.2.1", viewer)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var entry journal.Entry
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &entry))
+	assert.Equal(t, tx.Hash(), entry.Hash)
+	assert.Equal(t, journal.StatusReplaced, entry.Status)
+	assert.NotContains(t, rec.Body.String(), "rawTx")
+
+	rec = serveFrom(server, http.MethodGet, "/journal/"+common.HexToHash("0x01").Hex(), "192.0.2.1", viewer)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = serveFrom(server, http.MethodGet, "/journal?status=unknown&limit=-1", "192.0.2.1", viewer)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serveFrom(server, http.MethodGet, "/journal", "192.0.2.1", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = serve(NewServer(nil, nil), http.MethodGet, "/journal")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
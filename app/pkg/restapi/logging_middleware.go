@@ -0,0 +1,37 @@
+package restapi
+
+import (
+	"net/http"
+	"time"
+	"tiny-blockchain-app/app/pkg/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+var logger = logging.New("restapi")
+
+// requestLogger : X-Request-ID를 correlation ID로 요청 context에 전달하고 요청 결과를 기록
+// 같은 ID가 트랜잭션 전송, RPC 추적 로그에도 requestId로 남음
+func requestLogger(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+		req := c.Request()
+		c.SetRequest(req.WithContext(logging.WithRequestID(req.Context(), requestID)))
+
+		start := time.Now()
+		err := next(c)
+
+		status := responseStatus(c, err)
+		reqLogger := logging.FromContext(c.Request().Context(), logger).New(
+			"method", req.Method, "path", req.URL.Path, "status", status, "elapsed", time.Since(start))
+		switch {
+		case status >= http.StatusInternalServerError:
+			reqLogger.Error("Request failed", "err", err)
+		case err != nil:
+			reqLogger.Warn("Request rejected", "err", err)
+		default:
+			reqLogger.Info("Request handled")
+		}
+		return err
+	}
+}
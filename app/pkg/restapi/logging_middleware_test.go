@@ -0,0 +1,27 @@
+package restapi
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"tiny-blockchain-app/app/pkg/logging"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logging.Setup(&buf, false, "logfmt")
+	defer logging.Setup(nil, false, "")
+
+	server := NewServer(nil, nil)
+	rec := serve(server, http.MethodGet, "/")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	requestID := rec.Header().Get(echo.HeaderXRequestID)
+	assert.NotEqual(t, "", requestID)
+	assert.True(t, strings.Contains(buf.String(), "requestId="+requestID))
+	assert.True(t, strings.Contains(buf.String(), "Request handled"))
+}
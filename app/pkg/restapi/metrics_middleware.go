@@ -0,0 +1,41 @@
+package restapi
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"tiny-blockchain-app/app/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+// metricsMiddleware : 요청 수와 지연 시간을 경로 패턴(/contracts/:address/call 등) 단위로 기록
+func metricsMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		status := responseStatus(c, err)
+
+		// 등록되지 않은 경로는 label 수가 늘어나지 않도록 하나로 묶음
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request().Method
+		metrics.HTTPRequests.Inc(method, route, strconv.Itoa(status))
+		metrics.HTTPDuration.Observe(time.Since(start).Seconds(), method, route)
+		return err
+	}
+}
+
+// responseStatus : 핸들러가 오류를 반환하면 응답이 아직 쓰이지 않았으므로 오류의 상태 코드 사용
+func responseStatus(c echo.Context, err error) int {
+	if err == nil {
+		return c.Response().Status
+	}
+	if httpErr, ok := err.(*echo.HTTPError); ok {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
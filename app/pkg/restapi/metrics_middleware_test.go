@@ -0,0 +1,27 @@
+package restapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	server := NewServer(nil, nil)
+
+	rec := serve(server, http.MethodGet, "/")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = serve(server, http.MethodDelete, "/raft/peers/2")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = serve(server, http.MethodGet, "/metrics")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain"))
+
+	body := rec.Body.String()
+	assert.True(t, strings.Contains(body, `tba_http_requests_total{method="GET",route="/",status="200"}`))
+	assert.True(t, strings.Contains(body, `tba_http_requests_total{method="DELETE",route="/raft/peers/:raftId",status="503"}`))
+	assert.True(t, strings.Contains(body, "# TYPE tba_rpc_requests_total counter"))
+}
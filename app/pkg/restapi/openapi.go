@@ -0,0 +1,246 @@
+package restapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OpenAPI 3 문서 (route 를 등록할 때 함께 작성, GET /openapi.json)
+
+type OpenAPI struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+type Operation struct {
+	Summary     string      `json:"summary"`
+	Description string      `json:"description,omitempty"`
+	Tags        []string    `json:"tags,omitempty"`
+	Parameters  []Parameter `json:"parameters,omitempty"`
+	// RequestBody : application/json 요청 본문, 처리 전에 검증
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+	// Role : 필요한 역할 (비어있으면 인증 없이 접근 가능)
+	Role     string                `json:"x-role,omitempty"`
+	Security []map[string][]string `json:"security,omitempty"`
+	// Idempotent : Idempotency-Key 헤더로 재요청 시 첫 요청의 결과를 응답
+	Idempotent bool `json:"-"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Schema : OpenAPI schema 중 검증에 사용하는 항목
+// Format : address(EIP-55 체크섬 확인), uint256(10진수 또는 0x 16진수 문자열), hex(0x 바이트)
+type Schema struct {
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	AnyOf       []*Schema          `json:"anyOf,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	Example     interface{}        `json:"example,omitempty"`
+}
+
+// jsonBody : application/json 요청 본문
+func jsonBody(schema *Schema) *RequestBody {
+	return &RequestBody{Required: true, Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: schema}}}
+}
+
+// jsonResponse : application/json 응답 (schema 가 nil 이면 본문 형식 생략)
+func jsonResponse(description string, schema *Schema) Response {
+	if schema == nil {
+		return Response{Description: description}
+	}
+	return Response{Description: description, Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: schema}}}
+}
+
+func pathParam(name string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "path", Required: true, Schema: schema}
+}
+
+func queryParam(name string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Schema: schema}
+}
+
+func object(required []string, properties map[string]*Schema) *Schema {
+	return &Schema{Type: "object", Required: required, Properties: properties}
+}
+
+func stringSchema(description string) *Schema {
+	return &Schema{Type: "string", Description: description}
+}
+
+func addressSchema(description string) *Schema {
+	return &Schema{Type: "string", Format: "address", Description: description, Example: "0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448"}
+}
+
+func amountSchema(description string) *Schema {
+	return &Schema{Type: "string", Format: "uint256", Description: description, Example: "1000"}
+}
+
+func hexSchema(description string) *Schema {
+	return &Schema{Type: "string", Format: "hex", Description: description}
+}
+
+func integerSchema(minimum, maximum float64) *Schema {
+	return &Schema{Type: "integer", Minimum: &minimum, Maximum: &maximum}
+}
+
+// errorSchema : echo.HTTPError 응답
+var errorSchema = object(nil, map[string]*Schema{"message": {}})
+
+func newOpenAPI() *OpenAPI {
+	return &OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: "Tiny Blockchain App REST API", Version: "1.0.0"},
+		Paths:   map[string]map[string]Operation{},
+		Components: Components{SecuritySchemes: map[string]SecurityScheme{
+			"apiKey":    {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			"bearerJWT": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		}},
+	}
+}
+
+// idempotencyKeyParam : Idempotent 요청에 추가하는 header parameter
+var idempotencyKeyParam = Parameter{
+	Name:   HeaderIdempotencyKey,
+	In:     "header",
+	Schema: &Schema{Type: "string", Description: "Client generated unique key (max 255 characters), a retry with the same key returns the first result"},
+}
+
+// handle : route 와 문서를 함께 등록
+// 인증(op.Role) → middlewares(요청 제한 등) → 재요청 확인(op.Idempotent) → 요청 검증(path parameter, 본문) → handler 순으로 실행
+func (s *Server) handle(method, path string, handler echo.HandlerFunc, op Operation, middlewares ...echo.MiddlewareFunc) {
+	chain := make([]echo.MiddlewareFunc, 0, len(middlewares)+3)
+	if op.Role != "" {
+		chain = append(chain, s.require(op.Role))
+		op.Security = []map[string][]string{{"apiKey": {}}, {"bearerJWT": {}}}
+	}
+	chain = append(chain, middlewares...)
+	if op.Idempotent {
+		chain = append(chain, s.idempotent)
+	}
+	chain = append(chain, validateRequest(op))
+	s.echo.Add(method, path, handler, chain...)
+
+	// 공유하는 Operation 변수를 수정하지 않도록 복사
+	responses := make(map[string]Response, len(op.Responses)+6)
+	for code, response := range op.Responses {
+		responses[code] = response
+	}
+	op.Responses = responses
+	if op.Idempotent {
+		op.Parameters = append(append([]Parameter(nil), op.Parameters...), idempotencyKeyParam)
+		op.Responses["202"] = jsonResponse("Retry while the first request with the same Idempotency-Key is still in progress", object(nil, map[string]*Schema{
+			"status": stringSchema("pending, or interrupted if the server restarted after sending"),
+			"txHash": hexSchema("Transaction hash once sent"),
+		}))
+		op.Responses["422"] = jsonResponse("Idempotency-Key was used for a different request", errorSchema)
+	}
+	if len(op.Parameters) > 0 || op.RequestBody != nil {
+		op.Responses["400"] = jsonResponse("Invalid request", validationErrorSchema)
+	}
+	if op.Role != "" {
+		op.Responses["401"] = jsonResponse("Missing or invalid credentials", errorSchema)
+		op.Responses["403"] = jsonResponse("Role "+op.Role+" or signing account is not allowed", errorSchema)
+		op.Responses["429"] = jsonResponse("Rate limit exceeded (see Retry-After)", errorSchema)
+	}
+
+	openAPIPath := openAPIPath(path)
+	if s.openAPI.Paths[openAPIPath] == nil {
+		s.openAPI.Paths[openAPIPath] = map[string]Operation{}
+	}
+	s.openAPI.Paths[openAPIPath][strings.ToLower(method)] = op
+}
+
+// openAPIPath : /contracts/:address/call → /contracts/{address}/call
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+var openAPIOperation = Operation{
+	Summary:   "This OpenAPI document",
+	Tags:      []string{"docs"},
+	Responses: map[string]Response{"200": jsonResponse("OpenAPI 3 document", &Schema{Type: "object"})},
+}
+
+var docsOperation = Operation{
+	Summary:   "API documentation UI",
+	Tags:      []string{"docs"},
+	Responses: map[string]Response{"200": {Description: "Swagger UI page"}},
+}
+
+// GET /openapi.json
+func (s *Server) openAPIDocument(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.openAPI)
+}
+
+// docsPage : /openapi.json 을 읽는 Swagger UI
+const docsPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>Tiny Blockchain App REST API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});</script>
+</body>
+</html>`
+
+// GET /docs
+func docs(c echo.Context) error {
+	return c.HTML(http.StatusOK, docsPage)
+}
@@ -0,0 +1,99 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPI_Document(t *testing.T) {
+	server := NewServer(nil, nil)
+
+	rec := serve(server, http.MethodGet, "/openapi.json")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var document OpenAPI
+	err := json.Unmarshal(rec.Body.Bytes(), &document)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "3.0.3", document.OpenAPI)
+
+	// 등록된 모든 route 가 문서에 있어야 함
+	for _, route := range server.echo.Routes() {
+		operations, exist := document.Paths[openAPIPath(route.Path)]
+		if !assert.True(t, exist, route.Path) {
+			continue
+		}
+		_, exist = operations[strings.ToLower(route.Method)]
+		assert.True(t, exist, route.Method+" "+route.Path)
+	}
+
+	transact := document.Paths["/contracts/{address}/transact"]["post"]
+	assert.Equal(t, RoleOperator, transact.Role)
+	assert.Equal(t, []string{"abi", "method"}, transact.RequestBody.Content["application/json"].Schema.Required)
+	assert.Equal(t, "address", transact.Parameters[0].Schema.Format)
+	_, exist := transact.Responses["400"]
+	assert.True(t, exist)
+	_, exist = transact.Responses["401"]
+	assert.True(t, exist)
+
+	health := document.Paths["/health"]["get"]
+	assert.Equal(t, 0, len(health.Security))
+
+	rec = serve(server, http.MethodGet, "/docs")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), "/openapi.json"))
+}
+
+func TestOpenAPI_Validation(t *testing.T) {
+	server := NewServer(nil, nil)
+	const contract = "/contracts/0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9"
+
+	cases := []struct {
+		name   string
+		path   string
+		body   string
+		errors []string
+	}{
+		{"bad checksum", "/contracts/0xb9d171F81716ee2Ce29b85Ba44B3966992512Ec9/call", `{"abi":"[]","method":"balanceOf"}`,
+			[]string{"address: invalid EIP-55 checksum"}},
+		{"missing fields", contract + "/transact", `{"args":[]}`,
+			[]string{"body.abi: is required", "body.method: is required"}},
+		{"bad amount and from", contract + "/transact", `{"abi":"[]","method":"deposit","value":"1.5","from":"0x1234"}`,
+			[]string{"body.from: must be a 20-byte hex address", "body.value: must be a non-negative 256-bit integer (decimal or 0x hex)"}},
+		{"wrong type", contract + "/call", `{"abi":1,"method":"balanceOf"}`,
+			[]string{"body.abi: does not match any allowed schema"}},
+		{"invalid json", contract + "/call", `{"abi":`,
+			[]string{"body: invalid JSON"}},
+		{"bad signature", "/signatures/verify", `{"address":"0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9","signature":"zz","message":"hi"}`,
+			[]string{"body.signature: must be 0x-prefixed hex bytes"}},
+		{"bad enode", "/raft/peers", `{"enode":"http://node","learner":"yes"}`,
+			[]string{"body.enode: must match ^enode://", "body.learner: must be a boolean"}},
+		{"raft id range", "/raft/peers/70000/promote", ``,
+			[]string{"raftId: must be <= 65535"}},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		server.echo.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, tc.name)
+		var response validationError
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Equal(t, nil, err, tc.name)
+		assert.Equal(t, tc.errors, response.Errors, tc.name)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	assert.Equal(t, nil, validateAddress("0xb9D171F81716ee2Ce29b85Ba44B3966992512Ec9"))
+	assert.Equal(t, nil, validateAddress("0xb9d171f81716ee2ce29b85ba44b3966992512ec9"))
+	assert.Equal(t, nil, validateAddress("0xB9D171F81716EE2CE29B85BA44B3966992512EC9"))
+	assert.NotEqual(t, nil, validateAddress("0xb9d171F81716ee2Ce29b85Ba44B3966992512Ec9"))
+	assert.NotEqual(t, nil, validateAddress("0xb9D171F81716ee2Ce29b85Ba44B3966992512E"))
+}
@@ -0,0 +1,214 @@
+package restapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"tiny-blockchain-app/app/pkg/blockchain/quorum"
+
+	"github.com/labstack/echo/v4"
+)
+
+type addPeerRequest struct {
+	Enode   string `json:"enode"`
+	Learner bool   `json:"learner"`
+}
+
+type addPeerResponse struct {
+	RaftID uint16 `json:"raftId"`
+	Role   string `json:"role"`
+}
+
+var raftIDParam = pathParam("raftId", integerSchema(1, 65535))
+
+var raftClusterOperation = Operation{
+	Summary: "Raft cluster members",
+	Tags:    []string{"raft"},
+	Role:    RoleViewer,
+	Responses: map[string]Response{
+		"200": jsonResponse("Cluster members", &Schema{Type: "array", Items: &Schema{Type: "object"}}),
+		"502": jsonResponse("Node request failed", errorSchema),
+		"503": jsonResponse("Raft client is not configured", errorSchema),
+	},
+}
+
+var raftLeaderOperation = Operation{
+	Summary: "Raft leader enode id",
+	Tags:    []string{"raft"},
+	Role:    RoleViewer,
+	Responses: map[string]Response{
+		"200": jsonResponse("Leader", object(nil, map[string]*Schema{"leader": stringSchema("")})),
+		"502": jsonResponse("Node request failed", errorSchema),
+		"503": jsonResponse("Raft client is not configured", errorSchema),
+	},
+}
+
+var raftRoleOperation = Operation{
+	Summary: "Raft role of the connected node",
+	Tags:    []string{"raft"},
+	Role:    RoleViewer,
+	Responses: map[string]Response{
+		"200": jsonResponse("Role", object(nil, map[string]*Schema{"role": stringSchema("minter, verifier or learner")})),
+		"502": jsonResponse("Node request failed", errorSchema),
+		"503": jsonResponse("Raft client is not configured", errorSchema),
+	},
+}
+
+var raftAddPeerOperation = Operation{
+	Summary: "Add a raft peer or learner",
+	Tags:    []string{"raft"},
+	Role:    RoleAdmin,
+	RequestBody: jsonBody(object([]string{"enode"}, map[string]*Schema{
+		"enode":   {Type: "string", Pattern: "^enode://", Description: "enode URL with raftport"},
+		"learner": {Type: "boolean", Description: "Add as a non-voting learner"},
+	})),
+	Idempotent: true,
+	Responses: map[string]Response{
+		"201": jsonResponse("Added", object(nil, map[string]*Schema{"raftId": {Type: "integer"}, "role": stringSchema("")})),
+		"502": jsonResponse("Node request failed", errorSchema),
+		"503": jsonResponse("Raft client is not configured", errorSchema),
+	},
+}
+
+var raftPromotePeerOperation = Operation{
+	Summary:    "Promote a learner to a verifier",
+	Tags:       []string{"raft"},
+	Role:       RoleAdmin,
+	Parameters: []Parameter{raftIDParam},
+	Idempotent: true,
+	Responses: map[string]Response{
+		"200": jsonResponse("Promoted", object(nil, map[string]*Schema{"promoted": {Type: "boolean"}})),
+		"502": jsonResponse("Node request failed", errorSchema),
+		"503": jsonResponse("Raft client is not configured", errorSchema),
+	},
+}
+
+var raftRemovePeerOperation = Operation{
+	Summary:    "Remove a raft member",
+	Tags:       []string{"raft"},
+	Role:       RoleAdmin,
+	Parameters: []Parameter{raftIDParam},
+	Idempotent: true,
+	Responses: map[string]Response{
+		"204": jsonResponse("Removed", nil),
+		"502": jsonResponse("Node request failed", errorSchema),
+		"503": jsonResponse("Raft client is not configured", errorSchema),
+	},
+}
+
+// GET /raft/cluster
+func (s *Server) raftCluster(c echo.Context) error {
+	raft, err := s.raftClient()
+	if err != nil {
+		return err
+	}
+	members, err := raft.Cluster(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusOK, members)
+}
+
+// GET /raft/leader
+func (s *Server) raftLeader(c echo.Context) error {
+	raft, err := s.raftClient()
+	if err != nil {
+		return err
+	}
+	leader, err := raft.Leader(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusOK, map[string]string{"leader": leader})
+}
+
+// GET /raft/role
+func (s *Server) raftRole(c echo.Context) error {
+	raft, err := s.raftClient()
+	if err != nil {
+		return err
+	}
+	role, err := raft.Role(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusOK, map[string]string{"role": role})
+}
+
+// POST /raft/peers
+func (s *Server) raftAddPeer(c echo.Context) error {
+	raft, err := s.raftClient()
+	if err != nil {
+		return err
+	}
+
+	var request addPeerRequest
+	if err := c.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	response := addPeerResponse{Role: quorum.RoleVerifier}
+	if request.Learner {
+		response.Role = quorum.RoleLearner
+		response.RaftID, err = raft.AddLearner(c.Request().Context(), request.Enode)
+	} else {
+		response.RaftID, err = raft.AddPeer(c.Request().Context(), request.Enode)
+	}
+	if errors.Is(err, quorum.ErrInvalidEnode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+// POST /raft/peers/:raftId/promote
+func (s *Server) raftPromotePeer(c echo.Context) error {
+	raft, err := s.raftClient()
+	if err != nil {
+		return err
+	}
+	raftID, err := parseRaftID(c)
+	if err != nil {
+		return err
+	}
+
+	promoted, err := raft.PromoteToPeer(c.Request().Context(), raftID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusOK, map[string]bool{"promoted": promoted})
+}
+
+// DELETE /raft/peers/:raftId
+func (s *Server) raftRemovePeer(c echo.Context) error {
+	raft, err := s.raftClient()
+	if err != nil {
+		return err
+	}
+	raftID, err := parseRaftID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := raft.RemovePeer(c.Request().Context(), raftID); err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) raftClient() (*quorum.RaftClient, error) {
+	if s.raft == nil {
+		return nil, echo.NewHTTPError(http.StatusServiceUnavailable, "raft client is not configured")
+	}
+	return s.raft, nil
+}
+
+func parseRaftID(c echo.Context) (uint16, error) {
+	raftID, err := strconv.ParseUint(c.Param("raftId"), 10, 16)
+	if err != nil || raftID == 0 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "invalid raft id")
+	}
+	return uint16(raftID), nil
+}
@@ -0,0 +1,80 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tiny-blockchain-app/app/pkg/blockchain/quorum"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRaftServer(t *testing.T) *Server {
+	rpcServer := rpc.NewServer()
+	err := rpcServer.RegisterName("raft", quorum.NewStubRaftService(
+		quorum.ClusterMember{RaftID: 1, NodeID: strings.Repeat("a", 128), IP: "172.16.239.11", P2PPort: 30300, RaftPort: 63000, Role: quorum.RoleMinter, NodeActive: true},
+	))
+	assert.Equal(t, nil, err)
+
+	raft := quorum.NewRaftClient(rpc.DialInProc(rpcServer))
+	t.Cleanup(func() {
+		raft.Close()
+		rpcServer.Stop()
+	})
+
+	server := NewServer(nil, nil)
+	server.SetRaftClient(raft)
+	return server
+}
+
+func serve(server *Server, method string, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRaftMembership(t *testing.T) {
+	server := newRaftServer(t)
+	enode := "enode://" + strings.Repeat("b", 128) + "@172.16.239.12:30300?discport=0&raftport=63000"
+
+	rec := postJSON(server, "/raft/peers", map[string]interface{}{"enode": enode, "learner": true})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var added addPeerResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &added))
+	assert.Equal(t, uint16(2), added.RaftID)
+	assert.Equal(t, quorum.RoleLearner, added.Role)
+
+	rec = serve(server, http.MethodPost, "/raft/peers/2/promote")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(server, http.MethodGet, "/raft/cluster")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var members []quorum.ClusterMember
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &members))
+	assert.Equal(t, 2, len(members))
+	assert.Equal(t, quorum.RoleVerifier, members[1].Role)
+
+	rec = serve(server, http.MethodDelete, "/raft/peers/2")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = serve(server, http.MethodGet, "/raft/leader")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), strings.Repeat("a", 128)))
+}
+
+func TestRaftMembership_Invalid(t *testing.T) {
+	server := newRaftServer(t)
+
+	rec := postJSON(server, "/raft/peers", map[string]interface{}{"enode": "enode://abc@127.0.0.1:30300"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(server, http.MethodDelete, "/raft/peers/abc")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(NewServer(nil, nil), http.MethodGet, "/raft/cluster")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -0,0 +1,214 @@
+package restapi
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"tiny-blockchain-app/app/pkg/metrics"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig : 호출자(API key 또는 JWT sub)와 IP 별 요청 제한
+type RateLimitConfig struct {
+	Enabled bool
+	// Read : 조회 요청 (status, call, 서명 검증, raft 조회)
+	Read LimitConfig
+	// Transaction : 트랜잭션 전송과 노드 관리 요청
+	Transaction LimitConfig
+	// MaxPendingPerAccount : 서명 계정별 receipt 를 기다리는 트랜잭션 수 제한 (0이면 제한 없음)
+	MaxPendingPerAccount int
+	// TrustedProxies : 이 대역(CIDR)에서 온 요청만 X-Forwarded-For 의 IP 를 사용 (없으면 연결한 IP)
+	TrustedProxies []string
+}
+
+// LimitConfig : 초당 RequestsPerSec 회, 최대 Burst 회 연속 (RequestsPerSec 0이면 제한 없음)
+type LimitConfig struct {
+	RequestsPerSec float64
+	Burst          int
+}
+
+// limiterIdleTimeout : 이 시간 동안 요청이 없는 호출자의 limiter 는 삭제
+const limiterIdleTimeout = 10 * time.Minute
+
+// rateLimiter : 호출자별 token bucket
+type rateLimiter struct {
+	name      string
+	conf      LimitConfig
+	mu        sync.Mutex
+	callers   map[string]*callerLimiter
+	lastSweep time.Time
+}
+
+type callerLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newRateLimiter(name string, conf LimitConfig) *rateLimiter {
+	if conf.Burst < 1 {
+		conf.Burst = 1
+	}
+	return &rateLimiter{name: name, conf: conf, callers: map[string]*callerLimiter{}, lastSweep: time.Now()}
+}
+
+// reserve : 모든 callers 가 허용되면 0, 아니면 다시 시도할 수 있을 때까지의 시간 (거부되면 어느 호출자의 요청 수도 차감하지 않음)
+func (l *rateLimiter) reserve(now time.Time, callers ...string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > limiterIdleTimeout {
+		for key, c := range l.callers {
+			if now.Sub(c.lastSeen) > limiterIdleTimeout {
+				delete(l.callers, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	reservations := make([]*rate.Reservation, 0, len(callers))
+	var delay time.Duration
+	for _, caller := range callers {
+		c, exist := l.callers[caller]
+		if !exist {
+			c = &callerLimiter{limiter: rate.NewLimiter(rate.Limit(l.conf.RequestsPerSec), l.conf.Burst)}
+			l.callers[caller] = c
+		}
+		c.lastSeen = now
+
+		reservation := c.limiter.ReserveN(now, 1)
+		reservations = append(reservations, reservation)
+		if d := reservation.DelayFrom(now); d > delay {
+			delay = d
+		}
+	}
+	if delay > 0 {
+		for _, reservation := range reservations {
+			reservation.CancelAt(now)
+		}
+	}
+	return delay
+}
+
+// pendingLimiter : 서명 계정별 진행 중인 트랜잭션 수
+type pendingLimiter struct {
+	max     int
+	mu      sync.Mutex
+	pending map[common.Address]int
+}
+
+func newPendingLimiter(max int) *pendingLimiter {
+	return &pendingLimiter{max: max, pending: map[common.Address]int{}}
+}
+
+// acquire : 한도를 넘으면 false, 성공하면 트랜잭션이 끝난 뒤 release 호출
+func (l *pendingLimiter) acquire(account common.Address) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.pending[account] >= l.max {
+		return false
+	}
+	l.pending[account]++
+	return true
+}
+
+func (l *pendingLimiter) release(account common.Address) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pending[account]--
+	if l.pending[account] <= 0 {
+		delete(l.pending, account)
+	}
+}
+
+// SetRateLimit : 요청 제한 설정 (설정하지 않거나 Enabled 가 false 이면 제한 없음)
+func (s *Server) SetRateLimit(conf RateLimitConfig) error {
+	extractor, err := ipExtractor(conf.TrustedProxies)
+	if err != nil {
+		return err
+	}
+	s.echo.IPExtractor = extractor
+
+	s.readLimiter, s.txLimiter, s.pendingLimiter = nil, nil, nil
+	if !conf.Enabled {
+		return nil
+	}
+	if conf.Read.RequestsPerSec > 0 {
+		s.readLimiter = newRateLimiter("read", conf.Read)
+	}
+	if conf.Transaction.RequestsPerSec > 0 {
+		s.txLimiter = newRateLimiter("transaction", conf.Transaction)
+	}
+	if conf.MaxPendingPerAccount > 0 {
+		s.pendingLimiter = newPendingLimiter(conf.MaxPendingPerAccount)
+	}
+	return nil
+}
+
+// ipExtractor : 요청 IP 를 구하는 방식, 신뢰하는 proxy 가 없으면 헤더를 무시하고 연결한 IP 사용
+// (X-Forwarded-For, X-Real-IP 는 누구나 보낼 수 있어 그대로 쓰면 IP 별 제한을 우회할 수 있음)
+func ipExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range trustedProxies {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+// readLimit, txLimit : route middleware, 인증 이후에 적용 (호출자 구분에 Principal 사용)
+func (s *Server) readLimit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return s.limit(c, s.readLimiter, next)
+	}
+}
+
+func (s *Server) txLimit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return s.limit(c, s.txLimiter, next)
+	}
+}
+
+func (s *Server) limit(c echo.Context, limiter *rateLimiter, next echo.HandlerFunc) error {
+	if limiter == nil {
+		return next(c)
+	}
+	// API key 하나를 여러 IP 에서, IP 하나에서 여러 API key 로 보내도 각각 제한
+	callers := []string{"ip:" + c.RealIP()}
+	if principal, ok := c.Get(principalKey).(Principal); ok {
+		callers = append(callers, "principal:"+principal.Name)
+	}
+	if delay := limiter.reserve(time.Now(), callers...); delay > 0 {
+		return tooManyRequests(c, limiter.name, delay)
+	}
+	return next(c)
+}
+
+// tooManyRequests : 429 와 Retry-After(초, 올림) 응답
+func tooManyRequests(c echo.Context, limit string, retryAfter time.Duration) error {
+	metrics.HTTPRateLimited.Inc(limit)
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+	return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded ("+limit+")")
+}
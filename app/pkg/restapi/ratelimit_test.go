@@ -0,0 +1,162 @@
+package restapi
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveFrom(server *Server, method, path, ip string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":40000"
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_PerIP(t *testing.T) {
+	server := NewServer(nil, nil)
+	assert.Equal(t, nil, server.SetRateLimit(RateLimitConfig{
+		Enabled: true,
+		Read:    LimitConfig{RequestsPerSec: 0.1, Burst: 2},
+	}))
+
+	// /status 는 monitor 가 없으면 503, 제한을 넘으면 429
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(server, http.MethodGet, "/status", "10.0.0.1", nil).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(server, http.MethodGet, "/status", "10.0.0.1", nil).Code)
+	rec := serveFrom(server, http.MethodGet, "/status", "10.0.0.1", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("Retry-After"))
+
+	// 다른 IP, 제한이 없는 route 는 영향 없음
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(server, http.MethodGet, "/status", "10.0.0.2", nil).Code)
+	assert.Equal(t, http.StatusOK, serveFrom(server, http.MethodGet, "/health", "10.0.0.1", nil).Code)
+
+	// 신뢰하는 proxy 가 없으면 클라이언트가 보낸 헤더로 IP 를 바꿀 수 없음
+	spoofed := map[string]string{echo.HeaderXForwardedFor: "10.9.9.9", echo.HeaderXRealIP: "10.9.9.9"}
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(server, http.MethodGet, "/status", "10.0.0.1", spoofed).Code)
+}
+
+func TestRateLimit_TrustedProxies(t *testing.T) {
+	server := NewServer(nil, nil)
+	assert.NotEqual(t, nil, server.SetRateLimit(RateLimitConfig{TrustedProxies: []string{"10.0.0.1"}}))
+	assert.Equal(t, nil, server.SetRateLimit(RateLimitConfig{
+		Enabled:        true,
+		Read:           LimitConfig{RequestsPerSec: 0.1, Burst: 1},
+		TrustedProxies: []string{"10.0.0.0/24"},
+	}))
+
+	// proxy 가 전달한 클라이언트 IP 별로 제한
+	forwarded := func(ip string) map[string]string { return map[string]string{echo.HeaderXForwardedFor: ip} }
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(server, http.MethodGet, "/status", "10.0.0.1", forwarded("192.0.2.1")).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(server, http.MethodGet, "/status", "10.0.0.1", forwarded("192.0.2.1")).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(server, http.MethodGet, "/status", "10.0.0.1", forwarded("192.0.2.2")).Code)
+
+	// 신뢰하지 않는 곳에서 온 X-Forwarded-For 는 무시
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(server, http.MethodGet, "/status", "172.16.0.1", forwarded("192.0.2.3")).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(server, http.MethodGet, "/status", "172.16.0.1", forwarded("192.0.2.4")).Code)
+}
+
+func TestRateLimit_PerAPIKey(t *testing.T) {
+	auth, err := NewAuthenticator(AuthConfig{
+		Enabled: true,
+		APIKeys: []APIKeyConfig{
+			{Name: "a", KeySha256: HashAPIKey("key-a"), Role: RoleAdmin},
+			{Name: "b", KeySha256: HashAPIKey("key-b"), Role: RoleAdmin},
+		},
+	})
+	assert.Equal(t, nil, err)
+	server := NewServer(nil, nil)
+	server.SetAuth(auth)
+	assert.Equal(t, nil, server.SetRateLimit(RateLimitConfig{
+		Enabled:     true,
+		Read:        LimitConfig{RequestsPerSec: 100, Burst: 100},
+		Transaction: LimitConfig{RequestsPerSec: 0.5, Burst: 1},
+	}))
+
+	// API key 별로 제한, 조회와 전송은 별도 제한
+	keyA, keyB := map[string]string{"X-API-Key": "key-a"}, map[string]string{"X-API-Key": "key-b"}
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(server, http.MethodDelete, "/raft/peers/2", "10.0.0.1", keyA).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(server, http.MethodDelete, "/raft/peers/2", "10.0.0.2", keyA).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(server, http.MethodGet, "/status", "10.0.0.1", keyA).Code)
+
+	// 같은 IP 에서 API key 를 바꿔도 IP 별 제한 적용, 거부된 요청은 다른 제한에서 차감하지 않음
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(server, http.MethodDelete, "/raft/peers/2", "10.0.0.1", keyB).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(server, http.MethodDelete, "/raft/peers/2", "10.0.0.3", keyB).Code)
+}
+
+func TestPendingLimiter(t *testing.T) {
+	limiter := newPendingLimiter(2)
+	account, other := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+
+	assert.True(t, limiter.acquire(account))
+	assert.True(t, limiter.acquire(account))
+	assert.False(t, limiter.acquire(account))
+	assert.True(t, limiter.acquire(other))
+
+	limiter.release(account)
+	assert.True(t, limiter.acquire(account))
+
+	// 설정하지 않으면 제한 없음
+	var disabled *pendingLimiter
+	assert.True(t, disabled.acquire(account))
+	disabled.release(account)
+}
+
+func TestRateLimit_PendingSignerEndpoints(t *testing.T) {
+	sim, err := simulated.New(1)
+	assert.Equal(t, nil, err)
+	defer sim.Close()
+	sim.SetTxPool(true)
+	sim.SetAutoCommit(false)
+
+	server := NewServer(sim, sim.Accounts[0])
+	server.SetTxManager(txmanager.New(sim))
+	assert.Equal(t, nil, server.SetRateLimit(RateLimitConfig{Enabled: true, MaxPendingPerAccount: 1}))
+
+	ctx := context.Background()
+	chainID, _ := sim.ChainID(ctx)
+	price, _ := sim.SuggestGasPrice(ctx)
+	tx, err := sim.Accounts[0].SignTx(types.NewTransaction(0, common.HexToAddress("0x1000"), big.NewInt(1), params.TxGas, price, nil), chainID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, sim.SendTransaction(ctx, tx))
+
+	// 서명 계정으로 트랜잭션을 보내는 요청은 모두 진행 중인 트랜잭션 수 제한 적용
+	account := sim.Accounts[0].Address()
+	assert.True(t, server.pendingLimiter.acquire(account))
+	for _, path := range []string{
+		"/transactions/" + tx.Hash().Hex() + "/speedup",
+		"/transactions/" + tx.Hash().Hex() + "/cancel",
+		"/accounts/" + account.Hex() + "/nonces/repair",
+	} {
+		assert.Equal(t, http.StatusTooManyRequests, serveFrom(server, http.MethodPost, path, "10.0.0.1", nil).Code, path)
+	}
+	server.pendingLimiter.release(account)
+	assert.Equal(t, http.StatusOK, serveFrom(server, http.MethodPost, "/transactions/"+tx.Hash().Hex()+"/speedup", "10.0.0.1", nil).Code)
+}
+
+func TestRateLimiter_Sweep(t *testing.T) {
+	limiter := newRateLimiter("read", LimitConfig{RequestsPerSec: 1, Burst: 1})
+	now := time.Now()
+	assert.Equal(t, time.Duration(0), limiter.reserve(now, "a"))
+	assert.True(t, limiter.reserve(now, "a") > 0)
+
+	// 오래 요청이 없던 호출자는 정리
+	later := now.Add(2 * limiterIdleTimeout)
+	assert.Equal(t, time.Duration(0), limiter.reserve(later, "b"))
+	assert.Equal(t, 1, len(limiter.callers))
+}
@@ -1,20 +1,149 @@
 package restapi
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"tiny-blockchain-app/app/pkg/blockchain/backend"
+	"tiny-blockchain-app/app/pkg/blockchain/journal"
+	"tiny-blockchain-app/app/pkg/blockchain/monitor"
+	"tiny-blockchain-app/app/pkg/blockchain/quorum"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+	"tiny-blockchain-app/app/pkg/metrics"
+	"tiny-blockchain-app/app/pkg/wallet"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func NewServer() {
+type Server struct {
+	echo    *echo.Echo
+	client  backend.Backend
+	signer  wallet.Signer
+	signers map[common.Address]wallet.Signer
+	raft    *quorum.RaftClient
+	monitor *monitor.Monitor
+	auth    *Authenticator
+	openAPI *OpenAPI
+	// txManager : 트랜잭션 상태 조회와 교체 (nil 이면 503 응답)
+	txManager *txmanager.Manager
+	// nonceErrRetryCnt : transact 의 nonce 충돌 재전송 횟수
+	nonceErrRetryCnt uint8
+	// journal : 전송한 트랜잭션 기록 조회 (nil 이면 503 응답)
+	journal *journal.Journal
+
+	idempotency *idempotencyStore
+
+	readLimiter    *rateLimiter
+	txLimiter      *rateLimiter
+	pendingLimiter *pendingLimiter
+}
+
+func NewServer(client backend.Backend, signer wallet.Signer) *Server {
 	e := echo.New()
-	e.Use(middleware.Logger())
+	e.IPExtractor = echo.ExtractIPDirect()
+	e.Use(middleware.RequestID())
+	e.Use(requestLogger)
 	e.Use(middleware.Recover())
-	e.GET("/", hello)
+	e.Use(metricsMiddleware)
+
+	s := &Server{
+		echo:    e,
+		client:  client,
+		signers: map[common.Address]wallet.Signer{},
+		openAPI: newOpenAPI(),
+
+		idempotency: newIdempotencyStore(defaultIdempotencyTTL),
+	}
+	s.AddSigner(signer)
+
+	// 인증 없이 접근 가능 (상태 확인, 메트릭 수집, API 문서)
+	s.handle(http.MethodGet, "/", hello, helloOperation)
+	s.handle(http.MethodGet, "/health", s.health, healthOperation)
+	s.handle(http.MethodGet, "/metrics", echo.WrapHandler(metrics.DefaultRegistry.Handler()), metricsOperation)
+	s.handle(http.MethodGet, "/openapi.json", s.openAPIDocument, openAPIOperation)
+	s.handle(http.MethodGet, "/docs", docs, docsOperation)
+
+	// 인증(역할 확인) 후 조회는 read, 전송과 노드 관리는 transaction 요청 제한 적용, 이후 요청 검증
+	s.handle(http.MethodGet, "/status", s.status, statusOperation, s.readLimit)
+	s.handle(http.MethodPost, "/contracts/:address/call", s.callContract, callContractOperation, s.readLimit)
+	// token-admin 메서드(mint, pause 등)와 서명 계정은 handler 에서 확인
+	s.handle(http.MethodPost, "/contracts/:address/transact", s.transactContract, transactContractOperation, s.txLimit)
+	s.handle(http.MethodPost, "/signatures/verify", s.verifySignature, verifySignatureOperation, s.readLimit)
+	// 트랜잭션을 보낸 계정의 서명 키가 있어야 교체 가능
+	s.handle(http.MethodGet, "/transactions/:hash", s.transactionStatus, transactionStatusOperation, s.readLimit)
+	s.handle(http.MethodPost, "/transactions/:hash/speedup", s.speedUpTransaction, speedUpTransactionOperation, s.txLimit)
+	s.handle(http.MethodPost, "/transactions/:hash/cancel", s.cancelTransaction, cancelTransactionOperation, s.txLimit)
+	s.handle(http.MethodGet, "/accounts/:address/nonces", s.accountNonces, accountNoncesOperation, s.readLimit)
+	s.handle(http.MethodPost, "/accounts/:address/nonces/repair", s.repairNonces, repairNoncesOperation, s.txLimit)
+	s.handle(http.MethodGet, "/journal", s.listJournal, listJournalOperation, s.readLimit)
+	s.handle(http.MethodGet, "/journal/:hash", s.journalEntry, journalEntryOperation, s.readLimit)
+
+	s.handle(http.MethodGet, "/raft/cluster", s.raftCluster, raftClusterOperation, s.readLimit)
+	s.handle(http.MethodGet, "/raft/leader", s.raftLeader, raftLeaderOperation, s.readLimit)
+	s.handle(http.MethodGet, "/raft/role", s.raftRole, raftRoleOperation, s.readLimit)
+	s.handle(http.MethodPost, "/raft/peers", s.raftAddPeer, raftAddPeerOperation, s.txLimit)
+	s.handle(http.MethodPost, "/raft/peers/:raftId/promote", s.raftPromotePeer, raftPromotePeerOperation, s.txLimit)
+	s.handle(http.MethodDelete, "/raft/peers/:raftId", s.raftRemovePeer, raftRemovePeerOperation, s.txLimit)
+
+	return s
+}
+
+// AddSigner : transact 에서 from 으로 선택할 수 있는 서명 계정 추가 (처음 추가한 계정이 기본 서명 계정)
+func (s *Server) AddSigner(signer wallet.Signer) {
+	if signer == nil {
+		return
+	}
+	s.signers[signer.Address()] = signer
+	if s.signer == nil {
+		s.signer = signer
+	}
+}
 
-	e.Logger.Fatal(e.Start(":8080"))
+// SetAuth : 인증 설정 (설정하지 않거나 Enabled 가 false 이면 모든 요청 허용)
+func (s *Server) SetAuth(auth *Authenticator) {
+	s.auth = auth
+}
+
+// SetNonceErrRetryCnt : transact 에서 nonce 충돌로 전송이 실패했을 때 nonce 를 다시 조회하여 재전송하는 횟수 (기본 0)
+func (s *Server) SetNonceErrRetryCnt(count uint8) {
+	s.nonceErrRetryCnt = count
+}
+
+// SetRaftClient : raft 관리 API에서 사용할 클라이언트 설정 (설정하지 않으면 503 응답)
+func (s *Server) SetRaftClient(raft *quorum.RaftClient) {
+	s.raft = raft
+}
+
+// SetMonitor : /health, /status 에서 사용할 노드 모니터 설정
+func (s *Server) SetMonitor(m *monitor.Monitor) {
+	s.monitor = m
+}
+
+// Start : Shutdown 으로 종료될 때까지 대기 (Shutdown 으로 종료되면 nil 반환)
+func (s *Server) Start(address string) error {
+	if err := s.echo.Start(address); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown : 새 요청을 받지 않고 처리 중인 요청이 끝날 때까지 ctx 가 끝날 때까지 대기
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.echo.Shutdown(ctx)
+}
+
+var helloOperation = Operation{
+	Summary:   "Greeting",
+	Tags:      []string{"status"},
+	Responses: map[string]Response{"200": {Description: "Greeting text"}},
+}
 
+var metricsOperation = Operation{
+	Summary:   "Prometheus metrics",
+	Tags:      []string{"status"},
+	Responses: map[string]Response{"200": {Description: "Prometheus text exposition format"}},
 }
 
 func hello(c echo.Context) error {
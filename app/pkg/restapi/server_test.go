@@ -0,0 +1,45 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_Shutdown(t *testing.T) {
+	server := NewServer(nil, nil)
+	server.echo.HideBanner, server.echo.HidePort = true, true
+	server.echo.GET("/slow", func(c echo.Context) error {
+		time.Sleep(200 * time.Millisecond)
+		return c.String(http.StatusOK, "done")
+	})
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- server.Start("127.0.0.1:0") }()
+	for server.echo.ListenerAddr() == nil {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 처리 중인 요청은 Shutdown 중에도 끝까지 응답
+	responded := make(chan int, 1)
+	go func() {
+		res, err := http.Get("http://" + server.echo.ListenerAddr().String() + "/slow")
+		if err != nil {
+			responded <- 0
+			return
+		}
+		res.Body.Close()
+		responded <- res.StatusCode
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Equal(t, nil, server.Shutdown(ctx))
+	assert.Equal(t, http.StatusOK, <-responded)
+	assert.Equal(t, nil, <-stopped)
+}
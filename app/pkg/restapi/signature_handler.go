@@ -0,0 +1,82 @@
+package restapi
+
+import (
+	"net/http"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/labstack/echo/v4"
+)
+
+// verifyRequest : message(텍스트), messageHex(0x 바이트), typedData(EIP-712) 중 하나를 검증
+type verifyRequest struct {
+	Address    string              `json:"address"`
+	Signature  string              `json:"signature"`
+	Message    *string             `json:"message"`
+	MessageHex string              `json:"messageHex"`
+	TypedData  *apitypes.TypedData `json:"typedData"`
+}
+
+type verifyResponse struct {
+	Valid  bool   `json:"valid"`
+	Signer string `json:"signer"`
+}
+
+var verifySignatureOperation = Operation{
+	Summary:     "Verify an EIP-191 message or EIP-712 typed data signature",
+	Description: "One of message, messageHex or typedData is required.",
+	Tags:        []string{"signatures"},
+	Role:        RoleViewer,
+	RequestBody: jsonBody(object([]string{"address", "signature"}, map[string]*Schema{
+		"address":    addressSchema("Expected signer"),
+		"signature":  hexSchema("65-byte signature"),
+		"message":    stringSchema("Text message"),
+		"messageHex": hexSchema("Message bytes"),
+		"typedData":  {Type: "object", Description: "EIP-712 typed data"},
+	})),
+	Responses: map[string]Response{
+		"200": jsonResponse("Verification result", object(nil, map[string]*Schema{"valid": {Type: "boolean"}, "signer": addressSchema("Recovered signer")})),
+	},
+}
+
+// POST /signatures/verify
+func (s *Server) verifySignature(c echo.Context) error {
+	var request verifyRequest
+	if err := c.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if !common.IsHexAddress(request.Address) {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid address")
+	}
+	signature, err := hexutil.Decode(request.Signature)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid signature encoding")
+	}
+
+	var signer common.Address
+	switch {
+	case request.TypedData != nil:
+		signer, err = wallet.RecoverTypedDataSigner(*request.TypedData, signature)
+	case request.Message != nil:
+		signer, err = wallet.RecoverMessageSigner([]byte(*request.Message), signature)
+	case request.MessageHex != "":
+		message, decodeErr := hexutil.Decode(request.MessageHex)
+		if decodeErr != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid messageHex encoding")
+		}
+		signer, err = wallet.RecoverMessageSigner(message, signature)
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "message, messageHex or typedData is required")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, verifyResponse{
+		Valid:  signer == common.HexToAddress(request.Address),
+		Signer: signer.Hex(),
+	})
+}
@@ -0,0 +1,60 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func postJSON(server *Server, path string, body interface{}) *httptest.ResponseRecorder {
+	encoded, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(string(encoded)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestVerifySignature(t *testing.T) {
+	server := NewServer(nil, nil)
+
+	privateKey, _ := crypto.GenerateKey()
+	keyPair := wallet.KeyPair{PublicKey: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	message := "swap intent #1"
+	signature, err := keyPair.SignMessage([]byte(message))
+	assert.Equal(t, nil, err)
+
+	rec := postJSON(server, "/signatures/verify", map[string]interface{}{
+		"address":   keyPair.PublicKey.Hex(),
+		"signature": hexutil.Encode(signature),
+		"message":   message,
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response verifyResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.True(t, response.Valid)
+	assert.Equal(t, keyPair.PublicKey.Hex(), response.Signer)
+
+	rec = postJSON(server, "/signatures/verify", map[string]interface{}{
+		"address":    keyPair.PublicKey.Hex(),
+		"signature":  hexutil.Encode(signature),
+		"messageHex": hexutil.Encode([]byte("swap intent #2")),
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.False(t, response.Valid)
+
+	rec = postJSON(server, "/signatures/verify", map[string]interface{}{
+		"address":   keyPair.PublicKey.Hex(),
+		"signature": hexutil.Encode(signature),
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
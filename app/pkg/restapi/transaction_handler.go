@@ -0,0 +1,233 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/labstack/echo/v4"
+)
+
+type replaceResponse struct {
+	TxHash string `json:"txHash"`
+	// Replaces : 교체된 트랜잭션 (요청한 hash 가 이미 교체되었으면 마지막 교체)
+	Replaces string `json:"replaces"`
+	Nonce    uint64 `json:"nonce"`
+}
+
+var txHashParam = pathParam("hash", &Schema{Type: "string", Format: "hex", Pattern: "^0x[0-9a-fA-F]{64}$", Description: "Transaction hash"})
+
+var transactionStatusOperation = Operation{
+	Summary:    "Transaction state including speed-up and cancel replacements",
+	Tags:       []string{"transactions"},
+	Role:       RoleViewer,
+	Parameters: []Parameter{txHashParam},
+	Responses: map[string]Response{
+		"200": jsonResponse("Status", object(nil, map[string]*Schema{
+			"hash":         hexSchema("Requested transaction hash"),
+			"state":        stringSchema("pending, mined, failed, dropped or unknown"),
+			"replacements": {Type: "array", Items: hexSchema(""), Description: "Transactions sent with the same nonce, oldest first"},
+			"cancelled":    {Type: "boolean"},
+			"minedHash":    hexSchema("Mined transaction (differs from hash if a replacement was mined)"),
+			"blockNumber":  {Type: "integer"},
+		})),
+		"502": jsonResponse("Node request failed", errorSchema),
+		"503": jsonResponse("Transaction manager is not configured", errorSchema),
+	},
+}
+
+var replaceResponses = map[string]Response{
+	"200": jsonResponse("Replacement sent", object(nil, map[string]*Schema{
+		"txHash":   hexSchema("Replacement transaction hash"),
+		"replaces": hexSchema("Replaced transaction hash"),
+		"nonce":    {Type: "integer"},
+	})),
+	"403": jsonResponse("Not allowed to sign with the sender", errorSchema),
+	"404": jsonResponse("Transaction not found", errorSchema),
+	"409": jsonResponse("Transaction is already mined, its nonce was used or its gas price is zero", errorSchema),
+	"502": jsonResponse("Node request failed", errorSchema),
+	"503": jsonResponse("Transaction manager is not configured", errorSchema),
+}
+
+var speedUpTransactionOperation = Operation{
+	Summary:     "Resend a pending transaction with a higher gas price",
+	Description: "The sender must be one of the server signing accounts. Idempotency-Key is not supported: repeating the request replaces the latest transaction again.",
+	Tags:        []string{"transactions"},
+	Role:        RoleOperator,
+	Parameters:  []Parameter{txHashParam},
+	Responses:   replaceResponses,
+}
+
+var cancelTransactionOperation = Operation{
+	Summary:     "Replace a pending transaction with a zero-value self transfer",
+	Description: "The sender must be one of the server signing accounts. Idempotency-Key is not supported: repeating the request replaces the latest transaction again.",
+	Tags:        []string{"transactions"},
+	Role:        RoleOperator,
+	Parameters:  []Parameter{txHashParam},
+	Responses:   replaceResponses,
+}
+
+var poolTransactionSchema = &Schema{Type: "array", Items: object(nil, map[string]*Schema{
+	"hash":  hexSchema(""),
+	"nonce": {Type: "integer"},
+	"to":    addressSchema(""),
+})}
+
+var nonceReportSchema = object(nil, map[string]*Schema{
+	"account":      addressSchema(""),
+	"nonce":        {Type: "integer", Description: "Next nonce to be mined"},
+	"pendingNonce": {Type: "integer", Description: "Next nonce including executable txpool transactions"},
+	"pending":      poolTransactionSchema,
+	"stuck":        poolTransactionSchema,
+	"gaps":         {Type: "array", Items: &Schema{Type: "integer"}, Description: "Missing nonces that block the stuck transactions"},
+})
+
+var accountNoncesOperation = Operation{
+	Summary:     "Compare mined and pending nonces and find nonce gaps in the txpool",
+	Description: "Requires the txpool API on the node.",
+	Tags:        []string{"transactions"},
+	Role:        RoleViewer,
+	Parameters:  []Parameter{pathParam("address", addressSchema("Account"))},
+	Responses: map[string]Response{
+		"200": jsonResponse("Nonce report", nonceReportSchema),
+		"502": jsonResponse("Node request failed", errorSchema),
+		"503": jsonResponse("Transaction manager is not configured", errorSchema),
+	},
+}
+
+var repairNoncesOperation = Operation{
+	Summary:     "Fill nonce gaps with recorded transactions or zero-value self transfers",
+	Description: "The account must be one of the server signing accounts. Idempotency-Key is not supported: repeating the request only fills gaps that remain.",
+	Tags:        []string{"transactions"},
+	Role:        RoleOperator,
+	Parameters:  []Parameter{pathParam("address", addressSchema("Account"))},
+	Responses: map[string]Response{
+		"200": jsonResponse("Filled nonces", object(nil, map[string]*Schema{
+			"report": nonceReportSchema,
+			"fills": {Type: "array", Items: object(nil, map[string]*Schema{
+				"nonce":       {Type: "integer"},
+				"hash":        hexSchema("Sent transaction"),
+				"resubmitted": {Type: "boolean", Description: "A recorded transaction was sent again instead of a no-op"},
+			})},
+		})),
+		"403": jsonResponse("Not allowed to sign with the account", errorSchema),
+		"502": jsonResponse("Node request failed", errorSchema),
+		"503": jsonResponse("Transaction manager is not configured", errorSchema),
+	},
+}
+
+type repairNoncesResponse struct {
+	Report *txmanager.NonceReport `json:"report"`
+	Fills  []txmanager.Fill       `json:"fills"`
+}
+
+// SetTxManager : 트랜잭션 상태 조회, speed-up, cancel, nonce 확인에 사용할 manager 설정 (설정하지 않으면 503 응답)
+func (s *Server) SetTxManager(m *txmanager.Manager) {
+	s.txManager = m
+}
+
+// GET /transactions/:hash
+func (s *Server) transactionStatus(c echo.Context) error {
+	if s.txManager == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "transaction manager is not configured")
+	}
+	status, err := s.txManager.Status(c.Request().Context(), common.HexToHash(c.Param("hash")))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusOK, status)
+}
+
+// POST /transactions/:hash/speedup
+func (s *Server) speedUpTransaction(c echo.Context) error {
+	return s.replaceTransaction(c, s.txManager.SpeedUp)
+}
+
+// POST /transactions/:hash/cancel
+func (s *Server) cancelTransaction(c echo.Context) error {
+	return s.replaceTransaction(c, s.txManager.Cancel)
+}
+
+// replaceTransaction : 트랜잭션을 보낸 계정의 서명 키로 교체
+func (s *Server) replaceTransaction(c echo.Context, replace func(context.Context, wallet.Signer, common.Hash) (*types.Transaction, error)) error {
+	if s.txManager == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "transaction manager is not configured")
+	}
+	ctx := c.Request().Context()
+	hash := common.HexToHash(c.Param("hash"))
+
+	from, err := s.txManager.Sender(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "transaction not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	signer, exist := s.signers[from]
+	if !exist {
+		return echo.NewHTTPError(http.StatusForbidden, "no signing key for "+from.Hex())
+	}
+	if err := authorizeSigner(c, from); err != nil {
+		return err
+	}
+	if !s.pendingLimiter.acquire(from) {
+		return tooManyRequests(c, "pending", time.Second)
+	}
+	defer s.pendingLimiter.release(from)
+
+	previous := hash
+	if chain := s.txManager.Chain(hash); chain != nil {
+		previous = chain.Transactions[len(chain.Transactions)-1].Hash()
+	}
+	tx, err := replace(ctx, signer, hash)
+	if errors.Is(err, txmanager.ErrNotPending) || errors.Is(err, txmanager.ErrZeroGasPrice) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusOK, replaceResponse{TxHash: tx.Hash().Hex(), Replaces: previous.Hex(), Nonce: tx.Nonce()})
+}
+
+// GET /accounts/:address/nonces
+func (s *Server) accountNonces(c echo.Context) error {
+	if s.txManager == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "transaction manager is not configured")
+	}
+	report, err := s.txManager.Inspect(c.Request().Context(), common.HexToAddress(c.Param("address")))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// POST /accounts/:address/nonces/repair
+func (s *Server) repairNonces(c echo.Context) error {
+	if s.txManager == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "transaction manager is not configured")
+	}
+	account := common.HexToAddress(c.Param("address"))
+	signer, exist := s.signers[account]
+	if !exist {
+		return echo.NewHTTPError(http.StatusForbidden, "no signing key for "+account.Hex())
+	}
+	if err := authorizeSigner(c, account); err != nil {
+		return err
+	}
+	if !s.pendingLimiter.acquire(account) {
+		return tooManyRequests(c, "pending", time.Second)
+	}
+	defer s.pendingLimiter.release(account)
+
+	report, fills, err := s.txManager.RepairGaps(c.Request().Context(), signer)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	}
+	return c.JSON(http.StatusOK, repairNoncesResponse{Report: report, Fills: fills})
+}
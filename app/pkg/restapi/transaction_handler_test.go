@@ -0,0 +1,128 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+	"tiny-blockchain-app/app/pkg/blockchain/simulated"
+	"tiny-blockchain-app/app/pkg/blockchain/txmanager"
+	"tiny-blockchain-app/app/pkg/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionHandler(t *testing.T) {
+	sim, err := simulated.New(2)
+	assert.Equal(t, nil, err)
+	defer sim.Close()
+	sim.SetTxPool(true)
+	sim.SetAutoCommit(false)
+
+	server := NewServer(sim, sim.Accounts[0])
+	server.SetTxManager(txmanager.New(sim))
+
+	ctx := context.Background()
+	chainID, _ := sim.ChainID(ctx)
+	price, _ := sim.SuggestGasPrice(ctx)
+	send := func(account *wallet.KeyPair) *types.Transaction {
+		tx, err := account.SignTx(types.NewTransaction(0, common.HexToAddress("0x1000"), big.NewInt(1), params.TxGas, price, nil), chainID)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, sim.SendTransaction(ctx, tx))
+		return tx
+	}
+	owned, other := send(sim.Accounts[0]), send(sim.Accounts[1])
+
+	rec := serve(server, http.MethodPost, "/transactions/"+owned.Hash().Hex()+"/speedup")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var replaced replaceResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &replaced))
+	assert.Equal(t, owned.Hash().Hex(), replaced.Replaces)
+	assert.Equal(t, uint64(0), replaced.Nonce)
+
+	// 이미 교체한 트랜잭션은 마지막 교체를 다시 교체
+	rec = serve(server, http.MethodPost, "/transactions/"+owned.Hash().Hex()+"/cancel")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var cancelled replaceResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &cancelled))
+	assert.Equal(t, replaced.TxHash, cancelled.Replaces)
+
+	rec = serve(server, http.MethodGet, "/transactions/"+owned.Hash().Hex())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var status txmanager.Status
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, txmanager.StatePending, status.State)
+	assert.Equal(t, 3, len(status.Replacements))
+	assert.True(t, status.Cancelled)
+
+	// 서버에 보낸 계정의 서명 키가 없음
+	rec = serve(server, http.MethodPost, "/transactions/"+other.Hash().Hex()+"/speedup")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	sim.Commit()
+	rec = serve(server, http.MethodGet, "/transactions/"+owned.Hash().Hex())
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, txmanager.StateMined, status.State)
+	assert.Equal(t, cancelled.TxHash, status.MinedHash.Hex())
+
+	rec = serve(server, http.MethodPost, "/transactions/"+owned.Hash().Hex()+"/speedup")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	rec = serve(server, http.MethodPost, "/transactions/"+common.HexToHash("0x01").Hex()+"/cancel")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = serve(server, http.MethodGet, "/transactions/0x1234")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve(NewServer(nil, nil), http.MethodGet, "/transactions/"+owned.Hash().Hex())
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestNonceHandler(t *testing.T) {
+	sim, err := simulated.New(2)
+	assert.Equal(t, nil, err)
+	defer sim.Close()
+	sim.SetTxPool(true)
+	sim.SetAutoCommit(false)
+
+	server := NewServer(sim, sim.Accounts[0])
+	server.SetTxManager(txmanager.New(sim))
+
+	ctx := context.Background()
+	chainID, _ := sim.ChainID(ctx)
+	price, _ := sim.SuggestGasPrice(ctx)
+	for _, account := range sim.Accounts {
+		tx, err := account.SignTx(types.NewTransaction(2, common.HexToAddress("0x1000"), big.NewInt(1), params.TxGas, price, nil), chainID)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, sim.SendTransaction(ctx, tx))
+	}
+	owner, other := sim.Accounts[0].Address().Hex(), sim.Accounts[1].Address().Hex()
+
+	rec := serve(server, http.MethodGet, "/accounts/"+owner+"/nonces")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var report txmanager.NonceReport
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, []uint64{0, 1}, report.Gaps)
+	assert.Equal(t, 1, len(report.Stuck))
+
+	rec = serve(server, http.MethodPost, "/accounts/"+owner+"/nonces/repair")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var repaired repairNoncesResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &repaired))
+	assert.Equal(t, 2, len(repaired.Fills))
+	assert.Equal(t, uint64(1), repaired.Fills[1].Nonce)
+
+	rec = serve(server, http.MethodGet, "/accounts/"+owner+"/nonces")
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, uint64(3), report.PendingNonce)
+	assert.Equal(t, 0, len(report.Gaps))
+
+	// 서버에 서명 키가 없는 계정
+	rec = serve(server, http.MethodPost, "/accounts/"+other+"/nonces/repair")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = serve(server, http.MethodGet, "/accounts/0x1234/nonces")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve(NewServer(nil, nil), http.MethodGet, "/accounts/"+owner+"/nonces")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -0,0 +1,216 @@
+package restapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/labstack/echo/v4"
+)
+
+// validationError : 400 응답 본문
+type validationError struct {
+	Message string   `json:"message"`
+	Errors  []string `json:"errors"`
+}
+
+var validationErrorSchema = object([]string{"message", "errors"}, map[string]*Schema{
+	"message": stringSchema(""),
+	"errors":  {Type: "array", Items: stringSchema("field: reason")},
+})
+
+// validateRequest : path, query parameter 와 JSON 본문을 op 문서에 따라 검증, 실패하면 모든 오류를 담아 400
+func validateRequest(op Operation) echo.MiddlewareFunc {
+	var bodySchema *Schema
+	if op.RequestBody != nil {
+		bodySchema = op.RequestBody.Content[echo.MIMEApplicationJSON].Schema
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var errs []string
+			for _, param := range op.Parameters {
+				switch param.In {
+				case "path":
+					errs = append(errs, validateParam(param.Name, c.Param(param.Name), param.Schema)...)
+				case "query":
+					// 선택 query parameter 는 있을 때만 검증
+					if raw := c.QueryParam(param.Name); raw != "" || param.Required {
+						errs = append(errs, validateParam(param.Name, raw, param.Schema)...)
+					}
+				}
+			}
+
+			if bodySchema != nil {
+				body, err := io.ReadAll(c.Request().Body)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, "failed to read request body")
+				}
+				// handler 에서 다시 Bind 할 수 있도록 복원
+				c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+				decoder := json.NewDecoder(bytes.NewReader(body))
+				decoder.UseNumber()
+				var value interface{}
+				if err := decoder.Decode(&value); err != nil {
+					errs = append(errs, "body: invalid JSON")
+				} else {
+					errs = append(errs, validateValue("body", value, bodySchema)...)
+				}
+			}
+
+			if len(errs) > 0 {
+				return echo.NewHTTPError(http.StatusBadRequest, validationError{Message: "invalid request", Errors: errs})
+			}
+			return next(c)
+		}
+	}
+}
+
+// validateParam : path, query parameter 는 문자열이므로 integer 는 숫자로 변환하여 검증
+func validateParam(name, raw string, schema *Schema) []string {
+	var value interface{} = raw
+	if schema.Type == "integer" {
+		value = json.Number(raw)
+	}
+	return validateValue(name, value, schema)
+}
+
+// validateValue : value 는 json.Decoder.UseNumber 로 디코딩한 값
+func validateValue(path string, value interface{}, schema *Schema) []string {
+	if schema == nil {
+		return nil
+	}
+	if len(schema.AnyOf) > 0 {
+		for _, candidate := range schema.AnyOf {
+			if len(validateValue(path, value, candidate)) == 0 {
+				return nil
+			}
+		}
+		return []string{path + ": does not match any allowed schema"}
+	}
+
+	switch schema.Type {
+	case "":
+		return nil
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{path + ": must be an object"}
+		}
+		return validateObject(path, object, schema)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{path + ": must be an array"}
+		}
+		var errs []string
+		for i, item := range items {
+			errs = append(errs, validateValue(fmt.Sprintf("%s[%d]", path, i), item, schema.Items)...)
+		}
+		return errs
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return []string{path + ": must be a string"}
+		}
+		return validateString(path, text, schema)
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return []string{path + ": must be an integer"}
+		}
+		integer, err := number.Int64()
+		if err != nil {
+			return []string{path + ": must be an integer"}
+		}
+		if schema.Minimum != nil && float64(integer) < *schema.Minimum {
+			return []string{fmt.Sprintf("%s: must be >= %v", path, *schema.Minimum)}
+		}
+		if schema.Maximum != nil && float64(integer) > *schema.Maximum {
+			return []string{fmt.Sprintf("%s: must be <= %v", path, *schema.Maximum)}
+		}
+		return nil
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{path + ": must be a boolean"}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func validateObject(path string, object map[string]interface{}, schema *Schema) []string {
+	var errs []string
+	for _, name := range schema.Required {
+		if value, exist := object[name]; !exist || value == nil || value == "" {
+			errs = append(errs, path+"."+name+": is required")
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property, exist := schema.Properties[name]
+		if !exist || object[name] == nil {
+			continue
+		}
+		errs = append(errs, validateValue(path+"."+name, object[name], property)...)
+	}
+	return errs
+}
+
+// validateString : 빈 문자열은 필수 항목일 때만 오류 (선택 항목의 "" 는 생략으로 처리)
+func validateString(path, text string, schema *Schema) []string {
+	if text == "" {
+		return nil
+	}
+	switch schema.Format {
+	case "address":
+		if err := validateAddress(text); err != nil {
+			return []string{path + ": " + err.Error()}
+		}
+	case "uint256":
+		amount, ok := math.ParseBig256(text)
+		if !ok || amount.Sign() < 0 {
+			return []string{path + ": must be a non-negative 256-bit integer (decimal or 0x hex)"}
+		}
+	case "hex":
+		if _, err := hexutil.Decode(text); err != nil {
+			return []string{path + ": must be 0x-prefixed hex bytes"}
+		}
+	}
+	if schema.Pattern != "" {
+		if matched, err := regexp.MatchString(schema.Pattern, text); err != nil || !matched {
+			return []string{path + ": must match " + schema.Pattern}
+		}
+	}
+	return nil
+}
+
+// validateAddress : 대소문자가 섞여 있으면 EIP-55 체크섬 확인 (모두 소문자 또는 대문자는 체크섬 없음으로 허용)
+func validateAddress(text string) error {
+	if !common.IsHexAddress(text) {
+		return fmt.Errorf("must be a 20-byte hex address")
+	}
+	hex := strings.TrimPrefix(strings.TrimPrefix(text, "0x"), "0X")
+	if hex == strings.ToLower(hex) || hex == strings.ToUpper(hex) {
+		return nil
+	}
+	if common.HexToAddress(text).Hex()[2:] != hex {
+		return fmt.Errorf("invalid EIP-55 checksum")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Config : 서명 계정 설정
+// SignerEndpoint가 지정되면 원격 서명자, KeystoreDir가 지정되면 keystore,
+// 둘 다 없으면 PrivateKey(평문)를 사용
+type Config struct {
+	PrivateKey     string
+	KeystoreDir    string
+	Account        string
+	PasswordFile   string
+	SignerEndpoint string
+}
+
+// String : 로그, 출력에 평문 개인키가 남지 않도록 PrivateKey는 설정 여부만 표시
+func (c Config) String() string {
+	privateKey := ""
+	if c.PrivateKey != "" {
+		privateKey = "<redacted>"
+	}
+	return fmt.Sprintf("{PrivateKey:%s KeystoreDir:%s Account:%s PasswordFile:%s SignerEndpoint:%s}",
+		privateKey, c.KeystoreDir, c.Account, c.PasswordFile, c.SignerEndpoint)
+}
+
+// GoString : %#v 출력도 동일하게 가림
+func (c Config) GoString() string {
+	return c.String()
+}
+
+// LoadSigner : 설정에 따라 트랜잭션 서명에 사용할 Signer 로드 (설정이 없으면 nil)
+func (c Config) LoadSigner() (Signer, error) {
+	if c.SignerEndpoint != "" || c.KeystoreDir != "" {
+		if !common.IsHexAddress(c.Account) {
+			return nil, errors.New("invalid wallet account address")
+		}
+	}
+	account := common.HexToAddress(c.Account)
+
+	if c.SignerEndpoint != "" {
+		return NewRemoteSigner(c.SignerEndpoint, account)
+	}
+
+	if c.KeystoreDir != "" {
+		passphrase, err := ReadPassword(c.PasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		manager := NewManager(c.KeystoreDir, false)
+		if err := manager.Unlock(account, passphrase, 0); err != nil {
+			return nil, err
+		}
+		return manager.Signer(account)
+	}
+
+	if c.PrivateKey != "" {
+		return GenerateKeyPair(c.PrivateKey)
+	}
+	return nil, nil
+}
@@ -0,0 +1,247 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+var (
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	ErrIndexNotFound   = errors.New("address not derived from this wallet")
+)
+
+// extendedKey : BIP-32 확장 개인키 (개인키 + chain code)
+type extendedKey struct {
+	key       *big.Int
+	chainCode []byte
+}
+
+// NewMnemonic : bitSize(128~256, 32의 배수) 엔트로피로 BIP-39 니모닉 생성
+// 128 bit → 12 단어, 256 bit → 24 단어
+func NewMnemonic(bitSize int) (string, error) {
+	entropy, err := bip39.NewEntropy(bitSize)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// ValidateMnemonic : 단어 목록과 체크섬 검증
+func ValidateMnemonic(mnemonic string) bool {
+	return bip39.IsMnemonicValid(mnemonic)
+}
+
+// HDWallet : 하나의 시드에서 m/44'/60'/0'/0/i 경로로 사용자 계정을 파생
+// 기본 경로(m/44'/60'/0'/0)의 확장키를 미리 계산하므로 계정 하나당 한 단계의 파생만 수행
+type HDWallet struct {
+	basePath accounts.DerivationPath
+	baseKey  *extendedKey
+
+	mu      sync.RWMutex
+	indexes map[common.Address]uint32
+	scanned uint32 // IndexOf 조회를 위해 파생을 마친 인덱스 수
+	next    uint32 // NewAccount가 다음에 할당할 인덱스
+}
+
+// NewHDWallet : 니모닉과 (선택) BIP-39 passphrase로 기본 경로의 HDWallet 생성
+func NewHDWallet(mnemonic, passphrase string) (*HDWallet, error) {
+	return NewHDWalletWithPath(mnemonic, passphrase, accounts.DefaultRootDerivationPath)
+}
+
+// NewHDWalletWithPath : 기본 경로 대신 basePath 아래의 계정을 관리하는 HDWallet 생성
+func NewHDWalletWithPath(mnemonic, passphrase string, basePath accounts.DerivationPath) (*HDWallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+
+	baseKey, err := deriveFromSeed(seed, basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	path := make(accounts.DerivationPath, len(basePath))
+	copy(path, basePath)
+
+	return &HDWallet{
+		basePath: path,
+		baseKey:  baseKey,
+		indexes:  make(map[common.Address]uint32),
+	}, nil
+}
+
+// Path : index 계정의 전체 파생 경로
+func (w *HDWallet) Path(index uint32) accounts.DerivationPath {
+	path := make(accounts.DerivationPath, len(w.basePath), len(w.basePath)+1)
+	copy(path, w.basePath)
+	return append(path, index)
+}
+
+// Derive : basePath/index 경로의 KeyPair 파생
+func (w *HDWallet) Derive(index uint32) (*KeyPair, error) {
+	if index >= 0x80000000 {
+		return nil, fmt.Errorf("account index %d out of range", index)
+	}
+
+	child, err := w.baseKey.child(index)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPair, err := child.keyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	w.indexes[keyPair.PublicKey] = index
+	w.mu.Unlock()
+
+	return keyPair, nil
+}
+
+// NewAccount : 아직 할당하지 않은 다음 인덱스의 계정 파생
+func (w *HDWallet) NewAccount() (uint32, *KeyPair, error) {
+	w.mu.Lock()
+	index := w.next
+	w.next++
+	w.mu.Unlock()
+
+	keyPair, err := w.Derive(index)
+	if err != nil {
+		return 0, nil, err
+	}
+	return index, keyPair, nil
+}
+
+// SetNextIndex : 재시작 시 이미 할당된 계정 수를 복원
+func (w *HDWallet) SetNextIndex(next uint32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.next = next
+}
+
+// IndexOf : address의 파생 인덱스 조회
+// 캐시에 없으면 limit 인덱스 전까지 순서대로 파생하며 탐색
+func (w *HDWallet) IndexOf(address common.Address, limit uint32) (uint32, error) {
+	w.mu.RLock()
+	index, exist := w.indexes[address]
+	start := w.scanned
+	w.mu.RUnlock()
+	if exist {
+		return index, nil
+	}
+
+	for i := start; i < limit; i++ {
+		keyPair, err := w.Derive(i)
+		if err != nil {
+			return 0, err
+		}
+
+		w.mu.Lock()
+		if i >= w.scanned {
+			w.scanned = i + 1
+		}
+		w.mu.Unlock()
+
+		if keyPair.PublicKey == address {
+			return i, nil
+		}
+	}
+	return 0, ErrIndexNotFound
+}
+
+// Accounts : 지금까지 파생한 계정 주소와 인덱스
+func (w *HDWallet) Accounts() map[common.Address]uint32 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	result := make(map[common.Address]uint32, len(w.indexes))
+	for address, index := range w.indexes {
+		result[address] = index
+	}
+	return result
+}
+
+// deriveFromSeed : BIP-32 마스터 키 생성 후 path를 따라 파생
+func deriveFromSeed(seed []byte, path accounts.DerivationPath) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Sign() == 0 || key.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, errors.New("invalid master key")
+	}
+
+	current := &extendedKey{key: key, chainCode: sum[32:]}
+	for _, index := range path {
+		child, err := current.child(index)
+		if err != nil {
+			return nil, err
+		}
+		current = child
+	}
+	return current, nil
+}
+
+// child : BIP-32 CKDpriv (index >= 2^31 이면 hardened 파생)
+func (k *extendedKey) child(index uint32) (*extendedKey, error) {
+	var data []byte
+	if index >= 0x80000000 {
+		data = append([]byte{0x00}, common.LeftPadBytes(k.key.Bytes(), 32)...)
+	} else {
+		privateKey, err := k.privateKey()
+		if err != nil {
+			return nil, err
+		}
+		data = crypto.CompressPubkey(&privateKey.PublicKey)
+	}
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+	data = append(data, indexBytes...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+
+	childKey := new(big.Int).Add(tweak, k.key)
+	childKey.Mod(childKey, n)
+	if childKey.Sign() == 0 {
+		return nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+
+	return &extendedKey{key: childKey, chainCode: sum[32:]}, nil
+}
+
+func (k *extendedKey) privateKey() (*ecdsa.PrivateKey, error) {
+	return crypto.ToECDSA(common.LeftPadBytes(k.key.Bytes(), 32))
+}
+
+func (k *extendedKey) keyPair() (*KeyPair, error) {
+	privateKey, err := k.privateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{
+		PublicKey:  crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}, nil
+}
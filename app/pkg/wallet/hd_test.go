@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// 널리 사용되는 개발용 니모닉과 m/44'/60'/0'/0/i 파생 주소
+var testMnemonic string = "test test test test test test test test test test test junk"
+var testMnemonicAddresses = []string{
+	"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+	"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+	"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+}
+
+func TestNewMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic(128)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 12, len(strings.Fields(mnemonic)))
+	assert.True(t, ValidateMnemonic(mnemonic))
+
+	mnemonic, err = NewMnemonic(256)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 24, len(strings.Fields(mnemonic)))
+
+	_, err = NewMnemonic(100)
+	assert.NotEqual(t, nil, err)
+
+	assert.False(t, ValidateMnemonic("test test test test test test test test test test test test"))
+}
+
+func TestHDWallet_Derive(t *testing.T) {
+	hdWallet, err := NewHDWallet(testMnemonic, "")
+	assert.Equal(t, nil, err)
+
+	for i, expected := range testMnemonicAddresses {
+		keyPair, err := hdWallet.Derive(uint32(i))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, common.HexToAddress(expected), keyPair.PublicKey)
+	}
+	assert.Equal(t, "m/44'/60'/0'/0/2", hdWallet.Path(2).String())
+
+	// BIP-39 passphrase가 다르면 전혀 다른 계정이 파생됨
+	protected, err := NewHDWallet(testMnemonic, "passphrase")
+	assert.Equal(t, nil, err)
+	keyPair, err := protected.Derive(0)
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, common.HexToAddress(testMnemonicAddresses[0]), keyPair.PublicKey)
+
+	_, err = NewHDWallet("not a valid mnemonic", "")
+	assert.Equal(t, ErrInvalidMnemonic, err)
+}
+
+func TestHDWallet_NewAccountAndIndexOf(t *testing.T) {
+	hdWallet, err := NewHDWallet(testMnemonic, "")
+	assert.Equal(t, nil, err)
+
+	index, keyPair, err := hdWallet.NewAccount()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint32(0), index)
+	assert.Equal(t, common.HexToAddress(testMnemonicAddresses[0]), keyPair.PublicKey)
+
+	index, _, err = hdWallet.NewAccount()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint32(1), index)
+	assert.Equal(t, 2, len(hdWallet.Accounts()))
+
+	// 재시작한 지갑에서도 인덱스를 탐색하여 찾을 수 있어야 함
+	restored, err := NewHDWallet(testMnemonic, "")
+	assert.Equal(t, nil, err)
+	found, err := restored.IndexOf(common.HexToAddress(testMnemonicAddresses[2]), 10)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint32(2), found)
+
+	_, err = restored.IndexOf(common.HexToAddress("0x01"), 10)
+	assert.Equal(t, ErrIndexNotFound, err)
+}
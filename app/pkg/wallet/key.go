@@ -13,6 +13,15 @@ type KeyPair struct {
 	PrivateKey *ecdsa.PrivateKey
 }
 
+// String : 로그 출력 시 개인키가 노출되지 않도록 주소만 표시
+func (k KeyPair) String() string {
+	return "KeyPair{" + k.PublicKey.Hex() + "}"
+}
+
+func (k KeyPair) GoString() string {
+	return k.String()
+}
+
 func GenerateKeyPair(privateKey string) (*KeyPair, error) {
 
 	ecdsaPrivateKey, err := crypto.HexToECDSA(privateKey)
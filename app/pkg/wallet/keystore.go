@@ -0,0 +1,173 @@
+package wallet
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var ErrAccountNotFound = errors.New("account not managed by keystore")
+
+// Manager : Web3 Secret Storage(scrypt) 형식의 keystore 디렉토리로 계정을 관리
+// 개인키는 항상 암호화된 상태로 파일에 저장되며, 복호화된 키는 Unlock 기간 동안만 메모리에 존재
+type Manager struct {
+	ks *keystore.KeyStore
+}
+
+// NewManager : keystoreDir 디렉토리의 keystore 파일들을 관리하는 Manager 생성
+// light 옵션은 scrypt 연산량을 줄이며 테스트 용도로만 사용할 것
+func NewManager(keystoreDir string, light bool) *Manager {
+	scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+	if light {
+		scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+	return &Manager{
+		ks: keystore.NewKeyStore(keystoreDir, scryptN, scryptP),
+	}
+}
+
+// NewAccount : 새 계정을 생성하고 passphrase로 암호화하여 저장
+func (m *Manager) NewAccount(passphrase string) (common.Address, error) {
+	account, err := m.ks.NewAccount(passphrase)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return account.Address, nil
+}
+
+// Import : keystore JSON을 passphrase로 복호화한 뒤 newPassphrase로 다시 암호화하여 저장
+func (m *Manager) Import(keyJSON []byte, passphrase, newPassphrase string) (common.Address, error) {
+	account, err := m.ks.Import(keyJSON, passphrase, newPassphrase)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return account.Address, nil
+}
+
+// ImportKeyPair : 메모리의 KeyPair를 passphrase로 암호화하여 저장
+func (m *Manager) ImportKeyPair(keyPair KeyPair, passphrase string) (common.Address, error) {
+	account, err := m.ks.ImportECDSA(keyPair.PrivateKey, passphrase)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return account.Address, nil
+}
+
+// Export : 계정의 keystore JSON을 newPassphrase로 다시 암호화하여 반환
+func (m *Manager) Export(address common.Address, passphrase, newPassphrase string) ([]byte, error) {
+	account, err := m.find(address)
+	if err != nil {
+		return nil, err
+	}
+	return m.ks.Export(account, passphrase, newPassphrase)
+}
+
+// Unlock : passphrase로 계정을 잠금 해제
+// timeout이 0이면 Lock을 호출하거나 프로세스가 종료될 때까지 잠금 해제 상태 유지
+func (m *Manager) Unlock(address common.Address, passphrase string, timeout time.Duration) error {
+	account, err := m.find(address)
+	if err != nil {
+		return err
+	}
+	return m.ks.TimedUnlock(account, passphrase, timeout)
+}
+
+// Lock : 잠금 해제된 계정의 복호화된 키를 메모리에서 제거
+func (m *Manager) Lock(address common.Address) error {
+	return m.ks.Lock(address)
+}
+
+// Accounts : 관리 중인 계정 주소 목록
+func (m *Manager) Accounts() []common.Address {
+	managed := m.ks.Accounts()
+	addresses := make([]common.Address, len(managed))
+	for i, account := range managed {
+		addresses[i] = account.Address
+	}
+	return addresses
+}
+
+// HasAccount : 관리 중인 계정인지 확인
+func (m *Manager) HasAccount(address common.Address) bool {
+	return m.ks.HasAddress(address)
+}
+
+// Delete : passphrase 확인 후 keystore 파일 삭제
+func (m *Manager) Delete(address common.Address, passphrase string) error {
+	account, err := m.find(address)
+	if err != nil {
+		return err
+	}
+	return m.ks.Delete(account, passphrase)
+}
+
+// SignTx : 잠금 해제된 계정으로 트랜잭션 서명
+func (m *Manager) SignTx(address common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	account, err := m.find(address)
+	if err != nil {
+		return nil, err
+	}
+	return m.ks.SignTx(account, tx, chainID)
+}
+
+// KeyPair : passphrase로 계정을 복호화하여 KeyPair 반환
+func (m *Manager) KeyPair(address common.Address, passphrase string) (*KeyPair, error) {
+	keyJSON, err := m.Export(address, passphrase, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptKeyPair(keyJSON, passphrase)
+}
+
+func (m *Manager) find(address common.Address) (accounts.Account, error) {
+	account, err := m.ks.Find(accounts.Account{Address: address})
+	if err != nil {
+		return accounts.Account{}, ErrAccountNotFound
+	}
+	return account, nil
+}
+
+// DecryptKeyPair : keystore JSON을 passphrase로 복호화하여 KeyPair 생성
+func DecryptKeyPair(keyJSON []byte, passphrase string) (*KeyPair, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{
+		PublicKey:  key.Address,
+		PrivateKey: key.PrivateKey,
+	}, nil
+}
+
+// LoadKeyPair : keystore 파일과 password 파일로부터 KeyPair 생성
+func LoadKeyPair(keystoreFile, passwordFile string) (*KeyPair, error) {
+	keyJSON, err := os.ReadFile(keystoreFile)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := ReadPassword(passwordFile)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptKeyPair(keyJSON, passphrase)
+}
+
+// ReadPassword : password 파일의 첫 줄을 읽음 (파일 경로가 비어있으면 빈 패스워드)
+func ReadPassword(passwordFile string) (string, error) {
+	if passwordFile == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(string(content), "\n")
+	return strings.TrimRight(lines[0], "\r"), nil
+}
@@ -0,0 +1,99 @@
+package wallet
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_NewAccountAndUnlock(t *testing.T) {
+	manager := NewManager(t.TempDir(), true)
+
+	address, err := manager.NewAccount("secret")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []common.Address{address}, manager.Accounts())
+
+	tx := types.NewTransaction(0, address, big.NewInt(1), 21000, big.NewInt(0), nil)
+
+	// 잠금 해제 전에는 서명 불가
+	_, err = manager.SignTx(address, tx, big.NewInt(10))
+	assert.NotEqual(t, nil, err)
+
+	assert.NotEqual(t, nil, manager.Unlock(address, "wrong", 0))
+	assert.Equal(t, nil, manager.Unlock(address, "secret", 100*time.Millisecond))
+
+	signedTx, err := manager.SignTx(address, tx, big.NewInt(10))
+	assert.Equal(t, nil, err)
+	sender, err := types.Sender(types.NewEIP155Signer(big.NewInt(10)), signedTx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, address, sender)
+
+	// timeout 이후 자동으로 잠김
+	time.Sleep(300 * time.Millisecond)
+	_, err = manager.SignTx(address, tx, big.NewInt(10))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestManager_ImportExport(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	assert.Equal(t, nil, err)
+	keyPair := KeyPair{
+		PublicKey:  crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	source := NewManager(t.TempDir(), true)
+	address, err := source.ImportKeyPair(keyPair, "first")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, keyPair.PublicKey, address)
+
+	keyJSON, err := source.Export(address, "first", "second")
+	assert.Equal(t, nil, err)
+
+	target := NewManager(t.TempDir(), true)
+	_, err = target.Import(keyJSON, "first", "third")
+	assert.NotEqual(t, nil, err)
+
+	imported, err := target.Import(keyJSON, "second", "third")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, address, imported)
+
+	decrypted, err := target.KeyPair(address, "third")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, privateKey.D, decrypted.PrivateKey.D)
+
+	_, err = target.Export(common.HexToAddress("0x01"), "third", "third")
+	assert.Equal(t, ErrAccountNotFound, err)
+}
+
+func TestLoadKeyPair_QuorumTemplate(t *testing.T) {
+	dir := "../../../docker/quorum/nodes/template/Node-1/data/keystore"
+	expected, err := os.ReadFile(filepath.Join(dir, "accountAddress"))
+	assert.Equal(t, nil, err)
+
+	keyPair, err := LoadKeyPair(filepath.Join(dir, "accountKeystore"), filepath.Join(dir, "accountPassword"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, common.HexToAddress(string(expected)), keyPair.PublicKey)
+}
+
+func TestKeyPair_String(t *testing.T) {
+	privateKey, _ := crypto.GenerateKey()
+	keyPair := KeyPair{
+		PublicKey:  crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	secret := privateKey.D.String()
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		assert.NotContains(t, fmt.Sprintf(format, keyPair), secret)
+		assert.NotContains(t, fmt.Sprintf(format, &keyPair), secret)
+	}
+}
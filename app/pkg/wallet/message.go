@@ -0,0 +1,91 @@
+package wallet
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// HashMessage : EIP-191(personal_sign) 메시지 해시
+// keccak256("\x19Ethereum Signed Message:\n" + len(message) + message)
+func HashMessage(message []byte) []byte {
+	return accounts.TextHash(message)
+}
+
+// HashTypedData : EIP-712 typed data 해시
+// keccak256("\x19\x01" + domainSeparator + hashStruct(message))
+func HashTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return hash, nil
+}
+
+// RecoverMessageSigner : personal_sign 서명으로부터 서명자 주소 복원
+func RecoverMessageSigner(message, signature []byte) (common.Address, error) {
+	return recoverSigner(HashMessage(message), signature)
+}
+
+// RecoverTypedDataSigner : EIP-712 서명으로부터 서명자 주소 복원
+func RecoverTypedDataSigner(typedData apitypes.TypedData, signature []byte) (common.Address, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return recoverSigner(hash, signature)
+}
+
+// VerifyMessage : address가 message에 서명했는지 확인
+func VerifyMessage(address common.Address, message, signature []byte) bool {
+	signer, err := RecoverMessageSigner(message, signature)
+	return err == nil && signer == address
+}
+
+// VerifyTypedData : address가 typedData에 서명했는지 확인
+func VerifyTypedData(address common.Address, typedData apitypes.TypedData, signature []byte) bool {
+	signer, err := RecoverTypedDataSigner(typedData, signature)
+	return err == nil && signer == address
+}
+
+// signHash : 개인키로 해시에 서명하고 V를 27/28로 변환
+func signHash(keyPair KeyPair, hash []byte) ([]byte, error) {
+	signature, err := crypto.Sign(hash, keyPair.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// recoverSigner : V가 0/1 또는 27/28인 65 byte 서명 모두 허용
+// 서명 가변성(malleability)을 막기 위해 S는 secp256k1 N/2 이하만 허용
+func recoverSigner(hash, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, ErrInvalidSignature
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if !crypto.ValidateSignatureValues(sig[crypto.RecoveryIDOffset], r, s, true) {
+		return common.Address{}, ErrInvalidSignature
+	}
+
+	publicKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, ErrInvalidSignature
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
@@ -0,0 +1,99 @@
+package wallet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// EIP-712 명세의 예제 (https://eips.ethereum.org/EIPS/eip-712)
+var mailTypedData string = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": "1",
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func loadMailTypedData(t *testing.T) apitypes.TypedData {
+	var typedData apitypes.TypedData
+	err := json.Unmarshal([]byte(mailTypedData), &typedData)
+	assert.Equal(t, nil, err)
+	return typedData
+}
+
+func TestHashTypedData(t *testing.T) {
+	hash, err := HashTypedData(loadMailTypedData(t))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hexutil.Encode(hash))
+}
+
+func TestSignAndVerifyTypedData(t *testing.T) {
+	// 명세의 서명 키 : keccak256("cow")
+	privateKey, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	assert.Equal(t, nil, err)
+	keyPair := KeyPair{PublicKey: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	assert.Equal(t, common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), keyPair.PublicKey)
+
+	typedData := loadMailTypedData(t)
+	signature, err := keyPair.SignTypedData(typedData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+"1c", hexutil.Encode(signature))
+
+	assert.True(t, VerifyTypedData(keyPair.PublicKey, typedData, signature))
+
+	// 메시지가 바뀌면 다른 주소가 복원됨
+	typedData.Message["contents"] = "Hello, Alice!"
+	assert.False(t, VerifyTypedData(keyPair.PublicKey, typedData, signature))
+}
+
+func TestSignAndVerifyMessage(t *testing.T) {
+	keyPair := newTestKeyPair(t)
+	message := []byte("swap 10 E2B for 1 ERC1400")
+
+	signature, err := keyPair.SignMessage(message)
+	assert.Equal(t, nil, err)
+	assert.True(t, VerifyMessage(keyPair.PublicKey, message, signature))
+	assert.False(t, VerifyMessage(keyPair.PublicKey, []byte("swap 11 E2B for 1 ERC1400"), signature))
+
+	// V가 0/1인 서명도 허용
+	raw := make([]byte, len(signature))
+	copy(raw, signature)
+	raw[crypto.RecoveryIDOffset] -= 27
+	signer, err := RecoverMessageSigner(message, raw)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, keyPair.PublicKey, signer)
+
+	_, err = RecoverMessageSigner(message, signature[:64])
+	assert.Equal(t, ErrInvalidSignature, err)
+}
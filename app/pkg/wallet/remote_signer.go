@@ -0,0 +1,223 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const textPlainContentType = "text/plain"
+
+// SignTransactionResult : account_signTransaction 응답
+type SignTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+// RemoteSigner : Clef 호환 JSON-RPC(account_signTransaction, account_signData, account_signTypedData)로
+// 서명을 위임하는 Signer
+type RemoteSigner struct {
+	cli     *rpc.Client
+	address common.Address
+}
+
+// NewRemoteSigner : endpoint의 원격 서명자에 연결하고, address가 서명 가능한 계정인지 확인
+func NewRemoteSigner(endpoint string, address common.Address) (*RemoteSigner, error) {
+	cli, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []common.Address
+	if err := cli.CallContext(context.Background(), &addresses, "account_list"); err != nil {
+		cli.Close()
+		return nil, err
+	}
+	for _, managed := range addresses {
+		if managed == address {
+			return &RemoteSigner{cli: cli, address: address}, nil
+		}
+	}
+	cli.Close()
+	return nil, fmt.Errorf("remote signer does not manage account %s", address.Hex())
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *RemoteSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	case types.AccessListTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	default:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	// MixedcaseAddress는 포인터 리시버로만 JSON 인코딩되므로 포인터로 전달
+	var result SignTransactionResult
+	if err := s.cli.CallContext(context.Background(), &result, "account_signTransaction", &args); err != nil {
+		return nil, err
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(result.Raw); err != nil {
+		return nil, err
+	}
+
+	// 원격 서명자가 요청과 다른 트랜잭션이나 다른 계정으로 서명하지 않았는지 검증
+	txSigner := types.LatestSignerForChainID(chainID)
+	if txSigner.Hash(signedTx) != txSigner.Hash(tx) {
+		return nil, errors.New("remote signer returned a different transaction")
+	}
+	sender, err := types.Sender(txSigner, signedTx)
+	if err != nil {
+		return nil, err
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("remote signer signed with %s instead of %s", sender.Hex(), s.address.Hex())
+	}
+	return signedTx, nil
+}
+
+func (s *RemoteSigner) SignMessage(message []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	address := common.NewMixedcaseAddress(s.address)
+	err := s.cli.CallContext(context.Background(), &signature, "account_signData",
+		textPlainContentType, &address, hexutil.Encode(message))
+	if err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+func (s *RemoteSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	var signature hexutil.Bytes
+	address := common.NewMixedcaseAddress(s.address)
+	err := s.cli.CallContext(context.Background(), &signature, "account_signTypedData", &address, typedData)
+	if err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+// Close : 원격 서명자 연결 종료
+func (s *RemoteSigner) Close() {
+	s.cli.Close()
+}
+
+// SignerService : Signer 목록을 Clef 호환 "account" 네임스페이스로 노출
+// 로컬 개발/테스트에서 원격 서명자를 대신할 수 있음
+//
+//	server := rpc.NewServer()
+//	server.RegisterName("account", wallet.NewSignerService(chainID, signers...))
+type SignerService struct {
+	chainID *big.Int
+	signers map[common.Address]Signer
+}
+
+func NewSignerService(chainID *big.Int, signers ...Signer) *SignerService {
+	service := &SignerService{
+		chainID: chainID,
+		signers: make(map[common.Address]Signer, len(signers)),
+	}
+	for _, signer := range signers {
+		service.signers[signer.Address()] = signer
+	}
+	return service
+}
+
+// List : account_list
+func (s *SignerService) List(ctx context.Context) ([]common.Address, error) {
+	addresses := make([]common.Address, 0, len(s.signers))
+	for address := range s.signers {
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// SignTransaction : account_signTransaction
+func (s *SignerService) SignTransaction(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*SignTransactionResult, error) {
+	signer, exist := s.signers[args.From.Address()]
+	if !exist {
+		return nil, ErrAccountNotFound
+	}
+
+	chainID := s.chainID
+	if args.ChainID != nil {
+		if s.chainID != nil && s.chainID.Cmp(args.ChainID.ToInt()) != 0 {
+			return nil, fmt.Errorf("requested chainid %d does not match the configuration of the signer", args.ChainID.ToInt())
+		}
+		chainID = args.ChainID.ToInt()
+	}
+	if args.GasPrice == nil && args.MaxFeePerGas == nil {
+		return nil, errors.New("gasPrice or maxFeePerGas must be specified")
+	}
+
+	signedTx, err := signer.SignTx(args.ToTransaction(), chainID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &SignTransactionResult{Raw: raw, Tx: signedTx}, nil
+}
+
+// SignData : account_signData (text/plain 만 지원)
+func (s *SignerService) SignData(ctx context.Context, contentType string, addr common.MixedcaseAddress, data interface{}) (hexutil.Bytes, error) {
+	if contentType != textPlainContentType {
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+	signer, exist := s.signers[addr.Address()]
+	if !exist {
+		return nil, ErrAccountNotFound
+	}
+
+	text, ok := data.(string)
+	if !ok {
+		return nil, errors.New("data should be hex encoded string")
+	}
+	message, err := hexutil.Decode(text)
+	if err != nil {
+		return nil, err
+	}
+	return signer.SignMessage(message)
+}
+
+// SignTypedData : account_signTypedData
+func (s *SignerService) SignTypedData(ctx context.Context, addr common.MixedcaseAddress, typedData apitypes.TypedData) (hexutil.Bytes, error) {
+	signer, exist := s.signers[addr.Address()]
+	if !exist {
+		return nil, ErrAccountNotFound
+	}
+	return signer.SignTypedData(typedData)
+}
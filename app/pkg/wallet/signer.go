@@ -0,0 +1,106 @@
+package wallet
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer : 트랜잭션과 메시지 서명 방식을 추상화
+// 메모리 키(KeyPair), keystore, 원격 서명자(Clef 호환) 모두 같은 방식으로 사용
+type Signer interface {
+	// Address : 서명 계정 주소
+	Address() common.Address
+	// SignTx : chainID(EIP-155)를 포함하여 트랜잭션 서명
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// SignMessage : EIP-191(personal_sign) 형식으로 메시지 서명, V는 27/28
+	SignMessage(message []byte) ([]byte, error)
+	// SignTypedData : EIP-712 typed data 서명, V는 27/28
+	SignTypedData(typedData apitypes.TypedData) ([]byte, error)
+}
+
+// NewTransactOpts : Signer로 서명하는 bind.TransactOpts 생성
+func NewTransactOpts(signer Signer, chainID *big.Int) *bind.TransactOpts {
+	from := signer.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(tx, chainID)
+		},
+		Context: context.Background(),
+	}
+}
+
+// KeyPair는 메모리의 개인키로 서명하는 Signer
+func (k KeyPair) Address() common.Address {
+	return k.PublicKey
+}
+
+func (k KeyPair) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), k.PrivateKey)
+}
+
+func (k KeyPair) SignMessage(message []byte) ([]byte, error) {
+	return signHash(k, HashMessage(message))
+}
+
+func (k KeyPair) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return signHash(k, hash)
+}
+
+// keystoreSigner : Manager에서 잠금 해제된 계정으로 서명하는 Signer
+type keystoreSigner struct {
+	manager *Manager
+	account accounts.Account
+}
+
+// Signer : 계정의 Signer 반환 (서명 전에 Unlock 필요)
+func (m *Manager) Signer(address common.Address) (Signer, error) {
+	account, err := m.find(address)
+	if err != nil {
+		return nil, err
+	}
+	return &keystoreSigner{manager: m, account: account}, nil
+}
+
+func (s *keystoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *keystoreSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.manager.ks.SignTx(s.account, tx, chainID)
+}
+
+func (s *keystoreSigner) SignMessage(message []byte) ([]byte, error) {
+	return s.signHash(HashMessage(message))
+}
+
+func (s *keystoreSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return s.signHash(hash)
+}
+
+func (s *keystoreSigner) signHash(hash []byte) ([]byte, error) {
+	signature, err := s.manager.ks.SignHash(s.account, hash)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
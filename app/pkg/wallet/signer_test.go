@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+)
+
+var testChainID = big.NewInt(10)
+
+func newTestKeyPair(t *testing.T) *KeyPair {
+	privateKey, err := crypto.GenerateKey()
+	assert.Equal(t, nil, err)
+	return &KeyPair{
+		PublicKey:  crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+}
+
+// newSignerServer : Clef 대신 SignerService를 노출하는 로컬 JSON-RPC 서버
+func newSignerServer(t *testing.T, signers ...Signer) string {
+	server := rpc.NewServer()
+	err := server.RegisterName("account", NewSignerService(testChainID, signers...))
+	assert.Equal(t, nil, err)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func assertSigner(t *testing.T, signer Signer) {
+	to := common.HexToAddress("0xb5ff8c7f64c1cfddb68edc1006dd5c58b07e1448")
+	txs := []*types.Transaction{
+		types.NewTransaction(3, to, big.NewInt(1), 21000, big.NewInt(1), []byte{0x01}),
+		types.NewTx(&types.DynamicFeeTx{
+			ChainID:   testChainID,
+			Nonce:     4,
+			To:        &to,
+			Gas:       21000,
+			GasFeeCap: big.NewInt(2),
+			GasTipCap: big.NewInt(1),
+			Value:     big.NewInt(1),
+		}),
+	}
+
+	for _, tx := range txs {
+		signedTx, err := signer.SignTx(tx, testChainID)
+		assert.Equal(t, nil, err)
+
+		sender, err := types.Sender(types.LatestSignerForChainID(testChainID), signedTx)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, signer.Address(), sender)
+		assert.Equal(t, tx.Nonce(), signedTx.Nonce())
+	}
+
+	message := []byte("hello quorum")
+	signature, err := signer.SignMessage(message)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 65, len(signature))
+
+	assert.True(t, VerifyMessage(signer.Address(), message, signature))
+
+	var typedData apitypes.TypedData
+	err = json.Unmarshal([]byte(mailTypedData), &typedData)
+	assert.Equal(t, nil, err)
+	signature, err = signer.SignTypedData(typedData)
+	assert.Equal(t, nil, err)
+	assert.True(t, VerifyTypedData(signer.Address(), typedData, signature))
+}
+
+func TestKeyPairSigner(t *testing.T) {
+	assertSigner(t, newTestKeyPair(t))
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	manager := NewManager(t.TempDir(), true)
+	address, err := manager.NewAccount("secret")
+	assert.Equal(t, nil, err)
+
+	signer, err := manager.Signer(address)
+	assert.Equal(t, nil, err)
+
+	// 잠금 해제 전에는 서명 불가
+	_, err = signer.SignMessage([]byte("locked"))
+	assert.NotEqual(t, nil, err)
+
+	assert.Equal(t, nil, manager.Unlock(address, "secret", 0))
+	assertSigner(t, signer)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	keyPair := newTestKeyPair(t)
+	endpoint := newSignerServer(t, keyPair)
+
+	signer, err := NewRemoteSigner(endpoint, keyPair.PublicKey)
+	assert.Equal(t, nil, err)
+	defer signer.Close()
+	assertSigner(t, signer)
+
+	// 원격 서명자가 관리하지 않는 계정은 연결 단계에서 거부
+	_, err = NewRemoteSigner(endpoint, common.HexToAddress("0x01"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestNewTransactOpts(t *testing.T) {
+	keyPair := newTestKeyPair(t)
+	auth := NewTransactOpts(keyPair, testChainID)
+	assert.Equal(t, keyPair.PublicKey, auth.From)
+
+	tx := types.NewTransaction(0, keyPair.PublicKey, big.NewInt(0), 21000, big.NewInt(1), nil)
+	_, err := auth.Signer(keyPair.PublicKey, tx)
+	assert.Equal(t, nil, err)
+
+	_, err = auth.Signer(common.HexToAddress("0x01"), tx)
+	assert.NotEqual(t, nil, err)
+}